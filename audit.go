@@ -0,0 +1,417 @@
+package p2pd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/libp2p/go-libp2p-daemon/config"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	multierror "github.com/hashicorp/go-multierror"
+	cid "github.com/ipfs/go-cid"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// auditLog appends an entry for each control request changing the daemon's
+// state to a file, as JSON lines, rotating it when it grows too big.
+type auditLog struct {
+	c config.Audit
+
+	mx sync.Mutex
+	// f is nil after a failed rotation, until it is reopened by the next
+	// write
+	f      *os.File
+	size   int64
+	closed bool
+}
+
+type auditEntry struct {
+	Time    time.Time      `json:"time"`
+	Client  auditClient    `json:"client"`
+	ID      *uint64        `json:"id,omitempty"`
+	Type    string         `json:"type"`
+	Subtype string         `json:"subtype,omitempty"`
+	Params  map[string]any `json:"params,omitempty"`
+	Outcome string         `json:"outcome"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// auditClient identifies a client by the credentials of its process if it
+// connects over a unix socket and the platform provides them, and by its
+// address otherwise.
+type auditClient struct {
+	PID  *int   `json:"pid,omitempty"`
+	UID  *int   `json:"uid,omitempty"`
+	Addr string `json:"addr,omitempty"`
+}
+
+const (
+	outcomeOK          = "ok"
+	outcomeError       = "error"
+	outcomeRateLimited = "rate_limited"
+	outcomeCancelled   = "cancelled"
+)
+
+// audit log operations, for metrics
+const (
+	auditOpWrite  = "write"
+	auditOpRotate = "rotate"
+	auditOpReopen = "reopen"
+)
+
+// EnableAudit starts appending an entry for each control request that
+// connects to or disconnects from peers, reserves or cancels relay slots, opens
+// streams, registers handlers, publishes, subscribes, puts or provides DHT
+// records, tags peers, blocks or unblocks peers and subnets, signs with the
+// daemon's key, or adds signed peer records, to the audit log configured by c.
+// The log is closed with the daemon.
+func (d *Daemon) EnableAudit(c config.Audit) error {
+	a := &auditLog{c: c}
+	if err := a.open(); err != nil {
+		return err
+	}
+
+	d.mx.Lock()
+	defer d.mx.Unlock()
+	if d.audit != nil {
+		if err := d.audit.close(); err != nil {
+			log.Warnw("error closing audit log", "error", err)
+		}
+	}
+	d.audit = a
+	return nil
+}
+
+func newAuditClient(c net.Conn) auditClient {
+	if pid, uid, ok := peerCredentials(c); ok {
+		return auditClient{PID: &pid, UID: &uid}
+	}
+	if addr := c.RemoteAddr(); addr != nil {
+		return auditClient{Addr: addr.Network() + ":" + addr.String()}
+	}
+	return auditClient{}
+}
+
+// record appends the entry of req, answered with res, if req is audited.
+func (a *auditLog) record(client auditClient, req *pb.Request, res *pb.Response) {
+	params, ok := auditParams(req)
+	if !ok {
+		return
+	}
+
+	e := auditEntry{
+		Time:    time.Now().UTC(),
+		Client:  client,
+		ID:      req.Id,
+		Type:    req.GetType().String(),
+		Subtype: requestSubtype(req),
+		Params:  params,
+		Outcome: outcomeOK,
+	}
+	if res.GetType() == pb.Response_ERROR {
+		switch res.GetError().GetCode() {
+		case pb.ErrorResponse_RATE_LIMITED:
+			e.Outcome = outcomeRateLimited
+		case pb.ErrorResponse_CANCELLED:
+			e.Outcome = outcomeCancelled
+		default:
+			e.Outcome = outcomeError
+		}
+		e.Error = res.GetError().GetMsg()
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		log.Warnw("error encoding audit entry", "error", err)
+		return
+	}
+	line = append(line, '\n')
+
+	if err := a.write(line); err != nil {
+		log.Warnw("error writing audit entry", "error", err)
+	}
+}
+
+// write appends line to the log, rotating it first if it is too big. If the
+// rotation fails, or failed before, the log is reopened so that entries keep
+// being recorded.
+func (a *auditLog) write(line []byte) error {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+
+	if a.closed {
+		return fmt.Errorf("audit log closed")
+	}
+
+	var merr *multierror.Error
+	if a.f != nil && a.c.MaxSize > 0 && a.size > 0 && a.size+int64(len(line)) > a.c.MaxSize {
+		if err := a.rotate(); err != nil {
+			auditFailures.WithLabelValues(auditOpRotate).Inc()
+			merr = multierror.Append(merr, fmt.Errorf("rotating audit log: %w", err))
+		}
+	}
+	if a.f == nil {
+		if err := a.open(); err != nil {
+			auditFailures.WithLabelValues(auditOpReopen).Inc()
+			return multierror.Append(merr, fmt.Errorf("reopening audit log: %w", err))
+		}
+	}
+
+	n, err := a.f.Write(line)
+	a.size += int64(n)
+	if err != nil {
+		auditFailures.WithLabelValues(auditOpWrite).Inc()
+		merr = multierror.Append(merr, err)
+	}
+	return merr.ErrorOrNil()
+}
+
+// open opens File for appending; a.mx must be held, if a is shared.
+func (a *auditLog) open() error {
+	f, err := os.OpenFile(a.c.File, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	a.f = f
+	a.size = fi.Size()
+	return nil
+}
+
+// rotate moves the log to File.1, shifting the older ones up to MaxBackups
+// and dropping the oldest, and starts a new one; a.mx must be held. The log
+// is left closed if rotate fails.
+func (a *auditLog) rotate() error {
+	err := a.f.Close()
+	a.f = nil
+	if err != nil {
+		return err
+	}
+
+	path := a.c.File
+	if a.c.MaxBackups == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	} else {
+		for i := a.c.MaxBackups - 1; i > 0; i-- {
+			err := os.Rename(fmt.Sprintf("%s.%d", path, i), fmt.Sprintf("%s.%d", path, i+1))
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		if err := os.Rename(path, path+".1"); err != nil {
+			return err
+		}
+	}
+
+	return a.open()
+}
+
+func (a *auditLog) close() error {
+	a.mx.Lock()
+	defer a.mx.Unlock()
+
+	a.closed = true
+	if a.f == nil {
+		return nil
+	}
+	var merr *multierror.Error
+	if err := a.f.Sync(); err != nil {
+		merr = multierror.Append(merr, err)
+	}
+	if err := a.f.Close(); err != nil {
+		merr = multierror.Append(merr, err)
+	}
+	a.f = nil
+	return merr.ErrorOrNil()
+}
+
+// auditParams returns the key parameters of req if it is audited. Payloads
+// are logged by size only.
+func auditParams(req *pb.Request) (map[string]any, bool) {
+	switch req.GetType() {
+	case pb.Request_CONNECT:
+		if req.Connect == nil {
+			return nil, true
+		}
+		return map[string]any{
+			"peer":  auditPeer(req.Connect.Peer),
+			"addrs": auditAddrs(req.Connect.Addrs),
+		}, true
+
+	case pb.Request_DISCONNECT:
+		if req.Disconnect == nil {
+			return nil, true
+		}
+		return map[string]any{"peer": auditPeer(req.Disconnect.Peer)}, true
+
+	case pb.Request_DIRECT_CONNECT:
+		if req.DirectConnect == nil {
+			return nil, true
+		}
+		return map[string]any{"peer": auditPeer(req.DirectConnect.Peer)}, true
+
+	case pb.Request_RELAY_RESERVE:
+		if req.RelayReserve == nil {
+			return nil, true
+		}
+		return map[string]any{
+			"peer":  auditPeer(req.RelayReserve.Peer),
+			"addrs": auditAddrs(req.RelayReserve.Addrs),
+		}, true
+
+	case pb.Request_RELAY_CANCEL:
+		if req.RelayCancel == nil {
+			return nil, true
+		}
+		return map[string]any{"peer": auditPeer(req.RelayCancel.Peer)}, true
+
+	case pb.Request_STREAM_OPEN:
+		if req.StreamOpen == nil {
+			return nil, true
+		}
+		return map[string]any{
+			"peer":  auditPeer(req.StreamOpen.Peer),
+			"proto": req.StreamOpen.Proto,
+		}, true
+
+	case pb.Request_STREAM_HANDLER:
+		if req.StreamHandler == nil {
+			return nil, true
+		}
+		params := map[string]any{"proto": req.StreamHandler.Proto}
+		if req.StreamHandler.GetPassFd() {
+			params["passFd"] = true
+		} else {
+			params["addr"] = auditAddr(req.StreamHandler.Addr)
+		}
+		return params, true
+
+	case pb.Request_DHT:
+		switch req.Dht.GetType() {
+		case pb.DHTRequest_PUT_VALUE:
+			return map[string]any{
+				"key":       auditKey(req.Dht.Key),
+				"valueSize": len(req.Dht.Value),
+			}, true
+		case pb.DHTRequest_PROVIDE:
+			return map[string]any{"cid": auditCid(req.Dht.Cid)}, true
+		}
+
+	case pb.Request_PUBSUB:
+		switch req.Pubsub.GetType() {
+		case pb.PSRequest_PUBLISH:
+			return map[string]any{
+				"topic":    req.Pubsub.GetTopic(),
+				"dataSize": len(req.Pubsub.Data),
+			}, true
+		case pb.PSRequest_SUBSCRIBE:
+			return map[string]any{"topic": req.Pubsub.GetTopic()}, true
+		}
+
+	case pb.Request_CONNMANAGER:
+		if req.ConnManager == nil {
+			return nil, true
+		}
+		params := make(map[string]any)
+		if req.ConnManager.Peer != nil {
+			params["peer"] = auditPeer(req.ConnManager.Peer)
+		}
+		if req.ConnManager.Tag != nil {
+			params["tag"] = req.ConnManager.GetTag()
+		}
+		if req.ConnManager.Weight != nil {
+			params["weight"] = req.ConnManager.GetWeight()
+		}
+		return params, true
+
+	case pb.Request_SIGN:
+		if req.Sign == nil {
+			return nil, true
+		}
+		return map[string]any{
+			"domain":   req.Sign.GetDomain(),
+			"dataSize": len(req.Sign.Data),
+		}, true
+
+	case pb.Request_SEAL_ENVELOPE:
+		if req.SealEnvelope == nil {
+			return nil, true
+		}
+		return map[string]any{
+			"domain":      req.SealEnvelope.GetDomain(),
+			"payloadType": auditKey(req.SealEnvelope.PayloadType),
+			"payloadSize": len(req.SealEnvelope.Payload),
+		}, true
+
+	case pb.Request_ADD_PEER_RECORD:
+		if req.AddPeerRecord == nil {
+			return nil, true
+		}
+		params := map[string]any{"envelopeSize": len(req.AddPeerRecord.Envelope)}
+		if req.AddPeerRecord.Ttl != nil {
+			params["ttl"] = req.AddPeerRecord.GetTtl()
+		}
+		return params, true
+
+	case pb.Request_CONNGATER:
+		switch req.ConnGater.GetType() {
+		case pb.ConnGaterRequest_BLOCK_PEER, pb.ConnGaterRequest_UNBLOCK_PEER:
+			return map[string]any{"peer": auditPeer(req.ConnGater.Peer)}, true
+		case pb.ConnGaterRequest_BLOCK_SUBNET, pb.ConnGaterRequest_UNBLOCK_SUBNET:
+			return map[string]any{"subnet": req.ConnGater.GetSubnet()}, true
+		}
+	}
+
+	return nil, false
+}
+
+func auditPeer(b []byte) string {
+	if p, err := peer.IDFromBytes(b); err == nil {
+		return p.String()
+	}
+	return hex.EncodeToString(b)
+}
+
+func auditAddr(b []byte) string {
+	if a, err := ma.NewMultiaddrBytes(b); err == nil {
+		return a.String()
+	}
+	return hex.EncodeToString(b)
+}
+
+func auditAddrs(bs [][]byte) []string {
+	addrs := make([]string, 0, len(bs))
+	for _, b := range bs {
+		addrs = append(addrs, auditAddr(b))
+	}
+	return addrs
+}
+
+func auditCid(b []byte) string {
+	if c, err := cid.Cast(b); err == nil {
+		return c.String()
+	}
+	return hex.EncodeToString(b)
+}
+
+// auditKey returns DHT keys as text when they are, and in hex otherwise.
+func auditKey(b []byte) string {
+	if utf8.Valid(b) {
+		return string(b)
+	}
+	return "0x" + hex.EncodeToString(b)
+}
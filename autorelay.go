@@ -0,0 +1,42 @@
+package p2pd
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// relayCandidates sends AutoRelay up to num of the peers closest to the
+// daemon in the DHT, which it tries as relays.
+func (d *Daemon) relayCandidates(ctx context.Context, num int) <-chan peer.AddrInfo {
+	out := make(chan peer.AddrInfo, num)
+	go func() {
+		defer close(out)
+		// AutoRelay starts with the host, before d.host is set
+		if d.dht == nil {
+			return
+		}
+
+		peers, err := d.dht.GetClosestPeers(ctx, string(d.dht.PeerID()))
+		if err != nil {
+			log.Debugw("error finding relay candidates", "error", err)
+			return
+		}
+		for _, p := range peers {
+			if num == 0 {
+				return
+			}
+			pi := d.dht.Host().Peerstore().PeerInfo(p)
+			if len(pi.Addrs) == 0 {
+				continue
+			}
+			select {
+			case out <- pi:
+				num--
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
@@ -38,6 +38,11 @@ func (d *Daemon) Bootstrap() error {
 	}
 
 	count := d.connectBootstrapPeers(pis, BootstrapConnections)
+	if count < BootstrapConnections {
+		// peers known from a persistent peerstore help rejoining the network
+		// when the bootstrap peers are out of reach
+		count += d.connectBootstrapPeers(d.knownPeerInfos(pis), BootstrapConnections-count)
+	}
 	if count == 0 {
 		return fmt.Errorf("failed to connect to bootstrap peers")
 	}
@@ -51,6 +56,26 @@ func (d *Daemon) Bootstrap() error {
 	return nil
 }
 
+// knownPeerInfos returns the peers in the peerstore with addresses, other
+// than the bootstrap peers.
+func (d *Daemon) knownPeerInfos(bootstrap []peer.AddrInfo) []peer.AddrInfo {
+	skip := make(map[peer.ID]struct{}, len(bootstrap)+1)
+	skip[d.ID()] = struct{}{}
+	for _, pi := range bootstrap {
+		skip[pi.ID] = struct{}{}
+	}
+
+	pstore := d.host.Peerstore()
+	var pis []peer.AddrInfo
+	for _, p := range pstore.PeersWithAddrs() {
+		if _, ok := skip[p]; ok {
+			continue
+		}
+		pis = append(pis, pstore.PeerInfo(p))
+	}
+	return pis
+}
+
 func (d *Daemon) connectBootstrapPeers(pis []peer.AddrInfo, toconnect int) int {
 	count := 0
 
@@ -0,0 +1,136 @@
+package p2pd
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	ggio "github.com/gogo/protobuf/io"
+)
+
+// connWatcher watches a control connection while a request is in flight,
+// cancelling the request when the client disconnects or sends a CANCEL
+// request for it.
+//
+// It waits for data by peeking into the connection's buffered reader, which
+// consumes nothing, so that it can be stopped with a read deadline without
+// losing any of what the client sends next.
+type connWatcher struct {
+	c      net.Conn
+	br     *bufio.Reader
+	r      ggio.Reader
+	req    *pb.Request
+	cancel context.CancelFunc
+
+	mx       sync.Mutex
+	stopping bool
+	reading  bool
+
+	done chan struct{}
+	// the fields below are set by the watcher before done is closed
+
+	// cancelled is set when the client cancelled the request
+	cancelled bool
+	// next is a request the client sent ahead of the response
+	next *pb.Request
+	// err is the error reading from the connection
+	err error
+}
+
+// watchConn starts watching c, whose requests are read with r through br,
+// while req is in flight. The returned context is cancelled if the client
+// disconnects or cancels req.
+func watchConn(ctx context.Context, c net.Conn, br *bufio.Reader, r ggio.Reader, req *pb.Request) (context.Context, *connWatcher) {
+	ctx, cancel := context.WithCancel(ctx)
+	cw := &connWatcher{
+		c:      c,
+		br:     br,
+		r:      r,
+		req:    req,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go cw.watch()
+	return ctx, cw
+}
+
+func (cw *connWatcher) watch() {
+	defer close(cw.done)
+
+	for {
+		_, err := cw.br.Peek(1)
+
+		cw.mx.Lock()
+		if cw.stopping {
+			cw.mx.Unlock()
+			return
+		}
+		if err != nil {
+			cw.mx.Unlock()
+			log.Debugw("client went away", "error", err)
+			cw.err = err
+			cw.cancel()
+			return
+		}
+		cw.reading = true
+		cw.mx.Unlock()
+
+		req := new(pb.Request)
+		err = cw.r.ReadMsg(req)
+		if err != nil {
+			log.Debugw("error reading message", "error", err)
+			cw.err = err
+			cw.cancel()
+			return
+		}
+
+		if req.GetType() != pb.Request_CANCEL {
+			// requests are handled one at a time; this one is handled next
+			cw.next = req
+			return
+		}
+		if cw.req.Id != nil && req.GetCancel().GetId() == cw.req.GetId() {
+			log.Debugw("request cancelled", "id", cw.req.GetId())
+			cw.cancelled = true
+			cw.cancel()
+		}
+
+		cw.mx.Lock()
+		cw.reading = false
+		// finish didn't wake us up if it was called while we were reading
+		stopping := cw.stopping
+		cw.mx.Unlock()
+		if stopping {
+			return
+		}
+	}
+}
+
+// finish stops watching the connection and releases the request's context.
+// It returns the request the client sent next, if any, or the error reading
+// from the connection if the client went away. If the client cancelled the
+// request and res is an error, res is marked as such.
+func (cw *connWatcher) finish(res *pb.Response) (*pb.Request, error) {
+	cw.mx.Lock()
+	cw.stopping = true
+	reading := cw.reading
+	cw.mx.Unlock()
+
+	if !reading {
+		// wake up the watcher if it's waiting for data; if it's reading a
+		// message instead, it returns once the message is read
+		cw.c.SetReadDeadline(time.Now())
+	}
+	<-cw.done
+	cw.c.SetReadDeadline(time.Time{})
+	cw.cancel()
+
+	if cw.cancelled && res.GetType() == pb.Response_ERROR {
+		res.Error.Code = pb.ErrorResponse_CANCELLED.Enum()
+	}
+	return cw.next, cw.err
+}
@@ -14,7 +14,11 @@ type JSONMaddr struct {
 }
 
 func (jm *JSONMaddr) UnmarshalJSON(b []byte) error {
-	ma, err := multiaddr.NewMultiaddr(string(b))
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	ma, err := multiaddr.NewMultiaddr(s)
 	if err != nil {
 		return err
 	}
@@ -24,10 +28,19 @@ func (jm *JSONMaddr) UnmarshalJSON(b []byte) error {
 
 type MaddrArray []multiaddr.Multiaddr
 
+// UnmarshalJSON accepts either an array of multiaddr strings or a single
+// string of comma separated multiaddrs.
 func (maa *MaddrArray) UnmarshalJSON(b []byte) error {
-	maStrings := strings.Split(string(b), ",")
+	var maStrings []string
+	if err := json.Unmarshal(b, &maStrings); err != nil {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		maStrings = strings.Split(s, ",")
+	}
 	*maa = make(MaddrArray, len(maStrings))
-	for i, s := range strings.Split(string(b), ",") {
+	for i, s := range maStrings {
 		ma, err := multiaddr.NewMultiaddr(s)
 		if err != nil {
 			return err
@@ -63,16 +76,179 @@ type PubSub struct {
 }
 
 type Relay struct {
-	Enabled   bool
+	// Enabled starts a circuit relay v2 service, relaying connections to
+	// the peers reserving slots on it
+	Enabled bool
+	// Active, Hop, Discovery and HopLimit are circuit relay v1 options,
+	// which have no effect
 	Active    bool
 	Hop       bool
 	Discovery bool
-	Auto      bool
 	HopLimit  int
+	// Auto reserves slots on relays when the daemon is not publicly
+	// reachable, and advertises its addresses through them
+	Auto bool
+	// StaticRelays are the /p2p multiaddrs of the relays Auto uses; if
+	// there are none, it looks for relays through the DHT
+	StaticRelays MaddrArray
+	// Service limits the resources of the relay service, and the peers
+	// reserving slots on it
+	Service RelayService
+}
+
+type RelayService struct {
+	// ReservationTTL is how long a reservation lasts unless refreshed
+	ReservationTTL time.Duration
+	// MaxReservations is the number of slots the relay has
+	MaxReservations int
+	// MaxCircuits is the number of relayed connections to each peer open at
+	// once
+	MaxCircuits int
+	// BufferSize is the size of the buffers of relayed connections, in bytes
+	BufferSize int
+	// MaxReservationsPerPeer, MaxReservationsPerIP and
+	// MaxReservationsPerASN are the number of slots reserved from the same
+	// peer, IP address and autonomous system
+	MaxReservationsPerPeer int
+	MaxReservationsPerIP   int
+	MaxReservationsPerASN  int
+	// CircuitDuration is how long a relayed connection stays open, and
+	// CircuitData how many bytes are relayed in each of its directions,
+	// before it is reset; both are unlimited if 0
+	CircuitDuration time.Duration
+	CircuitData     int64
+	// AllowedPeers and AllowedSubnets, in CIDR notation such as 10.0.0.0/8,
+	// if any, are the only peers allowed to reserve slots
+	AllowedPeers   []string
+	AllowedSubnets []string
+}
+
+type ResourceManager struct {
+	// Limits is a JSON file of resource manager limits overriding the
+	// defaults, for the system, transient, service, protocol, peer, conn
+	// and stream scopes
+	Limits string
+	// Allowlist holds multiaddrs of peers not subject to the system and
+	// transient limits, such as /ip4/1.2.3.4/ipcidr/8 or /ip4/1.2.3.4/p2p/<id>
+	Allowlist MaddrArray
+}
+
+type RateLimitRule struct {
+	// Rate is the sustained number of requests per second; 0 for no limit
+	Rate float64
+	// Burst is the number of requests that can be made at once
+	Burst int
+}
+
+type RateLimit struct {
+	Enabled bool
+	// Default limits all the control requests of each client
+	Default RateLimitRule
+	// Requests limits the requests of each client by type, or by type and
+	// sub-type, such as CONNECT or DHT.PUT_VALUE
+	Requests map[string]RateLimitRule
+	// MaxDHTQueries caps the DHT requests each client can have in flight;
+	// 0 for no cap
+	MaxDHTQueries int
+}
+
+type Peerstore struct {
+	// Path is a directory where the peerstore persists across restarts; it
+	// is kept in memory if empty
+	Path string
+	// GCInterval is how often expired addresses are purged from the
+	// persisted peerstore
+	GCInterval time.Duration
+}
+
+type ConnectionGater struct {
+	// BlockedPeers are the IDs of peers the daemon doesn't connect with
+	BlockedPeers []string
+	// AllowedPeers, if any, are the IDs of the only peers the daemon connects
+	// with
+	AllowedPeers []string
+	// BlockedSubnets are the IP ranges, in CIDR notation such as 10.0.0.0/8,
+	// the daemon doesn't connect with
+	BlockedSubnets []string
+	// File is a JSON file where the blocks made at runtime persist across
+	// restarts; they don't if empty
+	File string
+}
+
+type Transport struct {
+	Enabled bool
+}
+
+type TCPTransport struct {
+	Enabled bool
+	// Reuseport makes outbound connections use the port listened on, which
+	// helps with NAT traversal
+	Reuseport bool
+	// ConnectTimeout bounds how long dialing a peer takes; the transport's
+	// default if 0
+	ConnectTimeout time.Duration
+}
+
+type WebSocketTransport struct {
+	Enabled bool
+	// TLSCert and TLSKey are PEM files of a certificate and its key, used
+	// to listen on secure WebSocket addresses such as /ip4/0.0.0.0/tcp/443/tls/ws
+	TLSCert string
+	TLSKey  string
+}
+
+type Transports struct {
+	TCP          TCPTransport
+	QUIC         Transport
+	WebSocket    WebSocketTransport
+	WebTransport Transport
+	WebRTC       Transport
+	// Muxers are the stream muxers offered, in order of preference; yamux
+	// or mplex
+	Muxers []string
+}
+
+type MDNS struct {
+	// Enabled discovers peers on the local network with mDNS, announcing
+	// the daemon to them
+	Enabled bool
+	// ServiceName is the mDNS service the daemon announces and browses;
+	// only peers using the same name discover each other
+	ServiceName string
+	// AutoConnect connects to the peers discovered
+	AutoConnect bool
+}
+
+type PrivateNetwork struct {
+	// KeyFile is a swarm.key file holding the pre-shared key of a private
+	// network; the daemon only connects with peers sharing the key
+	KeyFile string
+}
+
+type IDGenerate struct {
+	// Enabled makes the daemon generate a key and write it to ID if there
+	// is no file there yet, so that the daemon keeps its peer ID across
+	// restarts
+	Enabled bool
+	// Type is the type of generated keys: rsa, ed25519, secp256k1 or ecdsa
+	Type string
+	// Bits is the size of generated RSA keys
+	Bits int
 }
 
 type DHT struct {
-	Mode string
+	Mode      string
+	Datastore DHTDatastore
+}
+
+type DHTDatastore struct {
+	// Path is a directory where the records and provider records the DHT
+	// stores persist across restarts; they are kept in memory if empty
+	Path string
+	// Backend is the kind of datastore kept in Path
+	Backend string
+	// GCInterval is how often expired records are purged
+	GCInterval time.Duration
 }
 
 type PProf struct {
@@ -86,22 +262,66 @@ type Security struct {
 	Plaintext bool
 }
 
+type Tracing struct {
+	Enabled bool
+	// Endpoint is the host:port of an OTLP/HTTP collector to export spans to
+	Endpoint string
+	// Insecure disables TLS when exporting to Endpoint
+	Insecure bool
+	// File is a file to write spans to as JSON, one per line
+	File string
+	// SampleRatio is the fraction of traces started by the daemon to sample
+	SampleRatio float64
+}
+
+type Diagnostics struct {
+	// File is where SIGUSR1 writes diagnostics snapshots; stdout if empty
+	File string
+}
+
+type Audit struct {
+	Enabled bool
+	// File is the file audit entries are appended to, as JSON lines
+	File string
+	// MaxSize is the size in bytes past which File is rotated; 0 to never
+	// rotate it
+	MaxSize int64
+	// MaxBackups is the number of rotated files kept, as File.1, File.2 and
+	// so on, the lowest being the most recent
+	MaxBackups int
+}
+
 const DHTFullMode = "full"
 const DHTClientMode = "client"
 const DHTServerMode = "server"
 
+const DHTDatastoreLevelDB = "leveldb"
+
+const DefaultMDNSServiceName = "_p2p._udp"
+
+const MuxerYamux = "yamux"
+const MuxerMplex = "mplex"
+
 type Config struct {
 	ListenAddr        JSONMaddr
 	Quiet             bool
 	ID                string
+	IDPassphraseFile  string
+	IDGenerate        IDGenerate
 	Bootstrap         Bootstrap
 	DHT               DHT
 	ConnectionManager ConnectionManager
-	QUIC              bool
+	Transports        Transports
 	NatPortMap        bool
 	PubSub            PubSub
 	Relay             Relay
 	AutoNat           bool
+	// ForceReachabilityPublic and ForceReachabilityPrivate skip AutoNAT,
+	// assuming the daemon is publicly reachable or not
+	ForceReachabilityPublic  bool
+	ForceReachabilityPrivate bool
+	// HolePunching upgrades relayed connections to direct ones through NATs
+	HolePunching      bool
 	HostAddresses     MaddrArray
 	AnnounceAddresses MaddrArray
 	NoListen          bool
@@ -109,6 +329,15 @@ type Config struct {
 	PProf             PProf
 	Security          Security
 	Echo              bool
+	Tracing           Tracing
+	Diagnostics       Diagnostics
+	ResourceManager   ResourceManager
+	RateLimit         RateLimit
+	Audit             Audit
+	ConnectionGater   ConnectionGater
+	Peerstore         Peerstore
+	PrivateNetwork    PrivateNetwork
+	MDNS              MDNS
 }
 
 func (c *Config) UnmarshalJSON(b []byte) error {
@@ -131,10 +360,77 @@ func (c *Config) UnmarshalJSON(b []byte) error {
 
 func (c *Config) Validate() error {
 	if c.DHT.Mode != DHTClientMode && c.DHT.Mode != DHTFullMode && c.DHT.Mode != DHTServerMode && c.DHT.Mode != "" {
-		return fmt.Errorf("unknown DHT mode %s", c.DHT)
+		return fmt.Errorf("unknown DHT mode %s", c.DHT.Mode)
+	}
+	if c.IDGenerate.Enabled && c.ID == "" {
+		return fmt.Errorf("can't generate an identity without an ID path to write it to")
+	}
+	if c.DHT.Datastore.Backend != DHTDatastoreLevelDB {
+		return fmt.Errorf("unknown DHT datastore backend %s", c.DHT.Datastore.Backend)
+	}
+	if c.DHT.Datastore.GCInterval <= 0 {
+		return fmt.Errorf("DHT datastore GCInterval must be positive")
+	}
+	if c.Relay.Auto && len(c.Relay.StaticRelays) == 0 && c.DHT.Mode == "" {
+		return fmt.Errorf("can't have autorelay enabled without static relays or DHT enabled")
+	}
+	for _, a := range c.Relay.StaticRelays {
+		if _, err := a.ValueForProtocol(multiaddr.P_P2P); err != nil {
+			return fmt.Errorf("static relay %s has no peer ID", a)
+		}
+	}
+	if c.ForceReachabilityPublic && c.ForceReachabilityPrivate {
+		return fmt.Errorf("can't force reachability to both public and private")
 	}
-	if c.Relay.Auto && (!c.Relay.Enabled || c.DHT.Mode == "") {
-		return fmt.Errorf("can't have autorelay enabled without Relay enabled and DHT enabled")
+	if err := c.Relay.Service.validate(); err != nil {
+		return err
+	}
+	if c.Tracing.Enabled && c.Tracing.Endpoint == "" && c.Tracing.File == "" {
+		return fmt.Errorf("can't have tracing enabled without an endpoint or file to export to")
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("tracing sample ratio must be between 0 and 1")
+	}
+	rules := map[string]RateLimitRule{"Default": c.RateLimit.Default}
+	for k, r := range c.RateLimit.Requests {
+		rules[k] = r
+	}
+	for k, r := range rules {
+		if r.Rate < 0 {
+			return fmt.Errorf("rate limit %s has a negative rate", k)
+		}
+		if r.Rate > 0 && r.Burst < 1 {
+			return fmt.Errorf("rate limit %s must allow a burst of at least 1", k)
+		}
+	}
+	if c.RateLimit.MaxDHTQueries < 0 {
+		return fmt.Errorf("rate limit MaxDHTQueries can't be negative")
+	}
+	if c.Audit.Enabled && c.Audit.File == "" {
+		return fmt.Errorf("can't have the audit log enabled without a file to write to")
+	}
+	if c.Audit.MaxSize < 0 || c.Audit.MaxBackups < 0 {
+		return fmt.Errorf("audit log MaxSize and MaxBackups can't be negative")
+	}
+	if c.Peerstore.Path != "" && c.Peerstore.GCInterval <= 0 {
+		return fmt.Errorf("peerstore GCInterval must be positive")
+	}
+	if c.MDNS.Enabled && c.MDNS.ServiceName == "" {
+		return fmt.Errorf("can't have mDNS enabled without a service name")
+	}
+	if err := c.Transports.validate(); err != nil {
+		return err
+	}
+	if c.PrivateNetwork.KeyFile != "" {
+		t := c.Transports
+		if t.QUIC.Enabled || t.WebTransport.Enabled || t.WebRTC.Enabled {
+			return fmt.Errorf("QUIC, WebTransport and WebRTC don't support private networks and must be disabled")
+		}
+		for _, addr := range c.HostAddresses {
+			if !PrivateNetworkAddr(addr) {
+				return fmt.Errorf("host address %s uses a transport that doesn't support private networks", addr)
+			}
+		}
 	}
 	return nil
 }
@@ -142,15 +438,26 @@ func (c *Config) Validate() error {
 func NewDefaultConfig() Config {
 	defaultListen, _ := multiaddr.NewMultiaddr("/unix/tmp/p2pd.sock")
 	return Config{
-		ListenAddr: JSONMaddr{defaultListen},
-		Quiet:      false,
-		ID:         "",
+		ListenAddr:       JSONMaddr{defaultListen},
+		Quiet:            false,
+		ID:               "",
+		IDPassphraseFile: "",
+		IDGenerate: IDGenerate{
+			Enabled: false,
+			Type:    "ed25519",
+			Bits:    2048,
+		},
 		Bootstrap: Bootstrap{
 			Enabled: false,
 			Peers:   make(MaddrArray, 0),
 		},
 		DHT: DHT{
 			Mode: "",
+			Datastore: DHTDatastore{
+				Path:       "",
+				Backend:    DHTDatastoreLevelDB,
+				GCInterval: time.Hour,
+			},
 		},
 		ConnectionManager: ConnectionManager{
 			Enabled:       false,
@@ -158,7 +465,22 @@ func NewDefaultConfig() Config {
 			HighWaterMark: 512,
 			GracePeriod:   120 * time.Second,
 		},
-		QUIC:       true,
+		Transports: Transports{
+			TCP: TCPTransport{
+				Enabled:        true,
+				Reuseport:      true,
+				ConnectTimeout: 0,
+			},
+			QUIC: Transport{Enabled: true},
+			WebSocket: WebSocketTransport{
+				Enabled: true,
+				TLSCert: "",
+				TLSKey:  "",
+			},
+			WebTransport: Transport{Enabled: true},
+			WebRTC:       Transport{Enabled: true},
+			Muxers:       []string{MuxerYamux},
+		},
 		NatPortMap: false,
 		PubSub: PubSub{
 			Enabled:    false,
@@ -171,17 +493,34 @@ func NewDefaultConfig() Config {
 			},
 		},
 		Relay: Relay{
-			Enabled:   true,
-			Hop:       false,
-			Discovery: false,
-			Auto:      false,
-			HopLimit:  0,
-		},
-		AutoNat:           false,
-		HostAddresses:     make(MaddrArray, 0),
-		AnnounceAddresses: make(MaddrArray, 0),
-		NoListen:          false,
-		MetricsAddress:    "",
+			Enabled:      true,
+			Hop:          false,
+			Discovery:    false,
+			HopLimit:     0,
+			Auto:         false,
+			StaticRelays: make(MaddrArray, 0),
+			Service: RelayService{
+				ReservationTTL:         time.Hour,
+				MaxReservations:        128,
+				MaxCircuits:            16,
+				BufferSize:             2048,
+				MaxReservationsPerPeer: 4,
+				MaxReservationsPerIP:   8,
+				MaxReservationsPerASN:  32,
+				CircuitDuration:        2 * time.Minute,
+				CircuitData:            1 << 17,
+				AllowedPeers:           make([]string, 0),
+				AllowedSubnets:         make([]string, 0),
+			},
+		},
+		AutoNat:                  false,
+		ForceReachabilityPublic:  false,
+		ForceReachabilityPrivate: false,
+		HolePunching:             false,
+		HostAddresses:            make(MaddrArray, 0),
+		AnnounceAddresses:        make(MaddrArray, 0),
+		NoListen:                 false,
+		MetricsAddress:           "",
 		PProf: PProf{
 			Enabled: false,
 			Port:    0,
@@ -191,5 +530,103 @@ func NewDefaultConfig() Config {
 			TLS:   true,
 		},
 		Echo: false,
+		Tracing: Tracing{
+			Enabled:     false,
+			SampleRatio: 1,
+		},
+		Diagnostics: Diagnostics{
+			File: "",
+		},
+		ResourceManager: ResourceManager{
+			Limits:    "",
+			Allowlist: make(MaddrArray, 0),
+		},
+		RateLimit: RateLimit{
+			Enabled:       false,
+			Requests:      make(map[string]RateLimitRule),
+			MaxDHTQueries: 0,
+		},
+		Audit: Audit{
+			Enabled:    false,
+			File:       "",
+			MaxSize:    100 << 20,
+			MaxBackups: 5,
+		},
+		ConnectionGater: ConnectionGater{
+			BlockedPeers:   make([]string, 0),
+			AllowedPeers:   make([]string, 0),
+			BlockedSubnets: make([]string, 0),
+			File:           "",
+		},
+		Peerstore: Peerstore{
+			Path:       "",
+			GCInterval: 2 * time.Hour,
+		},
+		PrivateNetwork: PrivateNetwork{
+			KeyFile: "",
+		},
+		MDNS: MDNS{
+			Enabled:     false,
+			ServiceName: DefaultMDNSServiceName,
+			AutoConnect: false,
+		},
+	}
+}
+
+func (t Transports) validate() error {
+	if !t.TCP.Enabled && !t.QUIC.Enabled && !t.WebSocket.Enabled && !t.WebTransport.Enabled && !t.WebRTC.Enabled {
+		return fmt.Errorf("at least one transport must be enabled")
+	}
+	if t.TCP.ConnectTimeout < 0 {
+		return fmt.Errorf("TCP ConnectTimeout can't be negative")
+	}
+	if (t.WebSocket.TLSCert == "") != (t.WebSocket.TLSKey == "") {
+		return fmt.Errorf("WebSocket TLSCert and TLSKey must be set together")
+	}
+	if len(t.Muxers) == 0 {
+		return fmt.Errorf("at least one muxer must be offered")
+	}
+	seen := make(map[string]bool)
+	for _, m := range t.Muxers {
+		if m != MuxerYamux && m != MuxerMplex {
+			return fmt.Errorf("unknown muxer %s", m)
+		}
+		if seen[m] {
+			return fmt.Errorf("muxer %s is offered twice", m)
+		}
+		seen[m] = true
+	}
+	return nil
+}
+
+func (r RelayService) validate() error {
+	if r.ReservationTTL <= 0 {
+		return fmt.Errorf("relay service ReservationTTL must be positive")
+	}
+	if r.MaxReservations <= 0 || r.MaxCircuits <= 0 || r.BufferSize <= 0 {
+		return fmt.Errorf("relay service MaxReservations, MaxCircuits and BufferSize must be positive")
+	}
+	if r.MaxReservationsPerPeer <= 0 || r.MaxReservationsPerIP <= 0 || r.MaxReservationsPerASN <= 0 {
+		return fmt.Errorf("relay service MaxReservationsPerPeer, MaxReservationsPerIP and MaxReservationsPerASN must be positive")
+	}
+	if r.CircuitDuration < 0 || r.CircuitData < 0 {
+		return fmt.Errorf("relay service CircuitDuration and CircuitData can't be negative")
+	}
+	if (r.CircuitDuration == 0) != (r.CircuitData == 0) {
+		return fmt.Errorf("relay service CircuitDuration and CircuitData must be limited or unlimited together")
+	}
+	return nil
+}
+
+// PrivateNetworkAddr reports whether addr can be listened on or dialed in a
+// private network. QUIC, WebTransport and WebRTC have their own encryption,
+// which can't be combined with a pre-shared key.
+func PrivateNetworkAddr(addr multiaddr.Multiaddr) bool {
+	for _, p := range addr.Protocols() {
+		switch p.Code {
+		case multiaddr.P_QUIC, multiaddr.P_QUIC_V1, multiaddr.P_WEBTRANSPORT, multiaddr.P_WEBRTC, multiaddr.P_WEBRTC_DIRECT:
+			return false
+		}
 	}
+	return true
 }
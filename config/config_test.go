@@ -3,6 +3,7 @@ package config
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/multiformats/go-multiaddr"
 )
@@ -22,3 +23,161 @@ func TestDefaultConfig(t *testing.T) {
 		t.Fatalf("Expected %s, got %s", defaultListen.String(), c.ListenAddr.String())
 	}
 }
+
+func TestMaddrs(t *testing.T) {
+	const inputJson = `{
+		"ListenAddr": "/unix/tmp/test.sock",
+		"HostAddresses": ["/ip4/0.0.0.0/tcp/4001", "/ip4/0.0.0.0/udp/4001/quic-v1"],
+		"AnnounceAddresses": "/ip4/1.2.3.4/tcp/4001,/ip4/1.2.3.4/udp/4001/quic-v1"
+	}`
+	var c Config
+	if err := json.Unmarshal([]byte(inputJson), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.ListenAddr.String() != "/unix/tmp/test.sock" {
+		t.Fatalf("Expected /unix/tmp/test.sock, got %s", c.ListenAddr.String())
+	}
+	if len(c.HostAddresses) != 2 || c.HostAddresses[1].String() != "/ip4/0.0.0.0/udp/4001/quic-v1" {
+		t.Fatalf("Unexpected host addresses %v", c.HostAddresses)
+	}
+	if len(c.AnnounceAddresses) != 2 || c.AnnounceAddresses[0].String() != "/ip4/1.2.3.4/tcp/4001" {
+		t.Fatalf("Unexpected announce addresses %v", c.AnnounceAddresses)
+	}
+}
+
+func TestRateLimitValidation(t *testing.T) {
+	const inputJson = `{
+		"RateLimit": {
+			"Enabled": true,
+			"Requests": {"DHT.PUT_VALUE": {"Rate": 1}}
+		}
+	}`
+	var c Config
+	if err := json.Unmarshal([]byte(inputJson), &c); err == nil {
+		t.Fatal("Expected an error for a rate limit without a burst")
+	}
+}
+
+func TestPrivateNetworkValidation(t *testing.T) {
+	const inputJson = `{
+		"HostAddresses": ["/ip4/0.0.0.0/tcp/4001", "/ip4/0.0.0.0/udp/4001/quic-v1"],
+		"Transports": {
+			"QUIC": {"Enabled": false},
+			"WebTransport": {"Enabled": false},
+			"WebRTC": {"Enabled": false}
+		},
+		"PrivateNetwork": {"KeyFile": "swarm.key"}
+	}`
+	var c Config
+	if err := json.Unmarshal([]byte(inputJson), &c); err == nil {
+		t.Fatal("Expected an error for a QUIC host address in a private network")
+	}
+
+	// the default transports include QUIC
+	if err := json.Unmarshal([]byte(`{"PrivateNetwork": {"KeyFile": "swarm.key"}}`), &c); err == nil {
+		t.Fatal("Expected an error for QUIC enabled in a private network")
+	}
+}
+
+func TestTransports(t *testing.T) {
+	const inputJson = `{
+		"Transports": {
+			"TCP": {"Enabled": true, "Reuseport": false},
+			"WebRTC": {"Enabled": false},
+			"Muxers": ["mplex", "yamux"]
+		}
+	}`
+	var c Config
+	if err := json.Unmarshal([]byte(inputJson), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Transports.TCP.Reuseport || c.Transports.WebRTC.Enabled || !c.Transports.QUIC.Enabled {
+		t.Fatalf("Unexpected transports %+v", c.Transports)
+	}
+	if len(c.Transports.Muxers) != 2 || c.Transports.Muxers[0] != MuxerMplex {
+		t.Fatalf("Unexpected muxers %v", c.Transports.Muxers)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Transports": {"Muxers": ["yamux", "yamux"]}}`), &c); err == nil {
+		t.Fatal("Expected an error for a muxer offered twice")
+	}
+	if err := json.Unmarshal([]byte(`{"Transports": {"Muxers": []}}`), &c); err == nil {
+		t.Fatal("Expected an error for no muxers")
+	}
+}
+
+func TestRelayService(t *testing.T) {
+	const inputJson = `{
+		"Relay": {
+			"Service": {
+				"MaxReservations": 4,
+				"AllowedPeers": ["QmNnooDu7bfjPFoTZYxMNLWUQJyrVwtbZg5gBMjTezGAJN"]
+			}
+		}
+	}`
+	var c Config
+	if err := json.Unmarshal([]byte(inputJson), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Relay.Service.MaxReservations != 4 || c.Relay.Service.ReservationTTL != time.Hour {
+		t.Fatalf("Unexpected relay service %+v", c.Relay.Service)
+	}
+	if len(c.Relay.Service.AllowedPeers) != 1 {
+		t.Fatalf("Unexpected allowed peers %v", c.Relay.Service.AllowedPeers)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Relay": {"Service": {"MaxCircuits": 0}}}`), &c); err == nil {
+		t.Fatal("Expected an error for no circuits")
+	}
+	if err := json.Unmarshal([]byte(`{"Relay": {"Service": {"CircuitData": 0}}}`), &c); err == nil {
+		t.Fatal("Expected an error for a data limit without a duration limit")
+	}
+	if err := json.Unmarshal([]byte(`{"Relay": {"Service": {"CircuitDuration": 0, "CircuitData": 0}}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAutoRelay(t *testing.T) {
+	const inputJson = `{
+		"Relay": {
+			"Auto": true,
+			"StaticRelays": ["/ip4/1.2.3.4/tcp/4001/p2p/QmNnooDu7bfjPFoTZYxMNLWUQJyrVwtbZg5gBMjTezGAJN"]
+		},
+		"ForceReachabilityPrivate": true
+	}`
+	var c Config
+	if err := json.Unmarshal([]byte(inputJson), &c); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Relay.Auto || len(c.Relay.StaticRelays) != 1 || !c.ForceReachabilityPrivate {
+		t.Fatalf("Unexpected config %+v", c)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Relay": {"Auto": true}}`), &c); err == nil {
+		t.Fatal("Expected an error for autorelay without relays to use")
+	}
+	if err := json.Unmarshal([]byte(`{"Relay": {"Auto": true}, "DHT": {"Mode": "client"}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"Relay": {"Auto": true, "StaticRelays": ["/ip4/1.2.3.4/tcp/4001"]}}`), &c); err == nil {
+		t.Fatal("Expected an error for a static relay without a peer ID")
+	}
+	if err := json.Unmarshal([]byte(`{"ForceReachabilityPublic": true, "ForceReachabilityPrivate": true}`), &c); err == nil {
+		t.Fatal("Expected an error for reachability forced both ways")
+	}
+}
+
+func TestMDNS(t *testing.T) {
+	var c Config
+	if err := json.Unmarshal([]byte(`{"MDNS": {"Enabled": true, "AutoConnect": true}}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if c.MDNS.ServiceName != DefaultMDNSServiceName || !c.MDNS.AutoConnect {
+		t.Fatalf("Unexpected mDNS config %+v", c.MDNS)
+	}
+
+	if err := json.Unmarshal([]byte(`{"MDNS": {"Enabled": true, "ServiceName": ""}}`), &c); err == nil {
+		t.Fatal("Expected an error for mDNS without a service name")
+	}
+}
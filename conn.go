@@ -1,6 +1,7 @@
 package p2pd
 
 import (
+	"bufio"
 	"context"
 	"io"
 	"net"
@@ -11,9 +12,13 @@ import (
 	"github.com/libp2p/go-libp2p/core/protocol"
 
 	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	"github.com/libp2p/go-libp2p-daemon/shm"
 
 	ggio "github.com/gogo/protobuf/io"
+	proto "github.com/gogo/protobuf/proto"
 	ma "github.com/multiformats/go-multiaddr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const DefaultTimeout = 60 * time.Second
@@ -21,25 +26,81 @@ const DefaultTimeout = 60 * time.Second
 func (d *Daemon) handleConn(c net.Conn) {
 	defer c.Close()
 
-	r := ggio.NewDelimitedReader(c, network.MessageSizeMax)
+	controlConns.Inc()
+	defer controlConns.Dec()
+
+	// the delimited reader reads through br, which the connWatcher peeks into
+	br := bufio.NewReader(c)
+	r := ggio.NewDelimitedReader(br, network.MessageSizeMax)
 	w := ggio.NewDelimitedWriter(c)
 
-	for {
-		var req pb.Request
+	d.mx.Lock()
+	limits := d.limits
+	d.mx.Unlock()
+	var client string
+	if limits != nil {
+		client = clientID(c)
+	}
+	var creds *auditClient
+
+	// finish finishes a request, recording it in the audit log if it
+	// changes the daemon's state. The log is looked up for each request, as
+	// EnableAudit may replace it while the connection is open.
+	finish := func(span trace.Span, req *pb.Request, res *pb.Response, start time.Time) {
+		finishRequest(span, req, res, start)
+		d.mx.Lock()
+		audit := d.audit
+		d.mx.Unlock()
+		if audit == nil {
+			return
+		}
+		if creds == nil {
+			ac := newAuditClient(c)
+			creds = &ac
+		}
+		audit.record(*creds, req, res)
+	}
 
-		err := r.ReadMsg(&req)
-		if err != nil {
-			if err != io.EOF {
-				log.Debugw("error reading message", "error", err)
+	var next *pb.Request
+	for {
+		req := next
+		next = nil
+		if req == nil {
+			req = new(pb.Request)
+			err := r.ReadMsg(req)
+			if err != nil {
+				if err != io.EOF {
+					log.Debugw("error reading message", "error", err)
+				}
+				return
 			}
-			return
 		}
 
-		log.Debugw("request", "type", req.GetType())
+		if req.GetType() == pb.Request_CANCEL {
+			// the request it names has already been answered
+			continue
+		}
+
+		log.Debugw("request", "type", req.GetType(), "id", req.GetId())
+		start := time.Now()
+		ctx, span := d.startRequestSpan(req)
+
+		if limits != nil && !limits.allow(client, req) {
+			observeLimited(req, limitRate)
+			res := rateLimitedResponse("request rate limit exceeded")
+			finish(span, req, res, start)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+			continue
+		}
 
 		switch req.GetType() {
 		case pb.Request_IDENTIFY:
-			res := d.doIdentify(&req)
+			res := d.doIdentify(req)
+			finish(span, req, res, start)
 			err := w.WriteMsg(res)
 			if err != nil {
 				log.Debugw("error writing response", "error", err)
@@ -47,67 +108,143 @@ func (d *Daemon) handleConn(c net.Conn) {
 			}
 
 		case pb.Request_CONNECT:
-			res := d.doConnect(&req)
-			err := w.WriteMsg(res)
+			wctx, cw := watchConn(ctx, c, br, r, req)
+			res := d.doConnect(wctx, req)
+			var err error
+			next, err = cw.finish(res)
+			finish(span, req, res, start)
+			if err != nil {
+				return
+			}
+			err = w.WriteMsg(res)
 			if err != nil {
 				log.Debugw("error writing response", "error", err)
 				return
 			}
 
 		case pb.Request_STREAM_OPEN:
-			res, s := d.doStreamOpen(&req)
-			err := w.WriteMsg(res)
+			wctx, cw := watchConn(ctx, c, br, r, req)
+			res, s := d.doStreamOpen(wctx, req)
+			var err error
+			next, err = cw.finish(res)
+			finish(span, req, res, start)
+			if err != nil {
+				if s != nil {
+					s.Reset()
+				}
+				return
+			}
+			if s != nil && next != nil {
+				// the connection carries the stream once it's open
+				log.Debugw("request sent ahead of stream data", "type", next.GetType())
+				s.Reset()
+				return
+			}
+
+			var seg *shm.Segment
+			if s != nil {
+				seg = d.shmOffer(c, req.StreamOpen.GetShm())
+				if seg != nil {
+					res.StreamInfo.ShmSize = proto.Uint64(uint64(seg.RingSize()))
+				}
+			}
+
+			err = w.WriteMsg(res)
 			if err != nil {
 				log.Debugw("error writing response", "error", err)
 				if s != nil {
 					s.Reset()
 				}
+				if seg != nil {
+					seg.Close()
+				}
 				return
 			}
 
 			if s != nil {
-				d.doStreamPipe(c, s)
+				d.pipeStream(ctx, c, s, seg)
 				return
 			}
 
 		case pb.Request_STREAM_HANDLER:
-			res := d.doStreamHandler(&req)
+			res, hc := d.doStreamHandler(c, req)
+			finish(span, req, res, start)
 			err := w.WriteMsg(res)
 			if err != nil {
 				log.Debugw("error writing response", "error", err)
 				return
 			}
 
+			if hc != nil {
+				// handlers are registered once the client has seen the response,
+				// so that streams are never delivered ahead of it
+				d.doStreamHandlerConn(hc, req.StreamHandler.Proto, req.StreamHandler.GetShm(), r)
+				return
+			}
+
 		case pb.Request_DHT:
-			res, ch, cancel := d.doDHT(&req)
-			err := w.WriteMsg(res)
+			if limits != nil && !limits.acquireQuery(client) {
+				observeLimited(req, limitDHTQueries)
+				res := rateLimitedResponse("too many concurrent DHT queries")
+				finish(span, req, res, start)
+				err := w.WriteMsg(res)
+				if err != nil {
+					log.Debugw("error writing response", "error", err)
+					return
+				}
+				continue
+			}
+			release := func() {
+				if limits != nil {
+					limits.releaseQuery(client)
+				}
+			}
+
+			wctx, cw := watchConn(ctx, c, br, r, req)
+			res, ch, cancel := d.doDHT(wctx, req)
+			var err error
+			if ch == nil {
+				release()
+				next, err = cw.finish(res)
+				finish(span, req, res, start)
+				if err != nil {
+					return
+				}
+			} else {
+				res.Id = req.Id
+			}
+			err = w.WriteMsg(res)
 			if err != nil {
 				log.Debugw("error writing response", "error", err)
 				if ch != nil {
 					cancel()
+					cw.finish(res)
+					release()
 				}
 				return
 			}
 
 			if ch != nil {
-				for res := range ch {
-					err = w.WriteMsg(res)
-					if err != nil {
-						log.Debugw("error writing response", "error", err)
-						cancel()
-						return
-					}
-				}
-
-				err = w.WriteMsg(dhtResponseEnd())
+				// streaming queries are timed until the last response, and
+				// can be cancelled until then
+				err = d.doDHTStream(ch, w)
+				release()
+				var rerr error
+				next, rerr = cw.finish(res)
+				finish(span, req, res, start)
 				if err != nil {
 					log.Debugw("error writing response", "error", err)
+					cancel()
+					return
+				}
+				if rerr != nil {
 					return
 				}
 			}
 
 		case pb.Request_LIST_PEERS:
-			res := d.doListPeers(&req)
+			res := d.doListPeers(req)
+			finish(span, req, res, start)
 			err := w.WriteMsg(res)
 			if err != nil {
 				log.Debugw("error writing response", "error", err)
@@ -115,7 +252,71 @@ func (d *Daemon) handleConn(c net.Conn) {
 			}
 
 		case pb.Request_CONNMANAGER:
-			res := d.doConnManager(&req)
+			res := d.doConnManager(req)
+			finish(span, req, res, start)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_CONNGATER:
+			res := d.doConnGater(req)
+			finish(span, req, res, start)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_SIGN:
+			res := d.doSign(req)
+			finish(span, req, res, start)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_VERIFY:
+			res := d.doVerify(req)
+			finish(span, req, res, start)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_SEAL_ENVELOPE:
+			res := d.doSealEnvelope(req)
+			finish(span, req, res, start)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_OPEN_ENVELOPE:
+			res := d.doOpenEnvelope(req)
+			finish(span, req, res, start)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_PEER_RECORD:
+			res := d.doPeerRecord(req)
+			finish(span, req, res, start)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_ADD_PEER_RECORD:
+			res := d.doAddPeerRecord(req)
+			finish(span, req, res, start)
 			err := w.WriteMsg(res)
 			if err != nil {
 				log.Debugw("error writing response", "error", err)
@@ -123,7 +324,8 @@ func (d *Daemon) handleConn(c net.Conn) {
 			}
 
 		case pb.Request_DISCONNECT:
-			res := d.doDisconnect(&req)
+			res := d.doDisconnect(req)
+			finish(span, req, res, start)
 			err := w.WriteMsg(res)
 			if err != nil {
 				log.Debugw("error writing response", "error", err)
@@ -131,7 +333,8 @@ func (d *Daemon) handleConn(c net.Conn) {
 			}
 
 		case pb.Request_PUBSUB:
-			res, sub := d.doPubsub(&req)
+			res, sub := d.doPubsub(req)
+			finish(span, req, res, start)
 			err := w.WriteMsg(res)
 			if err != nil {
 				log.Debugw("error writing response", "error", err)
@@ -146,8 +349,97 @@ func (d *Daemon) handleConn(c net.Conn) {
 				return
 			}
 
+		case pb.Request_RESOURCE_USAGE:
+			res := d.doResourceUsage(req)
+			finish(span, req, res, start)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_DIAGNOSTICS:
+			res := d.doDiagnostics(req)
+			finish(span, req, res, start)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_EVENTS:
+			res, sub := d.doEvents(req)
+			finish(span, req, res, start)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				d.events.unsubscribe(sub)
+				return
+			}
+
+			d.doEventsPipe(sub, r, w)
+			return
+
+		case pb.Request_DIRECT_CONNECT:
+			wctx, cw := watchConn(ctx, c, br, r, req)
+			res := d.doDirectConnect(wctx, req)
+			var err error
+			next, err = cw.finish(res)
+			finish(span, req, res, start)
+			if err != nil {
+				return
+			}
+			err = w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_RELAY_RESERVE:
+			wctx, cw := watchConn(ctx, c, br, r, req)
+			res := d.doRelayReserve(wctx, req)
+			var err error
+			next, err = cw.finish(res)
+			finish(span, req, res, start)
+			if err != nil {
+				return
+			}
+			err = w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_RELAY_LIST_RESERVATIONS:
+			res := d.doRelayListReservations(req)
+			finish(span, req, res, start)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_RELAY_CANCEL:
+			res := d.doRelayCancel(req)
+			finish(span, req, res, start)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
+		case pb.Request_GET_REACHABILITY:
+			res := d.doGetReachability(req)
+			finish(span, req, res, start)
+			err := w.WriteMsg(res)
+			if err != nil {
+				log.Debugw("error writing response", "error", err)
+				return
+			}
+
 		default:
 			log.Debugw("unexpected request type", "type", req.GetType())
+			span.End()
 			return
 		}
 	}
@@ -163,15 +455,21 @@ func (d *Daemon) doIdentify(req *pb.Request) *pb.Response {
 
 	res := okResponse()
 	res.Identify = &pb.IdentifyResponse{Id: id, Addrs: baddrs}
+	rec, err := d.localPeerRecord()
+	if err != nil {
+		log.Debugw("error marshaling peer record", "error", err)
+	} else {
+		res.Identify.PeerRecord = rec
+	}
 	return res
 }
 
-func (d *Daemon) doConnect(req *pb.Request) *pb.Response {
+func (d *Daemon) doConnect(ctx context.Context, req *pb.Request) *pb.Response {
 	if req.Connect == nil {
 		return errorResponseString("Malformed request; missing parameters")
 	}
 
-	ctx, cancel := d.requestContext(req.Connect.GetTimeout())
+	ctx, cancel := d.requestContext(ctx, req.Connect.GetTimeout(), req.Connect.GetTimeoutMillis())
 	defer cancel()
 
 	pid, err := peer.IDFromBytes(req.Connect.Peer)
@@ -193,7 +491,9 @@ func (d *Daemon) doConnect(req *pb.Request) *pb.Response {
 	pi := peer.AddrInfo{ID: pid, Addrs: addrs}
 
 	log.Debug("connecting", "to", pid)
+	ctx, span := d.tracer().Start(ctx, "p2pd.dial", trace.WithAttributes(attribute.Stringer("peer", pid)))
 	err = d.host.Connect(ctx, pi)
+	endSpan(span, err)
 	if err != nil {
 		log.Debugw("error opening connection", "to", pid, "error", err)
 		return errorResponse(err)
@@ -220,17 +520,19 @@ func (d *Daemon) doDisconnect(req *pb.Request) *pb.Response {
 	return okResponse()
 }
 
-func (d *Daemon) doStreamOpen(req *pb.Request) (*pb.Response, network.Stream) {
+func (d *Daemon) doStreamOpen(ctx context.Context, req *pb.Request) (*pb.Response, network.Stream) {
 	if req.StreamOpen == nil {
+		streamOpenFailures.WithLabelValues(reasonMalformed).Inc()
 		return errorResponseString("Malformed request; missing parameters"), nil
 	}
 
-	ctx, cancel := d.requestContext(req.StreamOpen.GetTimeout())
+	ctx, cancel := d.requestContext(ctx, req.StreamOpen.GetTimeout(), req.StreamOpen.GetTimeoutMillis())
 	defer cancel()
 
 	pid, err := peer.IDFromBytes(req.StreamOpen.Peer)
 	if err != nil {
 		log.Debugw("Error parsing peer ID", "error", err)
+		streamOpenFailures.WithLabelValues(reasonMalformed).Inc()
 		return errorResponse(err), nil
 	}
 
@@ -241,40 +543,72 @@ func (d *Daemon) doStreamOpen(req *pb.Request) (*pb.Response, network.Stream) {
 
 	log.Debugf("opening stream", "to", pid)
 	ctx = network.WithAllowLimitedConn(ctx, "allow testing over relayed connections")
-	s, err := d.host.NewStream(ctx, pid, protos...)
+
+	// dial the peer at the addresses in the peerstore first, unless already
+	// connected to it, so that dialing and negotiation are traced apart
+	if len(d.host.Network().ConnsToPeer(pid)) == 0 {
+		dctx, span := d.tracer().Start(ctx, "p2pd.dial", trace.WithAttributes(attribute.Stringer("peer", pid)))
+		err = d.host.Connect(dctx, peer.AddrInfo{ID: pid})
+		endSpan(span, err)
+		if err != nil {
+			log.Debugw("error opening stream", "to", pid, "error", err)
+			streamOpenFailures.WithLabelValues(reasonDial).Inc()
+			return errorResponse(err), nil
+		}
+	}
+
+	nctx, span := d.tracer().Start(ctx, "p2pd.negotiate", trace.WithAttributes(
+		attribute.Stringer("peer", pid),
+		attribute.StringSlice("protocols", req.StreamOpen.Proto),
+	))
+	s, err := d.host.NewStream(nctx, pid, protos...)
 	if err != nil {
+		reason := openFailureReason(err)
+		span.SetAttributes(attribute.String("p2pd.failure.reason", reason))
+		endSpan(span, err)
 		log.Debugw("error opening stream", "to", pid, "error", err)
+		streamOpenFailures.WithLabelValues(reason).Inc()
+		return errorResponse(err), nil
+	}
+	endSpan(span, nil)
+
+	err = scopeStream(s)
+	if err != nil {
+		log.Debugw("error attaching stream to resource scope", "to", pid, "error", err)
+		streamOpenFailures.WithLabelValues(reasonResourceLimit).Inc()
+		s.Reset()
 		return errorResponse(err), nil
 	}
+	streamsOpened.WithLabelValues(string(s.Protocol())).Inc()
 
 	res := okResponse()
 	res.StreamInfo = makeStreamInfo(s)
 	return res, s
 }
 
-func (d *Daemon) doStreamHandler(req *pb.Request) *pb.Response {
+func (d *Daemon) doStreamHandler(c net.Conn, req *pb.Request) (*pb.Response, *handlerConn) {
 	if req.StreamHandler == nil {
-		return errorResponseString("Malformed request; missing parameters")
+		return errorResponseString("Malformed request; missing parameters"), nil
 	}
 
-	d.mx.Lock()
-	defer d.mx.Unlock()
+	if req.StreamHandler.GetPassFd() {
+		if !fdPassingSupported {
+			return errorResponseString("file descriptor passing is not supported on this platform"), nil
+		}
+		uc, ok := c.(shm.UnixConn)
+		if !ok {
+			return errorResponseString("file descriptor passing requires a unix socket control connection"), nil
+		}
+		return okResponse(), newHandlerConn(uc)
+	}
 
 	maddr, err := ma.NewMultiaddrBytes(req.StreamHandler.Addr)
 	if err != nil {
-		return errorResponse(err)
-	}
-	for _, sp := range req.StreamHandler.Proto {
-		p := protocol.ID(sp)
-		_, ok := d.handlers[p]
-		if !ok {
-			d.host.SetStreamHandler(p, d.handleStream)
-		}
-		log.Debugw("set stream handler", "protocol", sp, "to", maddr)
-		d.handlers[p] = maddr
+		return errorResponse(err), nil
 	}
+	d.setStreamHandlers(req.StreamHandler.Proto, streamHandler{addr: maddr, shm: req.StreamHandler.GetShm()})
 
-	return okResponse()
+	return okResponse(), nil
 }
 
 func (d *Daemon) doListPeers(req *pb.Request) *pb.Response {
@@ -292,13 +626,25 @@ func (d *Daemon) doListPeers(req *pb.Request) *pb.Response {
 	return res
 }
 
-func (d *Daemon) requestContext(utime int64) (context.Context, func()) {
+// requestContext derives the context of a request timing out after millis
+// milliseconds if set, or after utime seconds if set, or after DefaultTimeout.
+func (d *Daemon) requestContext(ctx context.Context, utime, millis int64) (context.Context, func()) {
 	timeout := DefaultTimeout
-	if utime > 0 {
+	if millis > 0 {
+		timeout = time.Duration(millis) * time.Millisecond
+	} else if utime > 0 {
 		timeout = time.Duration(utime) * time.Second
 	}
 
-	return context.WithTimeout(d.ctx, timeout)
+	return context.WithTimeout(ctx, timeout)
+}
+
+// finishRequest tags res with the id of req, records the metrics and ends the
+// span of a request answered with res.
+func finishRequest(span trace.Span, req *pb.Request, res *pb.Response, start time.Time) {
+	res.Id = req.Id
+	observeRequest(req, res, start)
+	endRequestSpan(span, res)
 }
 
 func okResponse() *pb.Response {
@@ -0,0 +1,387 @@
+package p2pd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/libp2p/go-libp2p-daemon/config"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// connGater keeps the daemon from connecting with blocked peers and subnets,
+// and with peers outside the allowlist when there is one, as well as with
+// those the gater next, if set, refuses.
+type connGater struct {
+	next connmgr.ConnectionGater
+
+	mx sync.RWMutex
+	// blockedPeers and blockedSubnets are blocked through control requests,
+	// and persist in file, if set
+	blockedPeers   map[peer.ID]struct{}
+	blockedSubnets map[string]*net.IPNet
+	file           string
+	// configPeers and configSubnets are blocked by the config, and aren't
+	// persisted
+	configPeers   map[peer.ID]struct{}
+	configSubnets map[string]*net.IPNet
+	allowedPeers  map[peer.ID]struct{}
+
+	// saveMx serializes saving, so that the file is replaced by the latest
+	// blocks rather than by whichever save is the last to finish
+	saveMx sync.Mutex
+}
+
+var _ connmgr.ConnectionGater = (*connGater)(nil)
+
+// gaterRules are the blocks persisted in the connection gater file.
+type gaterRules struct {
+	BlockedPeers   []string
+	BlockedSubnets []string
+}
+
+func newConnGater(next connmgr.ConnectionGater) *connGater {
+	return &connGater{
+		next:           next,
+		blockedPeers:   make(map[peer.ID]struct{}),
+		blockedSubnets: make(map[string]*net.IPNet),
+		configPeers:    make(map[peer.ID]struct{}),
+		configSubnets:  make(map[string]*net.IPNet),
+		allowedPeers:   make(map[peer.ID]struct{}),
+	}
+}
+
+// LoadConnectionGater applies the rules of c to the daemon's connection
+// gater, replacing those of a previous config, along with the blocks
+// persisted in the file of c, where blocks made through control requests
+// from then on are persisted. Connections the rules block are closed.
+func (d *Daemon) LoadConnectionGater(c config.ConnectionGater) error {
+	var saved gaterRules
+	if c.File != "" {
+		b, err := os.ReadFile(c.File)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return err
+		default:
+			if err := json.Unmarshal(b, &saved); err != nil {
+				return fmt.Errorf("reading connection gater file: %w", err)
+			}
+		}
+	}
+
+	configPeers, err := decodePeers(c.BlockedPeers)
+	if err != nil {
+		return err
+	}
+	configSubnets, err := decodeSubnets(c.BlockedSubnets)
+	if err != nil {
+		return err
+	}
+	allowed, err := decodePeers(c.AllowedPeers)
+	if err != nil {
+		return err
+	}
+	savedPeers, err := decodePeers(saved.BlockedPeers)
+	if err != nil {
+		return fmt.Errorf("reading connection gater file: %w", err)
+	}
+	savedSubnets, err := decodeSubnets(saved.BlockedSubnets)
+	if err != nil {
+		return fmt.Errorf("reading connection gater file: %w", err)
+	}
+
+	g := d.gater
+	g.mx.Lock()
+	g.configPeers = make(map[peer.ID]struct{}, len(configPeers))
+	for _, p := range configPeers {
+		g.configPeers[p] = struct{}{}
+	}
+	g.configSubnets = make(map[string]*net.IPNet, len(configSubnets))
+	for _, ipnet := range configSubnets {
+		g.configSubnets[ipnet.String()] = ipnet
+	}
+	g.allowedPeers = make(map[peer.ID]struct{}, len(allowed))
+	for _, p := range allowed {
+		g.allowedPeers[p] = struct{}{}
+	}
+	for _, p := range savedPeers {
+		g.blockedPeers[p] = struct{}{}
+	}
+	for _, ipnet := range savedSubnets {
+		g.blockedSubnets[ipnet.String()] = ipnet
+	}
+	g.file = c.File
+	g.mx.Unlock()
+
+	if err := g.save(); err != nil {
+		return err
+	}
+
+	d.closeGatedConns()
+	return nil
+}
+
+func decodePeers(ss []string) ([]peer.ID, error) {
+	peers := make([]peer.ID, 0, len(ss))
+	for _, s := range ss {
+		p, err := peer.Decode(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid peer ID %s: %w", s, err)
+		}
+		peers = append(peers, p)
+	}
+	return peers, nil
+}
+
+func decodeSubnets(ss []string) ([]*net.IPNet, error) {
+	subnets := make([]*net.IPNet, 0, len(ss))
+	for _, s := range ss {
+		_, ipnet, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, err
+		}
+		subnets = append(subnets, ipnet)
+	}
+	return subnets, nil
+}
+
+// save persists the blocks made through control requests to the gater file,
+// if any, replacing it.
+func (g *connGater) save() error {
+	g.saveMx.Lock()
+	defer g.saveMx.Unlock()
+
+	g.mx.RLock()
+	path := g.file
+	rules := gaterRules{
+		BlockedPeers:   make([]string, 0, len(g.blockedPeers)),
+		BlockedSubnets: make([]string, 0, len(g.blockedSubnets)),
+	}
+	for p := range g.blockedPeers {
+		rules.BlockedPeers = append(rules.BlockedPeers, p.String())
+	}
+	for s := range g.blockedSubnets {
+		rules.BlockedSubnets = append(rules.BlockedSubnets, s)
+	}
+	g.mx.RUnlock()
+
+	if path == "" {
+		return nil
+	}
+	sort.Strings(rules.BlockedPeers)
+	sort.Strings(rules.BlockedSubnets)
+
+	b, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("writing connection gater file: %w", err)
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+func (g *connGater) peerAllowed(p peer.ID) bool {
+	g.mx.RLock()
+	defer g.mx.RUnlock()
+
+	if _, ok := g.blockedPeers[p]; ok {
+		return false
+	}
+	if _, ok := g.configPeers[p]; ok {
+		return false
+	}
+	if len(g.allowedPeers) == 0 {
+		return true
+	}
+	_, ok := g.allowedPeers[p]
+	return ok
+}
+
+func (g *connGater) addrAllowed(a ma.Multiaddr) bool {
+	ip, err := manet.ToIP(a)
+	if err != nil {
+		// not an IP address, such as a relay address
+		return true
+	}
+
+	g.mx.RLock()
+	defer g.mx.RUnlock()
+
+	for _, ipnet := range g.blockedSubnets {
+		if ipnet.Contains(ip) {
+			return false
+		}
+	}
+	for _, ipnet := range g.configSubnets {
+		if ipnet.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *connGater) InterceptPeerDial(p peer.ID) bool {
+	return g.peerAllowed(p) && (g.next == nil || g.next.InterceptPeerDial(p))
+}
+
+func (g *connGater) InterceptAddrDial(p peer.ID, a ma.Multiaddr) bool {
+	return g.addrAllowed(a) && (g.next == nil || g.next.InterceptAddrDial(p, a))
+}
+
+func (g *connGater) InterceptAccept(cma network.ConnMultiaddrs) bool {
+	return g.addrAllowed(cma.RemoteMultiaddr()) && (g.next == nil || g.next.InterceptAccept(cma))
+}
+
+func (g *connGater) InterceptSecured(dir network.Direction, p peer.ID, cma network.ConnMultiaddrs) bool {
+	return g.peerAllowed(p) && (g.next == nil || g.next.InterceptSecured(dir, p, cma))
+}
+
+func (g *connGater) InterceptUpgraded(c network.Conn) (bool, control.DisconnectReason) {
+	if g.next == nil {
+		return true, 0
+	}
+	return g.next.InterceptUpgraded(c)
+}
+
+// closeGatedConns closes the connections the gater no longer allows.
+func (d *Daemon) closeGatedConns() {
+	for _, c := range d.host.Network().Conns() {
+		if d.gater.peerAllowed(c.RemotePeer()) && d.gater.addrAllowed(c.RemoteMultiaddr()) {
+			continue
+		}
+		log.Debugw("closing gated connection", "peer", c.RemotePeer(), "addr", c.RemoteMultiaddr())
+		if err := c.Close(); err != nil {
+			log.Debugw("error closing connection", "error", err)
+		}
+	}
+}
+
+func (d *Daemon) doConnGater(req *pb.Request) *pb.Response {
+	if req.ConnGater == nil {
+		return errorResponseString("Malformed request; missing parameters")
+	}
+
+	g := d.gater
+	switch req.ConnGater.GetType() {
+	case pb.ConnGaterRequest_BLOCK_PEER, pb.ConnGaterRequest_UNBLOCK_PEER:
+		p, err := peer.IDFromBytes(req.ConnGater.GetPeer())
+		if err != nil {
+			return errorResponse(err)
+		}
+
+		block := req.ConnGater.GetType() == pb.ConnGaterRequest_BLOCK_PEER
+		g.mx.Lock()
+		if _, ok := g.configPeers[p]; ok && !block {
+			g.mx.Unlock()
+			return errorResponseString(fmt.Sprintf("peer %s is blocked by the config", p))
+		}
+		if block {
+			g.blockedPeers[p] = struct{}{}
+		} else {
+			delete(g.blockedPeers, p)
+		}
+		g.mx.Unlock()
+
+		if err := g.save(); err != nil {
+			return errorResponse(err)
+		}
+		if block {
+			d.closeGatedConns()
+		}
+		return okResponse()
+
+	case pb.ConnGaterRequest_BLOCK_SUBNET, pb.ConnGaterRequest_UNBLOCK_SUBNET:
+		if req.ConnGater.Subnet == nil {
+			return errorResponseString("Malformed request; missing subnet parameter")
+		}
+		_, ipnet, err := net.ParseCIDR(req.ConnGater.GetSubnet())
+		if err != nil {
+			return errorResponse(err)
+		}
+
+		block := req.ConnGater.GetType() == pb.ConnGaterRequest_BLOCK_SUBNET
+		g.mx.Lock()
+		if _, ok := g.configSubnets[ipnet.String()]; ok && !block {
+			g.mx.Unlock()
+			return errorResponseString(fmt.Sprintf("subnet %s is blocked by the config", ipnet))
+		}
+		if block {
+			g.blockedSubnets[ipnet.String()] = ipnet
+		} else {
+			delete(g.blockedSubnets, ipnet.String())
+		}
+		g.mx.Unlock()
+
+		if err := g.save(); err != nil {
+			return errorResponse(err)
+		}
+		if block {
+			d.closeGatedConns()
+		}
+		return okResponse()
+
+	case pb.ConnGaterRequest_LIST_BLOCKED:
+		res := okResponse()
+		res.ConnGater = g.list()
+		return res
+
+	default:
+		log.Debugw("unexpected ConnGater request type", "type", req.ConnGater.GetType())
+		return errorResponseString("Unexpected request")
+	}
+}
+
+func (g *connGater) list() *pb.ConnGaterResponse {
+	g.mx.RLock()
+	defer g.mx.RUnlock()
+
+	res := &pb.ConnGaterResponse{}
+	for p := range g.blockedPeers {
+		res.BlockedPeers = append(res.BlockedPeers, []byte(p))
+	}
+	for p := range g.configPeers {
+		if _, ok := g.blockedPeers[p]; !ok {
+			res.BlockedPeers = append(res.BlockedPeers, []byte(p))
+		}
+	}
+	for p := range g.allowedPeers {
+		res.AllowedPeers = append(res.AllowedPeers, []byte(p))
+	}
+	for s := range g.blockedSubnets {
+		res.BlockedSubnets = append(res.BlockedSubnets, s)
+	}
+	for s := range g.configSubnets {
+		if _, ok := g.blockedSubnets[s]; !ok {
+			res.BlockedSubnets = append(res.BlockedSubnets, s)
+		}
+	}
+	sort.Strings(res.BlockedSubnets)
+	return res
+}
@@ -11,12 +11,15 @@ import (
 	"github.com/libp2p/go-libp2p-daemon/config"
 
 	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/event"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/libp2p/go-libp2p/core/routing"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
 	"github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/relay"
+	"github.com/libp2p/go-libp2p/p2p/protocol/holepunch"
 
 	multierror "github.com/hashicorp/go-multierror"
 	logging "github.com/ipfs/go-log"
@@ -25,6 +28,7 @@ import (
 	ps "github.com/libp2p/go-libp2p-pubsub"
 	ma "github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr/net"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var log = logging.Logger("p2pd")
@@ -34,24 +38,103 @@ type Daemon struct {
 	host     host.Host
 	listener manet.Listener
 
-	dht    *dht.IpfsDHT
-	pubsub *ps.PubSub
+	dht *dht.IpfsDHT
+	// dhtStore keeps the records the DHT stores, if the DHT is enabled
+	dhtStore *DHTStore
+	pubsub   *ps.PubSub
+	// mesh tracks the pubsub mesh for diagnostics
+	mesh *meshTracer
+	// gater blocks connections with unwanted peers and subnets
+	gater *connGater
 
 	mx sync.Mutex
-	// stream handlers: map of protocol.ID to multi-address
-	handlers map[protocol.ID]ma.Multiaddr
+	// stream handlers: map of protocol.ID to the client endpoint handling it
+	handlers map[protocol.ID]streamHandler
 	// closed is set when the daemon is shutting down
 	closed bool
+
+	// tracerProvider exports the daemon's spans, if tracing is enabled
+	tracerProvider trace.TracerProvider
+	// tracingShutdown flushes pending spans, if tracing is enabled
+	tracingShutdown func() error
+	// diagnosticsFile is where SIGUSR1 writes diagnostics snapshots
+	diagnosticsFile string
+	// limits rate limits control requests, if enabled
+	limits *rateLimiter
+	// audit records mutating control requests, if enabled
+	audit *auditLog
+	// privateNetwork is set when the host only connects with peers sharing
+	// its pre-shared key
+	privateNetwork bool
+
+	// events fans daemon events out to subscribed clients
+	events *eventHub
+	// holePunch upgrades relayed connections, if hole punching is enabled
+	holePunch *holepunch.Service
+	// reachability is the network.Reachability of the host, as determined
+	// by AutoNAT or forced by reachabilityForced
+	reachability       int32
+	reachabilityForced bool
+	reachabilitySub    event.Subscription
+
+	// reservations are the relay reservations requested by clients
+	reservations *relayReservations
+	// mdns discovers peers on the local network, if enabled
+	mdns mdns.Service
 }
 
+// NewDaemon creates a daemon whose host is created with the libp2p options
+// opts.
 func NewDaemon(ctx context.Context, maddr ma.Multiaddr, dhtMode string, opts ...libp2p.Option) (*Daemon, error) {
+	return NewDaemonWithOptions(ctx, maddr, dhtMode, WithHostOptions(opts...))
+}
+
+// NewDaemonWithOptions creates a daemon configured by dopts.
+func NewDaemonWithOptions(ctx context.Context, maddr ma.Multiaddr, dhtMode string, dopts ...Option) (*Daemon, error) {
+	var o options
+	err := o.apply(dopts)
+	if err == nil && o.autoRelayDHT && dhtMode == "" {
+		err = fmt.Errorf("autorelay needs static relays or the DHT to find relays through")
+	}
+	if err == nil && o.hostGater() {
+		err = fmt.Errorf("the daemon has a connection gater of its own; set yours with WithConnectionGater")
+	}
+	if err != nil {
+		if o.dhtStore != nil {
+			o.dhtStore.Close()
+		}
+		return nil, err
+	}
+
 	d := &Daemon{
 		ctx:      ctx,
-		handlers: make(map[protocol.ID]ma.Multiaddr),
+		handlers: make(map[protocol.ID]streamHandler),
+		mesh:     newMeshTracer(),
+		gater:    newConnGater(o.gater),
+		dhtStore: o.dhtStore,
+		events:   newEventHub(),
+
+		privateNetwork:     o.psk != nil,
+		reachabilityForced: o.reachability != network.ReachabilityUnknown,
+
+		reservations: newRelayReservations(),
 	}
+	opts := append(o.hostOptions(d), libp2p.ConnectionGater(d.gater))
 
 	if dhtMode != "" {
-		var dhtOpts []dhtopts.Option
+		if d.dhtStore == nil {
+			c := config.NewDefaultConfig().DHT.Datastore
+			store, err := NewDHTStore(c)
+			if err != nil {
+				return nil, err
+			}
+			d.dhtStore = store
+		}
+
+		dhtOpts := []dhtopts.Option{
+			dht.Datastore(d.dhtStore.ds),
+			dht.MaxRecordAge(maxRecordAge),
+		}
 		if dhtMode == config.DHTClientMode {
 			dhtOpts = append(dhtOpts, dht.Mode(dht.ModeClient))
 		} else if dhtMode == config.DHTServerMode {
@@ -63,13 +146,26 @@ func NewDaemon(ctx context.Context, maddr ma.Multiaddr, dhtMode string, opts ...
 
 	h, err := libp2p.New(opts...)
 	if err != nil {
+		d.closeDHT()
 		return nil, err
 	}
 	d.host = h
+	h.Network().Notify(&network.NotifyBundle{DisconnectedF: d.reservations.disconnected})
+
+	sub, err := h.EventBus().Subscribe(new(event.EvtLocalReachabilityChanged))
+	if err != nil {
+		h.Close()
+		d.closeDHT()
+		return nil, err
+	}
+	d.reachabilitySub = sub
+	go d.trackReachability(sub)
 
 	l, err := manet.Listen(maddr)
 	if err != nil {
+		d.reachabilitySub.Close()
 		h.Close()
+		d.closeDHT()
 		return nil, err
 	}
 	d.listener = l
@@ -97,8 +193,27 @@ func (d *Daemon) DHTRoutingFactory(opts []dhtopts.Option) func(host.Host) (routi
 	return makeRouting
 }
 
-func (d *Daemon) EnableRelayV2() error {
-	_, err := relay.New(d.host)
+// closeDHT closes the DHT and then the store of its records.
+func (d *Daemon) closeDHT() error {
+	var merr *multierror.Error
+	if d.dht != nil {
+		if err := d.dht.Close(); err != nil {
+			merr = multierror.Append(merr, err)
+		}
+	}
+	if d.dhtStore != nil {
+		if err := d.dhtStore.Close(); err != nil {
+			merr = multierror.Append(merr, err)
+		}
+	}
+	return merr.ErrorOrNil()
+}
+
+// EnableRelayV2 starts a circuit relay v2 service with opts, reporting its
+// usage in the libp2p_relaysvc metrics.
+func (d *Daemon) EnableRelayV2(opts ...relay.Option) error {
+	opts = append([]relay.Option{relay.WithMetricsTracer(relay.NewMetricsTracer())}, opts...)
+	_, err := relay.New(d.host, opts...)
 	return err
 }
 
@@ -112,6 +227,7 @@ func (d *Daemon) EnablePubsub(router string, sign, strict bool) error {
 	} else {
 		opts = append(opts, ps.WithMessageSignaturePolicy(ps.StrictSign))
 	}
+	opts = append(opts, ps.WithRawTracer(d.mesh))
 
 	switch router {
 	case "floodsub":
@@ -198,11 +314,27 @@ func (d *Daemon) Close() error {
 	d.closed = true
 	d.mx.Unlock()
 
+	d.reservations.closeAll()
+
 	var merr *multierror.Error
+	d.mx.Lock()
+	if d.mdns != nil {
+		if err := d.mdns.Close(); err != nil {
+			merr = multierror.Append(merr, err)
+		}
+	}
+	d.mx.Unlock()
+	if err := d.reachabilitySub.Close(); err != nil {
+		merr = multierror.Append(merr, err)
+	}
 	if err := d.host.Close(); err != nil {
 		merr = multierror.Append(err)
 	}
 
+	if err := d.closeDHT(); err != nil {
+		merr = multierror.Append(merr, err)
+	}
+
 	listenAddr := d.listener.Multiaddr()
 	if err := d.listener.Close(); err != nil {
 		merr = multierror.Append(merr, err)
@@ -212,5 +344,17 @@ func (d *Daemon) Close() error {
 		merr = multierror.Append(merr, err)
 	}
 
+	if d.audit != nil {
+		if err := d.audit.close(); err != nil {
+			merr = multierror.Append(merr, err)
+		}
+	}
+
+	if d.tracingShutdown != nil {
+		if err := d.tracingShutdown(); err != nil {
+			merr = multierror.Append(merr, err)
+		}
+	}
+
 	return merr.ErrorOrNil()
 }
@@ -8,12 +8,13 @@ import (
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 
+	ggio "github.com/gogo/protobuf/io"
 	cid "github.com/ipfs/go-cid"
 )
 
 const defaultProviderCount = 20
 
-func (d *Daemon) doDHT(req *pb.Request) (*pb.Response, <-chan *pb.DHTResponse, func()) {
+func (d *Daemon) doDHT(ctx context.Context, req *pb.Request) (*pb.Response, <-chan *pb.DHTResponse, func()) {
 	if d.dht == nil {
 		return errorResponseString("DHT not enabled"), nil, nil
 	}
@@ -24,31 +25,34 @@ func (d *Daemon) doDHT(req *pb.Request) (*pb.Response, <-chan *pb.DHTResponse, f
 
 	switch req.Dht.GetType() {
 	case pb.DHTRequest_FIND_PEER:
-		return d.doDHTFindPeer(req.Dht)
+		return d.doDHTFindPeer(ctx, req.Dht)
 
 	case pb.DHTRequest_FIND_PEERS_CONNECTED_TO_PEER:
-		return d.doDHTFindPeersConnectedToPeer(req.Dht)
+		return d.doDHTFindPeersConnectedToPeer(ctx, req.Dht)
 
 	case pb.DHTRequest_FIND_PROVIDERS:
-		return d.doDHTFindProviders(req.Dht)
+		return d.doDHTFindProviders(ctx, req.Dht)
 
 	case pb.DHTRequest_GET_CLOSEST_PEERS:
-		return d.doDHTGetClosestPeers(req.Dht)
+		return d.doDHTGetClosestPeers(ctx, req.Dht)
 
 	case pb.DHTRequest_GET_PUBLIC_KEY:
-		return d.doDHTGetPublicKey(req.Dht)
+		return d.doDHTGetPublicKey(ctx, req.Dht)
 
 	case pb.DHTRequest_GET_VALUE:
-		return d.doDHTGetValue(req.Dht)
+		return d.doDHTGetValue(ctx, req.Dht)
 
 	case pb.DHTRequest_SEARCH_VALUE:
-		return d.doDHTSearchValue(req.Dht)
+		return d.doDHTSearchValue(ctx, req.Dht)
 
 	case pb.DHTRequest_PUT_VALUE:
-		return d.doDHTPutValue(req.Dht)
+		return d.doDHTPutValue(ctx, req.Dht)
 
 	case pb.DHTRequest_PROVIDE:
-		return d.doDHTProvide(req.Dht)
+		return d.doDHTProvide(ctx, req.Dht)
+
+	case pb.DHTRequest_STORAGE_STATS:
+		return d.doDHTStorageStats(ctx, req.Dht)
 
 	default:
 		log.Debugw("unexpected DHT request type", "type", req.Dht.GetType())
@@ -56,7 +60,7 @@ func (d *Daemon) doDHT(req *pb.Request) (*pb.Response, <-chan *pb.DHTResponse, f
 	}
 }
 
-func (d *Daemon) doDHTFindPeer(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
+func (d *Daemon) doDHTFindPeer(ctx context.Context, req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
 	if req.Peer == nil {
 		return errorResponseString("Malformed request; missing peer parameter"), nil, nil
 	}
@@ -66,7 +70,7 @@ func (d *Daemon) doDHTFindPeer(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHT
 		return errorResponse(err), nil, nil
 	}
 
-	ctx, cancel := d.dhtRequestContext(req)
+	ctx, cancel := d.dhtRequestContext(ctx, req)
 	defer cancel()
 
 	pi, err := d.dht.FindPeer(ctx, p)
@@ -77,11 +81,11 @@ func (d *Daemon) doDHTFindPeer(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHT
 	return dhtOkResponse(dhtResponsePeerInfo(pi)), nil, nil
 }
 
-func (d *Daemon) doDHTFindPeersConnectedToPeer(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
+func (d *Daemon) doDHTFindPeersConnectedToPeer(ctx context.Context, req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
 	return errorResponseString("not supported"), nil, nil
 }
 
-func (d *Daemon) doDHTFindProviders(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
+func (d *Daemon) doDHTFindProviders(ctx context.Context, req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
 	if req.Cid == nil {
 		return errorResponseString("Malformed request; missing cid parameter"), nil, nil
 	}
@@ -96,7 +100,7 @@ func (d *Daemon) doDHTFindProviders(req *pb.DHTRequest) (*pb.Response, <-chan *p
 		count = int(*req.Count)
 	}
 
-	ctx, cancel := d.dhtRequestContext(req)
+	ctx, cancel := d.dhtRequestContext(ctx, req)
 
 	ch := d.dht.FindProvidersAsync(ctx, cid, count)
 
@@ -116,12 +120,12 @@ func (d *Daemon) doDHTFindProviders(req *pb.DHTRequest) (*pb.Response, <-chan *p
 	return dhtOkResponse(dhtResponseBegin()), rch, cancel
 }
 
-func (d *Daemon) doDHTGetClosestPeers(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
+func (d *Daemon) doDHTGetClosestPeers(ctx context.Context, req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
 	if req.Key == nil {
 		return errorResponseString("Malformed request; missing key parameter"), nil, nil
 	}
 
-	ctx, cancel := d.dhtRequestContext(req)
+	ctx, cancel := d.dhtRequestContext(ctx, req)
 
 	keyString := string(req.Key)
 	ch, err := d.dht.GetClosestPeers(ctx, keyString)
@@ -146,7 +150,7 @@ func (d *Daemon) doDHTGetClosestPeers(req *pb.DHTRequest) (*pb.Response, <-chan
 	return dhtOkResponse(dhtResponseBegin()), rch, cancel
 }
 
-func (d *Daemon) doDHTGetPublicKey(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
+func (d *Daemon) doDHTGetPublicKey(ctx context.Context, req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
 	if req.Peer == nil {
 		return errorResponseString("Malformed request; missing peer parameter"), nil, nil
 	}
@@ -156,7 +160,7 @@ func (d *Daemon) doDHTGetPublicKey(req *pb.DHTRequest) (*pb.Response, <-chan *pb
 		return errorResponse(err), nil, nil
 	}
 
-	ctx, cancel := d.dhtRequestContext(req)
+	ctx, cancel := d.dhtRequestContext(ctx, req)
 	defer cancel()
 
 	key, err := d.dht.GetPublicKey(ctx, p)
@@ -171,12 +175,12 @@ func (d *Daemon) doDHTGetPublicKey(req *pb.DHTRequest) (*pb.Response, <-chan *pb
 	return dhtOkResponse(res), nil, nil
 }
 
-func (d *Daemon) doDHTGetValue(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
+func (d *Daemon) doDHTGetValue(ctx context.Context, req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
 	if req.Key == nil {
 		return errorResponseString("Malformed request; missing key parameter"), nil, nil
 	}
 
-	ctx, cancel := d.dhtRequestContext(req)
+	ctx, cancel := d.dhtRequestContext(ctx, req)
 	defer cancel()
 
 	keyString := string(req.Key)
@@ -188,12 +192,12 @@ func (d *Daemon) doDHTGetValue(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHT
 	return dhtOkResponse(dhtResponseValue(val)), nil, nil
 }
 
-func (d *Daemon) doDHTSearchValue(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
+func (d *Daemon) doDHTSearchValue(ctx context.Context, req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
 	if req.Key == nil {
 		return errorResponseString("Malformed request; missing key parameter"), nil, nil
 	}
 
-	ctx, cancel := d.dhtRequestContext(req)
+	ctx, cancel := d.dhtRequestContext(ctx, req)
 
 	keyString := string(req.Key)
 	ch, err := d.dht.SearchValue(ctx, keyString)
@@ -218,7 +222,7 @@ func (d *Daemon) doDHTSearchValue(req *pb.DHTRequest) (*pb.Response, <-chan *pb.
 	return dhtOkResponse(dhtResponseBegin()), rch, cancel
 }
 
-func (d *Daemon) doDHTPutValue(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
+func (d *Daemon) doDHTPutValue(ctx context.Context, req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
 	if req.Key == nil {
 		return errorResponseString("Malformed request; missing key parameter"), nil, nil
 	}
@@ -227,7 +231,7 @@ func (d *Daemon) doDHTPutValue(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHT
 		return errorResponseString("Malformed request; missing value parameter"), nil, nil
 	}
 
-	ctx, cancel := d.dhtRequestContext(req)
+	ctx, cancel := d.dhtRequestContext(ctx, req)
 	defer cancel()
 
 	keyString := string(req.Key)
@@ -239,7 +243,7 @@ func (d *Daemon) doDHTPutValue(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHT
 	return okResponse(), nil, nil
 }
 
-func (d *Daemon) doDHTProvide(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
+func (d *Daemon) doDHTProvide(ctx context.Context, req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
 	if req.Cid == nil {
 		return errorResponseString("Malformed request; missing cid parameter"), nil, nil
 	}
@@ -249,7 +253,7 @@ func (d *Daemon) doDHTProvide(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTR
 		return errorResponse(err), nil, nil
 	}
 
-	ctx, cancel := d.dhtRequestContext(req)
+	ctx, cancel := d.dhtRequestContext(ctx, req)
 	defer cancel()
 
 	err = d.dht.Provide(ctx, cid, true)
@@ -260,8 +264,8 @@ func (d *Daemon) doDHTProvide(req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTR
 	return okResponse(), nil, nil
 }
 
-func (d *Daemon) dhtRequestContext(req *pb.DHTRequest) (context.Context, func()) {
-	return d.requestContext(req.GetTimeout())
+func (d *Daemon) dhtRequestContext(ctx context.Context, req *pb.DHTRequest) (context.Context, func()) {
+	return d.requestContext(ctx, req.GetTimeout(), req.GetTimeoutMillis())
 }
 
 func dhtResponseBegin() *pb.DHTResponse {
@@ -270,6 +274,22 @@ func dhtResponseBegin() *pb.DHTResponse {
 	}
 }
 
+// doDHTStream writes the responses of a streaming query to the client,
+// followed by the end marker.
+func (d *Daemon) doDHTStream(ch <-chan *pb.DHTResponse, w ggio.Writer) error {
+	dhtStreams.Inc()
+	defer dhtStreams.Dec()
+
+	for res := range ch {
+		err := w.WriteMsg(res)
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.WriteMsg(dhtResponseEnd())
+}
+
 func dhtResponseEnd() *pb.DHTResponse {
 	return &pb.DHTResponse{
 		Type: pb.DHTResponse_END.Enum(),
@@ -319,3 +339,18 @@ func peerInfo2pb(pi peer.AddrInfo) *pb.PeerInfo {
 		Addrs: addrs,
 	}
 }
+
+func (d *Daemon) doDHTStorageStats(ctx context.Context, req *pb.DHTRequest) (*pb.Response, <-chan *pb.DHTResponse, func()) {
+	ctx, cancel := d.dhtRequestContext(ctx, req)
+	defer cancel()
+
+	stats, err := d.dhtStore.stats(ctx)
+	if err != nil {
+		return errorResponse(err), nil, nil
+	}
+
+	return dhtOkResponse(&pb.DHTResponse{
+		Type:    pb.DHTResponse_VALUE.Enum(),
+		Storage: stats,
+	}), nil, nil
+}
@@ -0,0 +1,160 @@
+package p2pd
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-daemon/config"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	"github.com/gogo/protobuf/proto"
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	dssync "github.com/ipfs/go-datastore/sync"
+	leveldb "github.com/ipfs/go-ds-leveldb"
+	"github.com/libp2p/go-libp2p-kad-dht/providers"
+	recpb "github.com/libp2p/go-libp2p-record/pb"
+)
+
+// maxRecordAge is how long the DHT keeps the value records it stores, which
+// is the DHT's default too.
+var maxRecordAge = providers.ProvideValidity
+
+// DHTStore is the datastore where the DHT keeps the value and provider
+// records it stores, purging expired value records periodically; the DHT
+// purges expired provider records itself.
+type DHTStore struct {
+	ds ds.Batching
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDHTStore opens the DHT datastore configured by c, kept in memory if c
+// has no path.
+func NewDHTStore(c config.DHTDatastore) (*DHTStore, error) {
+	var store ds.Batching
+	if c.Path == "" {
+		store = dssync.MutexWrap(ds.NewMapDatastore())
+	} else {
+		ldb, err := leveldb.NewDatastore(c.Path, nil)
+		if err != nil {
+			return nil, err
+		}
+		store = ldb
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &DHTStore{ds: store, cancel: cancel}
+	s.wg.Add(1)
+	go s.background(ctx, c.GCInterval)
+	return s, nil
+}
+
+func (s *DHTStore) background(ctx context.Context, interval time.Duration) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.gc(ctx); err != nil && ctx.Err() == nil {
+				log.Warnw("error purging expired DHT records", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// gc deletes the value records older than maxRecordAge, along with those
+// that can't be read, as the DHT does when it comes across them.
+func (s *DHTStore) gc(ctx context.Context) error {
+	res, err := s.ds.Query(ctx, dsq.Query{})
+	if err != nil {
+		return err
+	}
+	defer res.Close()
+
+	var expired []ds.Key
+	for r := range res.Next() {
+		if r.Error != nil {
+			return r.Error
+		}
+		if isProviderKey(r.Key) {
+			continue
+		}
+		if !recordExpired(r.Value) {
+			continue
+		}
+		expired = append(expired, ds.RawKey(r.Key))
+	}
+
+	for _, k := range expired {
+		if err := s.ds.Delete(ctx, k); err != nil && err != ds.ErrNotFound {
+			return err
+		}
+	}
+	if len(expired) > 0 {
+		log.Debugw("purged expired DHT records", "count", len(expired))
+	}
+	return nil
+}
+
+func recordExpired(b []byte) bool {
+	rec := new(recpb.Record)
+	if err := proto.Unmarshal(b, rec); err != nil {
+		return true
+	}
+	received, err := time.Parse(time.RFC3339Nano, rec.GetTimeReceived())
+	if err != nil {
+		return true
+	}
+	return time.Since(received) > maxRecordAge
+}
+
+func isProviderKey(k string) bool {
+	return strings.HasPrefix(k, providers.ProvidersKeyPrefix)
+}
+
+// stats counts the records in the store.
+func (s *DHTStore) stats(ctx context.Context) (*pb.DHTStorageStats, error) {
+	res, err := s.ds.Query(ctx, dsq.Query{KeysOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer res.Close()
+
+	var records, providerRecords int64
+	keys := make(map[string]struct{})
+	for r := range res.Next() {
+		if r.Error != nil {
+			return nil, r.Error
+		}
+		if !isProviderKey(r.Key) {
+			records++
+			continue
+		}
+		// provider records are keyed /providers/<key>/<peer>
+		k := ds.RawKey(r.Key)
+		providerRecords++
+		keys[k.Parent().String()] = struct{}{}
+	}
+
+	return &pb.DHTStorageStats{
+		Records:      &records,
+		Providers:    &providerRecords,
+		ProvidedKeys: proto.Int64(int64(len(keys))),
+	}, nil
+}
+
+// Close stops purging expired records and closes the datastore.
+func (s *DHTStore) Close() error {
+	s.cancel()
+	s.wg.Wait()
+	return s.ds.Close()
+}
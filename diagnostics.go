@@ -0,0 +1,252 @@
+package p2pd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	"github.com/gogo/protobuf/jsonpb"
+	proto "github.com/gogo/protobuf/proto"
+	kb "github.com/libp2p/go-libp2p-kbucket"
+	ps "github.com/libp2p/go-libp2p-pubsub"
+)
+
+// meshTracer keeps track of the gossipsub mesh of each topic, which pubsub
+// doesn't otherwise expose.
+type meshTracer struct {
+	mx   sync.Mutex
+	mesh map[string]map[peer.ID]struct{}
+}
+
+var _ ps.RawTracer = (*meshTracer)(nil)
+
+func newMeshTracer() *meshTracer {
+	return &meshTracer{mesh: make(map[string]map[peer.ID]struct{})}
+}
+
+func (t *meshTracer) meshPeers(topic string) []peer.ID {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	peers := make([]peer.ID, 0, len(t.mesh[topic]))
+	for p := range t.mesh[topic] {
+		peers = append(peers, p)
+	}
+	return peers
+}
+
+func (t *meshTracer) Graft(p peer.ID, topic string) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	peers, ok := t.mesh[topic]
+	if !ok {
+		peers = make(map[peer.ID]struct{})
+		t.mesh[topic] = peers
+	}
+	peers[p] = struct{}{}
+}
+
+func (t *meshTracer) Prune(p peer.ID, topic string) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	delete(t.mesh[topic], p)
+}
+
+func (t *meshTracer) RemovePeer(p peer.ID) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	for _, peers := range t.mesh {
+		delete(peers, p)
+	}
+}
+
+func (t *meshTracer) Leave(topic string) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	delete(t.mesh, topic)
+}
+
+func (t *meshTracer) AddPeer(p peer.ID, proto protocol.ID)         {}
+func (t *meshTracer) Join(topic string)                            {}
+func (t *meshTracer) ValidateMessage(msg *ps.Message)              {}
+func (t *meshTracer) DeliverMessage(msg *ps.Message)               {}
+func (t *meshTracer) RejectMessage(msg *ps.Message, reason string) {}
+func (t *meshTracer) DuplicateMessage(msg *ps.Message)             {}
+func (t *meshTracer) ThrottlePeer(p peer.ID)                       {}
+func (t *meshTracer) RecvRPC(rpc *ps.RPC)                          {}
+func (t *meshTracer) SendRPC(rpc *ps.RPC, p peer.ID)               {}
+func (t *meshTracer) DropRPC(rpc *ps.RPC, p peer.ID)               {}
+func (t *meshTracer) UndeliverableMessage(msg *ps.Message)         {}
+
+func (d *Daemon) doDiagnostics(req *pb.Request) *pb.Response {
+	res := okResponse()
+	res.Diagnostics = d.diagnostics()
+	return res
+}
+
+// diagnostics takes a snapshot of the daemon's state.
+func (d *Daemon) diagnostics() *pb.DiagnosticsResponse {
+	diag := &pb.DiagnosticsResponse{
+		Goroutines:         proto.Int64(int64(runtime.NumGoroutine())),
+		PrivateNetwork:     proto.Bool(d.privateNetwork),
+		HolePunching:       proto.Bool(d.holePunch != nil),
+		HolePunchingActive: proto.Bool(d.holePunch != nil && d.holePunchReady()),
+	}
+
+	if d.dht != nil {
+		self := kb.ConvertPeerID(d.ID())
+		buckets := make(map[int]*pb.DiagnosticsBucket)
+		for _, p := range d.dht.RoutingTable().ListPeers() {
+			cpl := kb.CommonPrefixLen(self, kb.ConvertPeerID(p))
+			b, ok := buckets[cpl]
+			if !ok {
+				b = &pb.DiagnosticsBucket{Cpl: proto.Uint32(uint32(cpl))}
+				buckets[cpl] = b
+				diag.RoutingTable = append(diag.RoutingTable, b)
+			}
+			b.Peers = append(b.Peers, []byte(p))
+		}
+		sort.Slice(diag.RoutingTable, func(i, j int) bool {
+			return diag.RoutingTable[i].GetCpl() < diag.RoutingTable[j].GetCpl()
+		})
+	}
+
+	pstore := d.host.Peerstore()
+	for _, c := range d.host.Network().Conns() {
+		p := c.RemotePeer()
+		conn := &pb.DiagnosticsConn{
+			Peer:      []byte(p),
+			Addr:      c.RemoteMultiaddr().Bytes(),
+			Direction: proto.String(c.Stat().Direction.String()),
+		}
+
+		if v, err := pstore.Get(p, "AgentVersion"); err == nil {
+			if s, ok := v.(string); ok {
+				conn.AgentVersion = proto.String(s)
+			}
+		}
+		if v, err := pstore.Get(p, "ProtocolVersion"); err == nil {
+			if s, ok := v.(string); ok {
+				conn.ProtocolVersion = proto.String(s)
+			}
+		}
+
+		protos, _ := pstore.GetProtocols(p) // error value here is useless
+		for _, pid := range protos {
+			conn.Protocols = append(conn.Protocols, string(pid))
+		}
+
+		for _, s := range c.GetStreams() {
+			conn.Streams = append(conn.Streams, &pb.DiagnosticsStream{
+				Proto:     proto.String(string(s.Protocol())),
+				Direction: proto.String(s.Stat().Direction.String()),
+			})
+		}
+
+		diag.Conns = append(diag.Conns, conn)
+	}
+
+	if d.pubsub != nil {
+		for _, topic := range d.pubsub.GetTopics() {
+			t := &pb.DiagnosticsTopic{Topic: proto.String(topic)}
+			for _, p := range d.pubsub.ListPeers(topic) {
+				t.Peers = append(t.Peers, []byte(p))
+			}
+			for _, p := range d.mesh.meshPeers(topic) {
+				t.MeshPeers = append(t.MeshPeers, []byte(p))
+			}
+			diag.Topics = append(diag.Topics, t)
+		}
+	}
+
+	d.mx.Lock()
+	for p, h := range d.handlers {
+		handler := &pb.DiagnosticsHandler{
+			Proto: proto.String(string(p)),
+			Shm:   proto.Bool(h.shm),
+		}
+		if h.conn != nil {
+			handler.PassFd = proto.Bool(true)
+		} else {
+			handler.Addr = h.addr.Bytes()
+		}
+		diag.Handlers = append(diag.Handlers, handler)
+	}
+	d.mx.Unlock()
+	sort.Slice(diag.Handlers, func(i, j int) bool {
+		return diag.Handlers[i].GetProto() < diag.Handlers[j].GetProto()
+	})
+
+	return diag
+}
+
+// writeDiagnostics writes a JSON snapshot of the daemon's state to w.
+func (d *Daemon) writeDiagnostics(w io.Writer) error {
+	m := jsonpb.Marshaler{Indent: "  "}
+	if err := m.Marshal(w, d.diagnostics()); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// DiagnosticsHandler returns an HTTP handler serving JSON snapshots of the
+// daemon's state.
+func (d *Daemon) DiagnosticsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := d.writeDiagnostics(w); err != nil {
+			log.Warnw("error writing diagnostics", "error", err)
+		}
+	})
+}
+
+// SetDiagnosticsFile sets the file SIGUSR1 writes diagnostics snapshots to;
+// they go to stdout when it is empty.
+func (d *Daemon) SetDiagnosticsFile(path string) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+	d.diagnosticsFile = path
+}
+
+// dumpDiagnostics writes a snapshot of the daemon's state to the diagnostics
+// file, replacing any previous one.
+func (d *Daemon) dumpDiagnostics() error {
+	d.mx.Lock()
+	path := d.diagnosticsFile
+	d.mx.Unlock()
+
+	if path == "" {
+		return d.writeDiagnostics(os.Stdout)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	err = d.writeDiagnostics(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("writing diagnostics: %w", err)
+	}
+
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,107 @@
+package p2pd
+
+import (
+	"sync"
+
+	ggio "github.com/gogo/protobuf/io"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// eventBufferSize is the number of events queued for a client before newer
+// ones are dropped.
+const eventBufferSize = 64
+
+// eventHub fans events out to the clients subscribed to them.
+type eventHub struct {
+	mx   sync.Mutex
+	subs map[*eventSub]struct{}
+}
+
+type eventSub struct {
+	// types are the event types the client receives; all of them if empty
+	types map[pb.Event_Type]bool
+	ch    chan *pb.Event
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{subs: make(map[*eventSub]struct{})}
+}
+
+func (h *eventHub) subscribe(types []pb.Event_Type) *eventSub {
+	sub := &eventSub{
+		types: make(map[pb.Event_Type]bool),
+		ch:    make(chan *pb.Event, eventBufferSize),
+	}
+	for _, t := range types {
+		sub.types[t] = true
+	}
+
+	h.mx.Lock()
+	h.subs[sub] = struct{}{}
+	h.mx.Unlock()
+	return sub
+}
+
+func (h *eventHub) unsubscribe(sub *eventSub) {
+	h.mx.Lock()
+	delete(h.subs, sub)
+	h.mx.Unlock()
+}
+
+// publish sends evt to the subscribed clients, dropping it for those too
+// slow to keep up.
+func (h *eventHub) publish(evt *pb.Event) {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+	for sub := range h.subs {
+		if len(sub.types) > 0 && !sub.types[evt.GetType()] {
+			continue
+		}
+		select {
+		case sub.ch <- evt:
+		default:
+			log.Debugw("dropping event for slow client", "type", evt.GetType())
+		}
+	}
+}
+
+func (d *Daemon) doEvents(req *pb.Request) (*pb.Response, *eventSub) {
+	var types []pb.Event_Type
+	if req.Events != nil {
+		types = req.Events.Types
+	}
+	return okResponse(), d.events.subscribe(types)
+}
+
+// doEventsPipe writes the events of sub to the client until it closes the
+// connection.
+func (d *Daemon) doEventsPipe(sub *eventSub, r ggio.ReadCloser, w ggio.WriteCloser) {
+	defer d.events.unsubscribe(sub)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// read something until the client closes the connection
+		for {
+			var req pb.Request
+			if err := r.ReadMsg(&req); err != nil {
+				return
+			}
+			log.Warnw("unexpected message", "type", req.GetType())
+		}
+	}()
+
+	for {
+		select {
+		case evt := <-sub.ch:
+			if err := w.WriteMsg(evt); err != nil {
+				log.Debugw("error writing event", "error", err)
+				return
+			}
+		case <-done:
+			return
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,24 @@
+//go:build windows || plan9 || nacl || js
+
+package p2pd
+
+import (
+	"errors"
+	"net"
+	"os"
+
+	"github.com/libp2p/go-libp2p-daemon/shm"
+)
+
+// fdPassingSupported reports whether streams can be handed to clients as sockets.
+const fdPassingSupported = false
+
+var errFdPassingUnsupported = errors.New("file descriptor passing is not supported on this platform")
+
+func socketPair() (net.Conn, *os.File, error) {
+	return nil, nil, errFdPassingUnsupported
+}
+
+func sendFile(c shm.UnixConn, f *os.File) error {
+	return errFdPassingUnsupported
+}
@@ -0,0 +1,42 @@
+//go:build !windows && !plan9 && !nacl && !js
+
+package p2pd
+
+import (
+	"net"
+	"os"
+
+	"github.com/libp2p/go-libp2p-daemon/shm"
+
+	"golang.org/x/sys/unix"
+)
+
+// fdPassingSupported reports whether streams can be handed to clients as sockets.
+const fdPassingSupported = true
+
+// socketPair creates a connected pair of unix sockets, returning one end as a
+// conn for the daemon and the other as a file to pass on to a client.
+func socketPair() (net.Conn, *os.File, error) {
+	fds, err := unix.Socketpair(unix.AF_UNIX, unix.SOCK_STREAM, 0)
+	if err != nil {
+		return nil, nil, os.NewSyscallError("socketpair", err)
+	}
+	unix.CloseOnExec(fds[0])
+	unix.CloseOnExec(fds[1])
+
+	local := os.NewFile(uintptr(fds[0]), "stream")
+	c, err := net.FileConn(local)
+	local.Close()
+	if err != nil {
+		unix.Close(fds[1])
+		return nil, nil, err
+	}
+
+	return c, os.NewFile(uintptr(fds[1]), "stream"), nil
+}
+
+// sendFile passes f to the peer at the other end of c.
+func sendFile(c shm.UnixConn, f *os.File) error {
+	_, _, err := c.WriteMsgUnix([]byte{0}, unix.UnixRights(int(f.Fd())), nil)
+	return err
+}
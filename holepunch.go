@@ -0,0 +1,147 @@
+package p2pd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/holepunch"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// hole punch outcomes, for metrics
+const (
+	outcomeSuccess       = "success"
+	outcomeFailure       = "failure"
+	outcomeProtocolError = "protocol_error"
+)
+
+// holePunchOptions returns the options of the hole punching service, with
+// those set in opts, tracing hole punches to the daemon's event stream and
+// metrics, and keeping the service for DIRECT_CONNECT requests.
+func (d *Daemon) holePunchOptions(opts []holepunch.Option) []holepunch.Option {
+	return append(slices.Clone(opts),
+		holepunch.WithMetricsAndEventTracer(holepunch.NewMetricsTracer(), (*holePunchTracer)(d)),
+		func(s *holepunch.Service) error {
+			d.holePunch = s
+			return nil
+		},
+	)
+}
+
+// holePunchTracer publishes hole punching events.
+type holePunchTracer Daemon
+
+var _ holepunch.EventTracer = (*holePunchTracer)(nil)
+
+func (t *holePunchTracer) Trace(evt *holepunch.Event) {
+	hp := &pb.HolePunchEvent{}
+	switch e := evt.Evt.(type) {
+	case *holepunch.DirectDialEvt:
+		hp.Type = pb.HolePunchEvent_DIRECT_DIAL.Enum()
+		hp.Success = &e.Success
+		hp.ElapsedMillis = durationMillis(e.EllapsedTime)
+		hp.Error = optionalString(e.Error)
+		holePunchOutcomes.WithLabelValues("direct_dial", outcome(e.Success)).Inc()
+	case *holepunch.ProtocolErrorEvt:
+		hp.Type = pb.HolePunchEvent_PROTOCOL_ERROR.Enum()
+		hp.Error = optionalString(e.Error)
+		holePunchOutcomes.WithLabelValues("hole_punch", outcomeProtocolError).Inc()
+	case *holepunch.StartHolePunchEvt:
+		hp.Type = pb.HolePunchEvent_START.Enum()
+		hp.RttMillis = durationMillis(e.RTT)
+		for _, s := range e.RemoteAddrs {
+			if addr, err := ma.NewMultiaddr(s); err == nil {
+				hp.Addrs = append(hp.Addrs, addr.Bytes())
+			}
+		}
+	case *holepunch.HolePunchAttemptEvt:
+		hp.Type = pb.HolePunchEvent_ATTEMPT.Enum()
+		attempt := int32(e.Attempt)
+		hp.Attempt = &attempt
+		holePunchAttempts.Inc()
+	case *holepunch.EndHolePunchEvt:
+		hp.Type = pb.HolePunchEvent_END.Enum()
+		hp.Success = &e.Success
+		hp.ElapsedMillis = durationMillis(e.EllapsedTime)
+		hp.Error = optionalString(e.Error)
+		holePunchOutcomes.WithLabelValues("hole_punch", outcome(e.Success)).Inc()
+	default:
+		log.Debugw("unknown hole punch event", "type", evt.Type)
+		return
+	}
+
+	t.events.publish(&pb.Event{
+		Type:      pb.Event_HOLE_PUNCH.Enum(),
+		Time:      &evt.Timestamp,
+		Peer:      []byte(evt.Remote),
+		HolePunch: hp,
+	})
+}
+
+func outcome(success bool) string {
+	if success {
+		return outcomeSuccess
+	}
+	return outcomeFailure
+}
+
+func durationMillis(d time.Duration) *int64 {
+	ms := d.Milliseconds()
+	return &ms
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// holePunchReady reports whether the hole punching service punches holes:
+// once the host has a public address and learns it is behind a NAT.
+func (d *Daemon) holePunchReady() bool {
+	if d.getReachability() != network.ReachabilityPrivate {
+		return false
+	}
+	return slices.Contains(d.host.Mux().Protocols(), holepunch.Protocol)
+}
+
+func (d *Daemon) doDirectConnect(ctx context.Context, req *pb.Request) *pb.Response {
+	if req.DirectConnect == nil {
+		return errorResponseString("Malformed request; missing parameters")
+	}
+	if d.holePunch == nil {
+		return errorResponseString("hole punching not enabled")
+	}
+
+	p, err := peer.IDFromBytes(req.DirectConnect.Peer)
+	if err != nil {
+		return errorResponse(err)
+	}
+	if !d.holePunchReady() {
+		return errorResponseString("hole punching not active; the daemon has no public address or isn't behind a NAT")
+	}
+
+	ctx, cancel := d.requestContext(ctx, req.DirectConnect.GetTimeout(), req.DirectConnect.GetTimeoutMillis())
+	defer cancel()
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- d.holePunch.DirectConnect(p)
+	}()
+	select {
+	case err := <-errc:
+		if err != nil {
+			return errorResponse(fmt.Errorf("direct connection to %s failed: %w", p, err))
+		}
+		return okResponse()
+	case <-ctx.Done():
+		return errorResponse(ctx.Err())
+	}
+}
@@ -1,18 +1,153 @@
 package p2pd
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/libp2p/go-libp2p/core/crypto"
+	pb "github.com/libp2p/go-libp2p/core/crypto/pb"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/term"
+)
+
+// PassphraseEnv is the environment variable the passphrase of encrypted
+// identities is read from.
+const PassphraseEnv = "P2PD_ID_PASSPHRASE"
+
+var (
+	// ErrIdentityEncrypted is returned when reading an encrypted identity
+	// without a passphrase.
+	ErrIdentityEncrypted = errors.New("identity is encrypted; a passphrase is required")
+	// ErrWrongPassphrase is returned when an encrypted identity can't be
+	// decrypted with the passphrase given.
+	ErrWrongPassphrase = errors.New("wrong passphrase for encrypted identity")
+)
+
+// Encrypted identities are stored as a header, authenticated along with the
+// key, followed by the marshaled private key sealed with XChaCha20-Poly1305,
+// with a key derived from the passphrase with argon2id:
+//
+//	magic    "p2pd-key"
+//	version  1 byte
+//	time     4 bytes, argon2id iterations
+//	memory   4 bytes, argon2id memory in KiB
+//	threads  1 byte, argon2id parallelism
+//	salt     16 bytes
+//	nonce    24 bytes
+//
+// Plaintext identities are the marshaled private key alone, which can't
+// start with the magic, or PEM.
+const (
+	identityMagic   = "p2pd-key"
+	identityVersion = 1
+
+	identitySaltSize   = 16
+	identityHeaderSize = len(identityMagic) + 1 + 4 + 4 + 1 + identitySaltSize + chacha20poly1305.NonceSizeX
+)
+
+// PEM identities are PKCS#8 private keys.
+const (
+	pemPrefix     = "-----BEGIN "
+	pemPrivateKey = "PRIVATE KEY"
 )
 
+// argon2id parameters of new encrypted identities
+const (
+	identityKDFTime    = 3
+	identityKDFMemory  = 64 * 1024
+	identityKDFThreads = 4
+)
+
+// identityKDFMaxMemory bounds the memory an encrypted identity can make
+// reading it use, in KiB.
+const identityKDFMaxMemory = 4 * 1024 * 1024
+
+// KeyType returns the crypto key type named name: rsa, ed25519, secp256k1
+// or ecdsa.
+func KeyType(name string) (int, error) {
+	switch name {
+	case "rsa":
+		return crypto.RSA, nil
+	case "ed25519":
+		return crypto.Ed25519, nil
+	case "secp256k1":
+		return crypto.Secp256k1, nil
+	case "ecdsa":
+		return crypto.ECDSA, nil
+	default:
+		return 0, fmt.Errorf("unknown key type %s; must be rsa, ed25519, secp256k1 or ecdsa", name)
+	}
+}
+
+// ReadOrGenerateIdentity reads the identity at path like
+// ReadIdentityPassphrase, refusing to if other users can access the file, or
+// generates a key of type typ, with bits for RSA keys, and writes it to path
+// if there is no file there yet.
+func ReadOrGenerateIdentity(path string, typ, bits int, passphrase func() ([]byte, error)) (crypto.PrivKey, error) {
+	fi, err := os.Stat(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		priv, _, err := crypto.GenerateKeyPair(typ, bits)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil, err
+		}
+		if err := WriteIdentity(priv, path); err != nil {
+			return nil, err
+		}
+		log.Infow("generated identity", "path", path)
+		return priv, nil
+
+	case err != nil:
+		return nil, err
+	}
+
+	if err := checkIdentityMode(fi); err != nil {
+		return nil, fmt.Errorf("identity %s: %w", path, err)
+	}
+	return ReadIdentityPassphrase(path, passphrase)
+}
+
 func ReadIdentity(path string) (crypto.PrivKey, error) {
-	bytes, err := os.ReadFile(path)
+	return ReadIdentityPassphrase(path, nil)
+}
+
+// ReadIdentityPassphrase reads a plaintext, PEM or encrypted identity,
+// calling passphrase for the passphrase of encrypted ones only.
+func ReadIdentityPassphrase(path string, passphrase func() ([]byte, error)) (crypto.PrivKey, error) {
+	b, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
-	return crypto.UnmarshalPrivateKey(bytes)
+	if bytes.HasPrefix(b, []byte(pemPrefix)) {
+		return unmarshalPEMIdentity(b)
+	}
+	if !bytes.HasPrefix(b, []byte(identityMagic)) {
+		return crypto.UnmarshalPrivateKey(b)
+	}
+
+	if passphrase == nil {
+		return nil, ErrIdentityEncrypted
+	}
+	pass, err := passphrase()
+	if err != nil {
+		return nil, err
+	}
+	return decryptIdentity(b, pass)
 }
 
 func WriteIdentity(k crypto.PrivKey, path string) error {
@@ -23,3 +158,144 @@ func WriteIdentity(k crypto.PrivKey, path string) error {
 
 	return os.WriteFile(path, bytes, 0400)
 }
+
+// WriteEncryptedIdentity writes k encrypted with passphrase.
+func WriteEncryptedIdentity(k crypto.PrivKey, path string, passphrase []byte) error {
+	b, err := encryptIdentity(k, passphrase)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b, 0400)
+}
+
+// WritePEMIdentity writes k as PKCS#8 PEM, as other tools do. Secp256k1
+// keys can't be written this way.
+func WritePEMIdentity(k crypto.PrivKey, path string) error {
+	if k.Type() == pb.KeyType_Secp256k1 {
+		return fmt.Errorf("secp256k1 keys can't be written as PKCS#8")
+	}
+	std, err := crypto.PrivKeyToStdKey(k)
+	if err != nil {
+		return err
+	}
+	if p, ok := std.(*ed25519.PrivateKey); ok {
+		std = *p
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(std)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: pemPrivateKey, Bytes: der}), 0400)
+}
+
+func unmarshalPEMIdentity(b []byte) (crypto.PrivKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("invalid PEM identity")
+	}
+	if block.Type != pemPrivateKey {
+		return nil, fmt.Errorf("unsupported PEM identity of type %s; must be an unencrypted PKCS#8 %s", block.Type, pemPrivateKey)
+	}
+
+	std, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := std.(ed25519.PrivateKey); ok {
+		std = &p
+	}
+
+	priv, _, err := crypto.KeyPairFromStdKey(std)
+	return priv, err
+}
+
+func encryptIdentity(k crypto.PrivKey, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("empty passphrase")
+	}
+
+	plain, err := crypto.MarshalPrivateKey(k)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, identityHeaderSize)
+	header = append(header, identityMagic...)
+	header = append(header, identityVersion)
+	header = binary.BigEndian.AppendUint32(header, identityKDFTime)
+	header = binary.BigEndian.AppendUint32(header, identityKDFMemory)
+	header = append(header, identityKDFThreads)
+	random := make([]byte, identitySaltSize+chacha20poly1305.NonceSizeX)
+	if _, err := rand.Read(random); err != nil {
+		return nil, err
+	}
+	header = append(header, random...)
+	salt, nonce := random[:identitySaltSize], random[identitySaltSize:]
+
+	key := argon2.IDKey(passphrase, salt, identityKDFTime, identityKDFMemory, identityKDFThreads, chacha20poly1305.KeySize)
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(header, nonce, plain, header), nil
+}
+
+func decryptIdentity(b []byte, passphrase []byte) (crypto.PrivKey, error) {
+	if len(b) < identityHeaderSize {
+		return nil, fmt.Errorf("encrypted identity is truncated")
+	}
+	header, sealed := b[:identityHeaderSize], b[identityHeaderSize:]
+
+	h := header[len(identityMagic):]
+	if version := h[0]; version != identityVersion {
+		return nil, fmt.Errorf("unsupported encrypted identity version %d", version)
+	}
+	iterations := binary.BigEndian.Uint32(h[1:5])
+	memory := binary.BigEndian.Uint32(h[5:9])
+	threads := h[9]
+	if iterations == 0 || threads == 0 || memory > identityKDFMaxMemory {
+		return nil, fmt.Errorf("invalid encrypted identity key derivation parameters")
+	}
+	salt := h[10 : 10+identitySaltSize]
+	nonce := h[10+identitySaltSize:]
+
+	key := argon2.IDKey(passphrase, salt, iterations, memory, threads, chacha20poly1305.KeySize)
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := aead.Open(nil, nonce, sealed, header)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return crypto.UnmarshalPrivateKey(plain)
+}
+
+// ReadPassphrase reads the passphrase of an encrypted identity from file, if
+// set, from the PassphraseEnv environment variable, or else by prompting for
+// it on the terminal. A trailing newline in file is left out.
+func ReadPassphrase(file string, prompt string) ([]byte, error) {
+	if file != "" {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(strings.TrimRight(string(b), "\r\n")), nil
+	}
+
+	if pass, ok := os.LookupEnv(PassphraseEnv); ok {
+		return []byte(pass), nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, fmt.Errorf("no passphrase file or %s variable set, and no terminal to prompt on", PassphraseEnv)
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	pass, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	return pass, err
+}
@@ -0,0 +1,13 @@
+//go:build windows || plan9 || nacl || js
+
+package p2pd
+
+import (
+	"os"
+)
+
+// checkIdentityMode does nothing, as the file mode doesn't tell who can
+// access the file here.
+func checkIdentityMode(fi os.FileInfo) error {
+	return nil
+}
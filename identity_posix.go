@@ -0,0 +1,17 @@
+//go:build !windows && !plan9 && !nacl && !js
+
+package p2pd
+
+import (
+	"fmt"
+	"os"
+)
+
+// checkIdentityMode fails if users other than the owner can access an
+// identity file.
+func checkIdentityMode(fi os.FileInfo) error {
+	if mode := fi.Mode().Perm(); mode&0077 != 0 {
+		return fmt.Errorf("permissions %04o are too open; it must only be accessible by its owner", mode)
+	}
+	return nil
+}
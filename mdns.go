@@ -0,0 +1,75 @@
+package p2pd
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
+
+	"github.com/libp2p/go-libp2p-daemon/config"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// mdnsConnectTimeout bounds the connections to the peers discovered with
+// mDNS, when auto-connecting.
+const mdnsConnectTimeout = 10 * time.Second
+
+// EnableMDNS discovers peers on the local network with mDNS, announcing the
+// daemon to those browsing the service name of c. Discovered peers are added
+// to the peerstore and published as PEER_DISCOVERED events, and, with
+// AutoConnect, connected to.
+func (d *Daemon) EnableMDNS(c config.MDNS) error {
+	svc := mdns.NewMdnsService(d.host, c.ServiceName, &mdnsNotifee{d: d, autoConnect: c.AutoConnect})
+	if err := svc.Start(); err != nil {
+		return err
+	}
+
+	d.mx.Lock()
+	defer d.mx.Unlock()
+	if d.mdns != nil {
+		d.mdns.Close()
+	}
+	d.mdns = svc
+	return nil
+}
+
+type mdnsNotifee struct {
+	d           *Daemon
+	autoConnect bool
+}
+
+var _ mdns.Notifee = (*mdnsNotifee)(nil)
+
+func (n *mdnsNotifee) HandlePeerFound(pi peer.AddrInfo) {
+	d := n.d
+	if pi.ID == d.ID() {
+		return
+	}
+	d.host.Peerstore().AddAddrs(pi.ID, pi.Addrs, peerstore.AddressTTL)
+
+	addrs := make([][]byte, len(pi.Addrs))
+	for i, a := range pi.Addrs {
+		addrs[i] = a.Bytes()
+	}
+	now := time.Now().UnixNano()
+	d.events.publish(&pb.Event{
+		Type:  pb.Event_PEER_DISCOVERED.Enum(),
+		Time:  &now,
+		Peer:  []byte(pi.ID),
+		Addrs: addrs,
+	})
+
+	if !n.autoConnect || d.host.Network().Connectedness(pi.ID) == network.Connected {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(d.ctx, mdnsConnectTimeout)
+		defer cancel()
+		if err := d.host.Connect(ctx, pi); err != nil {
+			log.Debugw("error connecting to discovered peer", "peer", pi.ID, "error", err)
+		}
+	}()
+}
@@ -0,0 +1,294 @@
+package p2pd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-libp2p/core/routing"
+	"github.com/libp2p/go-libp2p/p2p/net/swarm"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	msmux "github.com/multiformats/go-multistream"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "p2pd"
+
+// stream directions, as seen from the client
+const (
+	directionIn  = "in"  // from the remote peer to the client
+	directionOut = "out" // from the client to the remote peer
+)
+
+// reasons for stream open and accept failures
+const (
+	reasonMalformed           = "malformed"
+	reasonDial                = "dial"
+	reasonTimeout             = "timeout"
+	reasonProtocolUnsupported = "protocol_unsupported"
+	reasonNoHandler           = "no_handler"
+	reasonHandlerDial         = "handler_dial"
+	reasonHandlerWrite        = "handler_write"
+	reasonResourceLimit       = "resource_limit"
+	reasonError               = "error"
+)
+
+var (
+	streamBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "stream",
+			Name:      "bytes_total",
+			Help:      "Bytes piped between streams and clients",
+		},
+		[]string{"protocol", "direction"},
+	)
+	streamSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "stream",
+			Name:      "size_bytes",
+			Help:      "Bytes piped over a single stream, observed when it ends",
+			Buckets:   prometheus.ExponentialBuckets(64, 4, 12),
+		},
+		[]string{"protocol", "direction"},
+	)
+	streamsOpened = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "stream",
+			Name:      "opened_total",
+			Help:      "Outbound streams opened on behalf of clients",
+		},
+		[]string{"protocol"},
+	)
+	streamOpenFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "stream",
+			Name:      "open_failures_total",
+			Help:      "Outbound streams that could not be opened",
+		},
+		[]string{"reason"},
+	)
+	streamsAccepted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "stream",
+			Name:      "accepted_total",
+			Help:      "Inbound streams delivered to client handlers",
+		},
+		[]string{"protocol"},
+	)
+	streamAcceptFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "stream",
+			Name:      "accept_failures_total",
+			Help:      "Inbound streams that could not be delivered to a client handler",
+		},
+		[]string{"protocol", "reason"},
+	)
+	streamsPiped = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "stream",
+			Name:      "piped",
+			Help:      "Streams currently piped to clients",
+		},
+		[]string{"protocol"},
+	)
+
+	requests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "control",
+			Name:      "requests_total",
+			Help:      "Control requests handled, by type and sub-type",
+		},
+		[]string{"type", "subtype"},
+	)
+	requestErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "control",
+			Name:      "request_errors_total",
+			Help:      "Control requests answered with an error, by type and sub-type",
+		},
+		[]string{"type", "subtype"},
+	)
+	requestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "control",
+			Name:      "request_duration_seconds",
+			Help:      "Time taken to answer control requests, by type and sub-type",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 4, 11),
+		},
+		[]string{"type", "subtype"},
+	)
+	requestsLimited = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "control",
+			Name:      "requests_limited_total",
+			Help:      "Control requests refused by the rate limits, by type, sub-type and limit hit",
+		},
+		[]string{"type", "subtype", "limit"},
+	)
+	controlConns = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "control",
+			Name:      "connections",
+			Help:      "Open control connections",
+		},
+	)
+	subscriptions = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "pubsub",
+			Name:      "subscriptions",
+			Help:      "Active pubsub subscriptions piped to clients",
+		},
+	)
+	dhtStreams = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "dht",
+			Name:      "streams",
+			Help:      "DHT queries currently streaming responses to clients",
+		},
+	)
+	holePunchAttempts = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "holepunch",
+			Name:      "attempts_total",
+			Help:      "Hole punching attempts",
+		},
+	)
+	holePunchOutcomes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "holepunch",
+			Name:      "outcomes_total",
+			Help:      "Outcomes of direct connection upgrades, by method",
+		},
+		[]string{"method", "outcome"},
+	)
+	auditFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "audit",
+			Name:      "failures_total",
+			Help:      "Failures to write, rotate or reopen the audit log, by operation",
+		},
+		[]string{"op"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		streamBytes,
+		streamSize,
+		streamsOpened,
+		streamOpenFailures,
+		streamsAccepted,
+		streamAcceptFailures,
+		streamsPiped,
+		requests,
+		requestErrors,
+		requestDuration,
+		requestsLimited,
+		controlConns,
+		subscriptions,
+		dhtStreams,
+		holePunchAttempts,
+		holePunchOutcomes,
+		auditFailures,
+	)
+}
+
+// requestSubtype returns the sub-type of requests that have one.
+func requestSubtype(req *pb.Request) string {
+	switch req.GetType() {
+	case pb.Request_DHT:
+		if req.Dht != nil {
+			return req.Dht.GetType().String()
+		}
+	case pb.Request_PUBSUB:
+		if req.Pubsub != nil {
+			return req.Pubsub.GetType().String()
+		}
+	case pb.Request_CONNMANAGER:
+		if req.ConnManager != nil {
+			return req.ConnManager.GetType().String()
+		}
+	case pb.Request_CONNGATER:
+		if req.ConnGater != nil {
+			return req.ConnGater.GetType().String()
+		}
+	}
+	return ""
+}
+
+// observeRequest records a control request answered with res, which started at start.
+func observeRequest(req *pb.Request, res *pb.Response, start time.Time) {
+	labels := prometheus.Labels{"type": req.GetType().String(), "subtype": requestSubtype(req)}
+	requests.With(labels).Inc()
+	requestDuration.With(labels).Observe(time.Since(start).Seconds())
+	if res.GetType() == pb.Response_ERROR {
+		requestErrors.With(labels).Inc()
+	}
+}
+
+// observeLimited records a control request refused because it hit limit.
+func observeLimited(req *pb.Request, limit string) {
+	requestsLimited.WithLabelValues(req.GetType().String(), requestSubtype(req), limit).Inc()
+}
+
+// openFailureReason classifies an error opening a stream.
+func openFailureReason(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return reasonTimeout
+	case errors.Is(err, msmux.ErrNotSupported[protocol.ID]{}):
+		return reasonProtocolUnsupported
+	case dialFailed(err):
+		return reasonDial
+	default:
+		return reasonError
+	}
+}
+
+// dialFailed reports whether err, returned by NewStream, is a failure to
+// connect to the peer rather than to negotiate a protocol with it.
+func dialFailed(err error) bool {
+	var dialErr *swarm.DialError
+	return errors.As(err, &dialErr) ||
+		errors.Is(err, swarm.ErrDialBackoff) ||
+		errors.Is(err, swarm.ErrDialToSelf) ||
+		errors.Is(err, swarm.ErrNoAddresses) ||
+		errors.Is(err, swarm.ErrNoGoodAddresses) ||
+		errors.Is(err, swarm.ErrGaterDisallowedConnection) ||
+		errors.Is(err, routing.ErrNotFound)
+}
+
+// countingWriter counts the bytes written through it as they go.
+type countingWriter struct {
+	io.WriteCloser
+	counter prometheus.Counter
+	n       int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.WriteCloser.Write(p)
+	w.n += int64(n)
+	w.counter.Add(float64(n))
+	return n, err
+}
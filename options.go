@@ -0,0 +1,152 @@
+package p2pd
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/pnet"
+	"github.com/libp2p/go-libp2p/p2p/host/autorelay"
+	"github.com/libp2p/go-libp2p/p2p/protocol/holepunch"
+)
+
+// Option configures a daemon created with NewDaemonWithOptions.
+type Option func(*options) error
+
+type options struct {
+	hostOpts []libp2p.Option
+	dhtStore *DHTStore
+	gater    connmgr.ConnectionGater
+
+	psk           pnet.PSK
+	holePunching  bool
+	holePunchOpts []holepunch.Option
+	reachability  network.Reachability
+	autoRelayDHT  bool
+	autoRelayOpts []autorelay.Option
+}
+
+// WithHostOptions passes opts to libp2p when the daemon creates its host.
+// The settings the daemon acts on too, such as private networks, hole
+// punching, forced reachability and connection gating, are set with the
+// options of this package instead, as the daemon doesn't see those of libp2p
+// options.
+func WithHostOptions(opts ...libp2p.Option) Option {
+	return func(o *options) error {
+		o.hostOpts = append(o.hostOpts, opts...)
+		return nil
+	}
+}
+
+// WithDHTStore has the daemon's DHT, if enabled, keep its records in store
+// rather than in memory. The daemon closes store when it is closed, or fails
+// to start.
+func WithDHTStore(store *DHTStore) Option {
+	return func(o *options) error {
+		o.dhtStore = store
+		return nil
+	}
+}
+
+// WithConnectionGater has the daemon's connection gater consult g too, so
+// that connections either of them refuses are refused. Connections g comes
+// to refuse later aren't closed by the daemon, unlike those its own rules
+// block.
+func WithConnectionGater(g connmgr.ConnectionGater) Option {
+	return func(o *options) error {
+		if g == nil {
+			return fmt.Errorf("nil connection gater")
+		}
+		o.gater = g
+		return nil
+	}
+}
+
+// WithPrivateNetwork has the daemon only connect with peers sharing psk, and
+// report it in its diagnostics.
+func WithPrivateNetwork(psk pnet.PSK) Option {
+	return func(o *options) error {
+		if len(psk) == 0 {
+			return fmt.Errorf("empty pre-shared key")
+		}
+		o.psk = psk
+		return nil
+	}
+}
+
+// WithHolePunching enables hole punching with opts, reporting hole punches
+// as daemon events and metrics, and serving DIRECT_CONNECT requests.
+func WithHolePunching(opts ...holepunch.Option) Option {
+	return func(o *options) error {
+		o.holePunching = true
+		o.holePunchOpts = append(o.holePunchOpts, opts...)
+		return nil
+	}
+}
+
+// WithForcedReachability has the daemon assume it is reachable as r, public
+// or private, instead of running AutoNAT to find out.
+func WithForcedReachability(r network.Reachability) Option {
+	return func(o *options) error {
+		if r != network.ReachabilityPublic && r != network.ReachabilityPrivate {
+			return fmt.Errorf("can only force public or private reachability, not %s", r)
+		}
+		o.reachability = r
+		return nil
+	}
+}
+
+// WithAutoRelayDHT enables AutoRelay with opts, with relays the daemon finds
+// through its DHT, which must be enabled. AutoRelay with static relays is
+// enabled with the libp2p option instead.
+func WithAutoRelayDHT(opts ...autorelay.Option) Option {
+	return func(o *options) error {
+		o.autoRelayDHT = true
+		o.autoRelayOpts = append(o.autoRelayOpts, opts...)
+		return nil
+	}
+}
+
+// apply sets the options opts in o. All of them are applied even if one
+// fails, so that o holds whatever the daemon must release, such as a DHT
+// store; the first error is returned.
+func (o *options) apply(opts []Option) error {
+	var err error
+	for _, opt := range opts {
+		if oerr := opt(o); oerr != nil && err == nil {
+			err = oerr
+		}
+	}
+	return err
+}
+
+// hostGater reports whether the libp2p options set a connection gater, which
+// would conflict with the daemon's own.
+func (o *options) hostGater() bool {
+	var cfg libp2p.Config
+	// other errors are left to libp2p to report when creating the host
+	cfg.Apply(o.hostOpts...)
+	return cfg.ConnectionGater != nil
+}
+
+// hostOptions returns the libp2p options of the host of d set by o.
+func (o *options) hostOptions(d *Daemon) []libp2p.Option {
+	opts := o.hostOpts
+	if o.psk != nil {
+		opts = append(opts, libp2p.PrivateNetwork(o.psk))
+	}
+	if o.holePunching {
+		opts = append(opts, libp2p.EnableHolePunching(d.holePunchOptions(o.holePunchOpts)...))
+	}
+	switch o.reachability {
+	case network.ReachabilityPublic:
+		opts = append(opts, libp2p.ForceReachabilityPublic())
+	case network.ReachabilityPrivate:
+		opts = append(opts, libp2p.ForceReachabilityPrivate())
+	}
+	if o.autoRelayDHT {
+		opts = append(opts, libp2p.EnableAutoRelayWithPeerSource(d.relayCandidates, o.autoRelayOpts...))
+	}
+	return opts
+}
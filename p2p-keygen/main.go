@@ -1,9 +1,13 @@
 package main
 
 import (
+	"bytes"
+	"encoding/base64"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"os"
 
 	p2pd "github.com/libp2p/go-libp2p-daemon"
 
@@ -11,38 +15,189 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
+const (
+	formatProtobuf = "protobuf"
+	formatPEM      = "pem"
+)
+
 func main() {
+	flag.Usage = func() {
+		out := flag.CommandLine.Output()
+		fmt.Fprintf(out, "Usage: %s [flags]\n", os.Args[0])
+		fmt.Fprintf(out, "       %s inspect [flags]\n", os.Args[0])
+		fmt.Fprintf(out, "       %s convert [flags]\n\n", os.Args[0])
+		fmt.Fprintf(out, "Generates a key, or inspects or converts an existing one.\n\n")
+		flag.PrintDefaults()
+	}
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "inspect":
+			inspect(os.Args[2:])
+			return
+		case "convert":
+			convert(os.Args[2:])
+			return
+		}
+	}
+	generate()
+}
+
+func generate() {
 	file := flag.String("f", "identity", "output key file")
-	ktype := flag.String("t", "rsa", "key type; rsa or ed25519")
+	ktype := flag.String("t", "rsa", "key type; rsa, ed25519, secp256k1 or ecdsa")
 	bits := flag.Int("b", 2048, "key size in bits (for rsa)")
+	format := flag.String("format", formatProtobuf, "output key format; protobuf or pem")
+	encrypt := flag.Bool("encrypt", false, "encrypt the key with a passphrase")
+	passphraseFile := flag.String("passphraseFile", "", "a file holding the passphrase to encrypt the key with; read from $"+p2pd.PassphraseEnv+" or prompted for otherwise")
 	flag.Parse()
 
-	var typ int
+	typ, err := p2pd.KeyType(*ktype)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	switch *ktype {
-	case "rsa":
-		typ = crypto.RSA
+	if err := checkFormat(*format, *encrypt); err != nil {
+		log.Fatal(err)
+	}
 
-	case "ed25519":
-		typ = crypto.Ed25519
+	priv, pub, err := crypto.GenerateKeyPair(typ, *bits)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	default:
-		log.Fatalf("Unknown key type %s; must be rsa or ed25519", *ktype)
+	if err := printPeerID(pub); err != nil {
+		log.Fatal(err)
 	}
 
-	priv, pub, err := crypto.GenerateKeyPair(typ, *bits)
+	err = writeKey(priv, *file, *format, *encrypt, *passphraseFile)
 	if err != nil {
 		log.Fatal(err)
 	}
+}
 
-	id, err := peer.IDFromPublicKey(pub)
+func inspect(args []string) {
+	fs := flag.NewFlagSet("inspect", flag.ExitOnError)
+	file := fs.String("f", "identity", "key file")
+	passphraseFile := fs.String("passphraseFile", "", "a file holding the passphrase of an encrypted key; read from $"+p2pd.PassphraseEnv+" or prompted for otherwise")
+	fs.Parse(args)
+
+	priv, err := readKey(*file, *passphraseFile)
 	if err != nil {
 		log.Fatal(err)
 	}
-	fmt.Printf("Peer ID: %s\n", id.String())
 
-	err = p2pd.WriteIdentity(priv, *file)
+	pub := priv.GetPublic()
+	if err := printPeerID(pub); err != nil {
+		log.Fatal(err)
+	}
+	pubBytes, err := crypto.MarshalPublicKey(pub)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("Key type: %s\n", pub.Type())
+	fmt.Printf("Public key: %s\n", base64.StdEncoding.EncodeToString(pubBytes))
+}
+
+func convert(args []string) {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	in := fs.String("i", "", "input key file, in protobuf, PKCS#8 PEM or encrypted format")
+	inPassphraseFile := fs.String("inPassphraseFile", "", "a file holding the passphrase of an encrypted input key; read from $"+p2pd.PassphraseEnv+" or prompted for otherwise")
+	file := fs.String("f", "", "output key file")
+	format := fs.String("format", formatProtobuf, "output key format; protobuf or pem")
+	encrypt := fs.Bool("encrypt", false, "encrypt the output key with a passphrase")
+	passphraseFile := fs.String("passphraseFile", "", "a file holding the passphrase to encrypt the output key with; read from $"+p2pd.PassphraseEnv+" or prompted for otherwise")
+	fs.Parse(args)
+
+	if *in == "" || *file == "" {
+		log.Fatal("convert needs an input key file (-i) and an output key file (-f)")
+	}
+	if err := checkFormat(*format, *encrypt); err != nil {
+		log.Fatal(err)
+	}
+
+	priv, err := readKey(*in, *inPassphraseFile)
 	if err != nil {
 		log.Fatal(err)
 	}
+
+	if err := printPeerID(priv.GetPublic()); err != nil {
+		log.Fatal(err)
+	}
+
+	err = writeKey(priv, *file, *format, *encrypt, *passphraseFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func checkFormat(format string, encrypt bool) error {
+	switch format {
+	case formatProtobuf:
+		return nil
+	case formatPEM:
+		if encrypt {
+			return errors.New("PEM keys can't be encrypted")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown key format %s; must be protobuf or pem", format)
+	}
+}
+
+func readKey(file, passphraseFile string) (crypto.PrivKey, error) {
+	return p2pd.ReadIdentityPassphrase(file, func() ([]byte, error) {
+		return p2pd.ReadPassphrase(passphraseFile, fmt.Sprintf("Passphrase for %s: ", file))
+	})
+}
+
+func writeKey(priv crypto.PrivKey, file, format string, encrypt bool, passphraseFile string) error {
+	switch {
+	case format == formatPEM:
+		return p2pd.WritePEMIdentity(priv, file)
+
+	case encrypt:
+		passphrase, err := readNewPassphrase(passphraseFile)
+		if err != nil {
+			return err
+		}
+		return p2pd.WriteEncryptedIdentity(priv, file, passphrase)
+
+	default:
+		return p2pd.WriteIdentity(priv, file)
+	}
+}
+
+// printPeerID prints the peer ID of pub in base58 and as a CIDv1.
+func printPeerID(pub crypto.PubKey) error {
+	id, err := peer.IDFromPublicKey(pub)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Peer ID: %s\n", id.String())
+	fmt.Printf("Peer ID (CIDv1): %s\n", peer.ToCid(id).String())
+	return nil
+}
+
+// readNewPassphrase reads a passphrase, asking for it twice when prompting.
+func readNewPassphrase(file string) ([]byte, error) {
+	pass, err := p2pd.ReadPassphrase(file, "Passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	if len(pass) == 0 {
+		return nil, errors.New("empty passphrase")
+	}
+	if _, ok := os.LookupEnv(p2pd.PassphraseEnv); file != "" || ok {
+		return pass, nil
+	}
+
+	again, err := p2pd.ReadPassphrase("", "Repeat passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(pass, again) {
+		return nil, errors.New("passphrases don't match")
+	}
+	return pass, nil
 }
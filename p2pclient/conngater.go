@@ -0,0 +1,106 @@
+package p2pclient
+
+import (
+	"net"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// Blocked lists the peers and subnets the daemon doesn't connect with.
+type Blocked struct {
+	Peers   []peer.ID
+	Subnets []*net.IPNet
+	// AllowedPeers, if any, are the only peers the daemon connects with
+	AllowedPeers []peer.ID
+}
+
+func (c *Client) doConnGater(cgReq *pb.ConnGaterRequest) (*pb.ConnGaterResponse, error) {
+	res, err := c.doRequest(&pb.Request{
+		Type:      pb.Request_CONNGATER.Enum(),
+		ConnGater: cgReq,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetConnGater(), nil
+}
+
+// BlockPeer keeps the daemon from connecting with p, closing any connection
+// it has with p.
+func (c *Client) BlockPeer(p peer.ID) error {
+	_, err := c.doConnGater(&pb.ConnGaterRequest{
+		Type: pb.ConnGaterRequest_BLOCK_PEER.Enum(),
+		Peer: []byte(p),
+	})
+	return err
+}
+
+// UnblockPeer lifts a block set by BlockPeer.
+func (c *Client) UnblockPeer(p peer.ID) error {
+	_, err := c.doConnGater(&pb.ConnGaterRequest{
+		Type: pb.ConnGaterRequest_UNBLOCK_PEER.Enum(),
+		Peer: []byte(p),
+	})
+	return err
+}
+
+// BlockSubnet keeps the daemon from connecting with addresses in subnet,
+// closing any connection it has with them.
+func (c *Client) BlockSubnet(subnet *net.IPNet) error {
+	s := subnet.String()
+	_, err := c.doConnGater(&pb.ConnGaterRequest{
+		Type:   pb.ConnGaterRequest_BLOCK_SUBNET.Enum(),
+		Subnet: &s,
+	})
+	return err
+}
+
+// UnblockSubnet lifts a block set by BlockSubnet.
+func (c *Client) UnblockSubnet(subnet *net.IPNet) error {
+	s := subnet.String()
+	_, err := c.doConnGater(&pb.ConnGaterRequest{
+		Type:   pb.ConnGaterRequest_UNBLOCK_SUBNET.Enum(),
+		Subnet: &s,
+	})
+	return err
+}
+
+// ListBlocked returns the peers and subnets the daemon doesn't connect with.
+func (c *Client) ListBlocked() (*Blocked, error) {
+	res, err := c.doConnGater(&pb.ConnGaterRequest{
+		Type: pb.ConnGaterRequest_LIST_BLOCKED.Enum(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	blocked := &Blocked{}
+	if blocked.Peers, err = decodePeerIDs(res.GetBlockedPeers()); err != nil {
+		return nil, err
+	}
+	if blocked.AllowedPeers, err = decodePeerIDs(res.GetAllowedPeers()); err != nil {
+		return nil, err
+	}
+	for _, s := range res.GetBlockedSubnets() {
+		_, ipnet, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, err
+		}
+		blocked.Subnets = append(blocked.Subnets, ipnet)
+	}
+	return blocked, nil
+}
+
+func decodePeerIDs(bs [][]byte) ([]peer.ID, error) {
+	ids := make([]peer.ID, 0, len(bs))
+	for _, b := range bs {
+		id, err := peer.IDFromBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
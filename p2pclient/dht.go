@@ -65,7 +65,7 @@ func readDhtResponseStream(ctx context.Context, control net.Conn) (<-chan *pb.DH
 		return nil, err
 	}
 	if msg.GetType() != pb.Response_OK {
-		return nil, errors.New(msg.GetError().GetMsg())
+		return nil, daemonError(msg.GetError())
 	}
 	if msg.Dht.GetType() != pb.DHTResponse_BEGIN {
 		return nil, fmt.Errorf("expected a stream BEGIN message but got %s", msg.Dht.GetType().String())
@@ -120,7 +120,7 @@ func (c *Client) doDHT(dhtReq *pb.DHTRequest) (*pb.DHTResponse, error) {
 	}
 
 	if msg.GetType() == pb.Response_ERROR {
-		err := fmt.Errorf("error from daemon in %s response: %s", req.GetType().String(), msg.GetError())
+		err := fmt.Errorf("error from daemon in %s response: %w", req.GetType().String(), daemonError(msg.GetError()))
 		log.Errorf(err.Error())
 		return nil, err
 	}
@@ -217,6 +217,39 @@ func (c *Client) Provide(id cid.Cid) error {
 	return err
 }
 
+// DHTStorageStats are the numbers of records the daemon's DHT stores.
+type DHTStorageStats struct {
+	// Records is the number of value records
+	Records int64
+	// Providers is the number of provider records
+	Providers int64
+	// ProvidedKeys is the number of keys the provider records are for
+	ProvidedKeys int64
+}
+
+// DHTStorageStats queries the daemon for the numbers of records its DHT
+// stores locally.
+func (c *Client) DHTStorageStats() (*DHTStorageStats, error) {
+	req := &pb.DHTRequest{
+		Type: pb.DHTRequest_STORAGE_STATS.Enum(),
+	}
+
+	msg, err := c.doDHTNonNil(req)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := msg.GetStorage()
+	if stats == nil {
+		return nil, errors.New("storage stats were not populated in STORAGE_STATS response")
+	}
+	return &DHTStorageStats{
+		Records:      stats.GetRecords(),
+		Providers:    stats.GetProviders(),
+		ProvidedKeys: stats.GetProvidedKeys(),
+	}, nil
+}
+
 func convertResponseToPeerInfo(respc <-chan *pb.DHTResponse) <-chan PeerInfo {
 	out := make(chan PeerInfo, 10)
 
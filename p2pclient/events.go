@@ -0,0 +1,144 @@
+package p2pclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+
+	ggio "github.com/gogo/protobuf/io"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// Events streams the daemon's events of the given types, or of all types if
+// none are given, until ctx is cancelled. Events are dropped if the channel
+// isn't drained fast enough.
+func (c *Client) Events(ctx context.Context, types ...pb.Event_Type) (<-chan *pb.Event, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+
+	w := ggio.NewDelimitedWriter(control)
+	req := &pb.Request{
+		Type:   pb.Request_EVENTS.Enum(),
+		Events: &pb.EventsRequest{Types: types},
+	}
+	if err = w.WriteMsg(req); err != nil {
+		control.Close()
+		return nil, err
+	}
+
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	msg := &pb.Response{}
+	if err = r.ReadMsg(msg); err != nil {
+		control.Close()
+		return nil, err
+	}
+
+	if msg.GetType() == pb.Response_ERROR {
+		control.Close()
+		return nil, fmt.Errorf("error from daemon in %s response: %w", req.GetType().String(), daemonError(msg.GetError()))
+	}
+
+	go func() {
+		<-ctx.Done()
+		control.Close()
+	}()
+
+	out := make(chan *pb.Event)
+	go func() {
+		defer close(out)
+		defer control.Close()
+
+		for {
+			evt := &pb.Event{}
+			if err := r.ReadMsg(evt); err != nil {
+				log.Debugw("reading event", "error", err)
+				return
+			}
+			select {
+			case out <- evt:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// DiscoveredPeers streams the peers the daemon discovers on the local
+// network with mDNS, until ctx is cancelled. Peers are only discovered if
+// mDNS is enabled on the daemon.
+func (c *Client) DiscoveredPeers(ctx context.Context) (<-chan peer.AddrInfo, error) {
+	events, err := c.Events(ctx, pb.Event_PEER_DISCOVERED)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan peer.AddrInfo)
+	go func() {
+		defer close(out)
+		for evt := range events {
+			p, err := peer.IDFromBytes(evt.GetPeer())
+			if err != nil {
+				log.Debugw("reading discovered peer", "error", err)
+				continue
+			}
+			pi := peer.AddrInfo{ID: p}
+			for _, b := range evt.GetAddrs() {
+				if addr, err := multiaddr.NewMultiaddrBytes(b); err == nil {
+					pi.Addrs = append(pi.Addrs, addr)
+				}
+			}
+			select {
+			case out <- pi:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// DirectConnect asks the daemon to upgrade its relayed connection with p to
+// a direct one, dialing p directly or punching a hole through the NATs
+// between them. It waits up to timeout, or the daemon's default if timeout is
+// 0. Hole punching must be enabled on the daemon.
+func (c *Client) DirectConnect(p peer.ID, timeout time.Duration) error {
+	control, err := c.newControlConn()
+	if err != nil {
+		return err
+	}
+	defer control.Close()
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	w := ggio.NewDelimitedWriter(control)
+
+	req := &pb.Request{
+		Type:          pb.Request_DIRECT_CONNECT.Enum(),
+		DirectConnect: &pb.DirectConnectRequest{Peer: []byte(p)},
+	}
+	if timeout != 0 {
+		ms := timeout.Milliseconds()
+		req.DirectConnect.TimeoutMillis = &ms
+	}
+
+	if err := w.WriteMsg(req); err != nil {
+		return err
+	}
+
+	res := &pb.Response{}
+	if err := r.ReadMsg(res); err != nil {
+		return err
+	}
+
+	if err := res.GetError(); err != nil {
+		return fmt.Errorf("error from daemon in %s response: %w", req.GetType().String(), daemonError(err))
+	}
+
+	return nil
+}
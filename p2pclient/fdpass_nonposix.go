@@ -0,0 +1,14 @@
+//go:build windows || plan9 || nacl || js
+
+package p2pclient
+
+import (
+	"errors"
+	"net"
+
+	"github.com/libp2p/go-libp2p-daemon/shm"
+)
+
+func receiveConn(c shm.UnixConn) (net.Conn, error) {
+	return nil, errors.New("file descriptor passing is not supported on this platform")
+}
@@ -0,0 +1,45 @@
+//go:build !windows && !plan9 && !nacl && !js
+
+package p2pclient
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/libp2p/go-libp2p-daemon/shm"
+
+	"golang.org/x/sys/unix"
+)
+
+// receiveConn receives a stream socket passed by the daemon over c.
+func receiveConn(c shm.UnixConn) (net.Conn, error) {
+	buf := make([]byte, 1)
+	oob := make([]byte, unix.CmsgSpace(4))
+	_, oobn, _, _, err := c.ReadMsgUnix(buf, oob)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs, err := unix.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		return nil, fmt.Errorf("parsing control message: %w", err)
+	}
+	if len(msgs) != 1 {
+		return nil, fmt.Errorf("expected a single control message, got %d", len(msgs))
+	}
+	fds, err := unix.ParseUnixRights(&msgs[0])
+	if err != nil {
+		return nil, fmt.Errorf("parsing control message: %w", err)
+	}
+	if len(fds) != 1 {
+		for _, fd := range fds {
+			unix.Close(fd)
+		}
+		return nil, fmt.Errorf("expected a single file descriptor, got %d", len(fds))
+	}
+
+	f := os.NewFile(uintptr(fds[0]), "stream")
+	defer f.Close()
+	return net.FileConn(f)
+}
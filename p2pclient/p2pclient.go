@@ -2,7 +2,10 @@ package p2pclient
 
 import (
 	"errors"
+	"fmt"
+	"net"
 	"sync"
+	"sync/atomic"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 
@@ -18,6 +21,19 @@ var log = logging.Logger("p2pclient")
 // MessageSizeMax is cribbed from github.com/libp2p/go-libp2p-net
 const MessageSizeMax = 1 << 22 // 4 MB
 
+// ErrRateLimited is returned, wrapped, when the daemon refuses a request
+// because the client exceeded its rate limits; the request may be retried
+// after backing off.
+var ErrRateLimited = errors.New("rate limited by daemon")
+
+// daemonError converts an error response from the daemon into an error.
+func daemonError(e *pb.ErrorResponse) error {
+	if e.GetCode() == pb.ErrorResponse_RATE_LIMITED {
+		return fmt.Errorf("%w: %s", ErrRateLimited, e.GetMsg())
+	}
+	return errors.New(e.GetMsg())
+}
+
 // Client is the struct that manages a connection to a libp2p daemon.
 type Client struct {
 	controlMaddr multiaddr.Multiaddr
@@ -26,28 +42,75 @@ type Client struct {
 
 	mhandlers sync.Mutex
 	handlers  map[string]StreamHandlerFunc
+	// handlerConns are the control connections streams are passed over when
+	// the client has no listener
+	handlerConns []net.Conn
+
+	// shm is set when stream data should move through shared memory
+	shm atomic.Bool
 }
 
 // NewClient creates a new libp2p daemon client, connecting to a daemon
 // listening on a multi-addr at controlMaddr, and establishing an inbound
-// listening multi-address at listenMaddr
+// listening multi-address at listenMaddr.
+//
+// If listenMaddr is nil, the client doesn't listen at all; inbound streams
+// are then passed to it as file descriptors over the unix socket control
+// connection its handlers were registered on.
 func NewClient(controlMaddr, listenMaddr multiaddr.Multiaddr) (*Client, error) {
 	client := &Client{
 		controlMaddr: controlMaddr,
 		handlers:     make(map[string]StreamHandlerFunc),
 	}
 
-	if err := client.listen(listenMaddr); err != nil {
-		return nil, err
+	if listenMaddr != nil {
+		if err := client.listen(listenMaddr); err != nil {
+			return nil, err
+		}
 	}
 
 	return client, nil
 }
 
+// EnableSharedMemory makes the client ask the daemon to move the data of new
+// streams, and of streams delivered to handlers registered afterwards, through
+// shared memory instead of the socket. The daemon decides for each stream
+// whether to go along, falling back to the socket where it can't.
+func (c *Client) EnableSharedMemory(enable bool) {
+	c.shm.Store(enable)
+}
+
 func (c *Client) newControlConn() (manet.Conn, error) {
 	return manet.Dial(c.controlMaddr)
 }
 
+// doRequest sends req to the daemon on a new control connection and returns
+// its response, or an error if the daemon answered with one.
+func (c *Client) doRequest(req *pb.Request) (*pb.Response, error) {
+	control, err := c.newControlConn()
+	if err != nil {
+		return nil, err
+	}
+	defer control.Close()
+
+	w := ggio.NewDelimitedWriter(control)
+	if err = w.WriteMsg(req); err != nil {
+		return nil, err
+	}
+
+	r := ggio.NewDelimitedReader(control, MessageSizeMax)
+	msg := &pb.Response{}
+	if err = r.ReadMsg(msg); err != nil {
+		return nil, err
+	}
+
+	if msg.GetType() == pb.Response_ERROR {
+		return nil, fmt.Errorf("error from daemon in %s response: %w", req.GetType().String(), daemonError(msg.GetError()))
+	}
+
+	return msg, nil
+}
+
 // Identify queries the daemon for its peer ID and listen addresses.
 func (c *Client) Identify() (peer.ID, []multiaddr.Multiaddr, error) {
 	control, err := c.newControlConn()
@@ -69,7 +132,7 @@ func (c *Client) Identify() (peer.ID, []multiaddr.Multiaddr, error) {
 	}
 
 	if reserr := res.GetError(); reserr != nil {
-		return peer.ID(""), nil, errors.New(reserr.GetMsg())
+		return peer.ID(""), nil, daemonError(reserr)
 	}
 
 	idres := res.GetIdentify()
@@ -124,8 +187,27 @@ func (c *Client) Connect(p peer.ID, addrs []multiaddr.Multiaddr) error {
 	}
 
 	if err := res.GetError(); err != nil {
-		return errors.New(err.GetMsg())
+		return daemonError(err)
 	}
 
 	return nil
 }
+
+// Diagnostics queries the daemon for a snapshot of its state.
+func (c *Client) Diagnostics() (*pb.DiagnosticsResponse, error) {
+	res, err := c.doRequest(&pb.Request{Type: pb.Request_DIAGNOSTICS.Enum()})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetDiagnostics(), nil
+}
+
+// ResourceUsage queries the daemon for the usage and limits of its resource
+// manager scopes.
+func (c *Client) ResourceUsage() (*pb.ResourceUsageResponse, error) {
+	res, err := c.doRequest(&pb.Request{Type: pb.Request_RESOURCE_USAGE.Enum()})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetResourceUsage(), nil
+}
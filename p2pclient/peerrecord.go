@@ -0,0 +1,62 @@
+package p2pclient
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// PeerRecord returns the marshaled envelope of the daemon's signed peer
+// record, which certifies its addresses and can be handed to other peers.
+func (c *Client) PeerRecord() ([]byte, error) {
+	res, err := c.doRequest(&pb.Request{Type: pb.Request_PEER_RECORD.Enum()})
+	if err != nil {
+		return nil, err
+	}
+	if res.PeerRecord == nil {
+		return nil, fmt.Errorf("peer record response was not populated")
+	}
+	return res.PeerRecord.Envelope, nil
+}
+
+// AddPeerRecord verifies the marshaled envelope of a peer's signed peer
+// record and adds its addresses to the daemon's peerstore, to be kept for
+// ttl, or the daemon's default if ttl is 0. It returns the peer and addresses
+// of the record, and whether the record was accepted; it isn't if the daemon
+// has a newer record of the peer.
+func (c *Client) AddPeerRecord(envelope []byte, ttl time.Duration) (*peer.AddrInfo, bool, error) {
+	req := &pb.Request{
+		Type:          pb.Request_ADD_PEER_RECORD.Enum(),
+		AddPeerRecord: &pb.AddPeerRecordRequest{Envelope: envelope},
+	}
+	if ttl != 0 {
+		secs := int64(ttl / time.Second)
+		req.AddPeerRecord.Ttl = &secs
+	}
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, false, err
+	}
+	if res.AddPeerRecord == nil {
+		return nil, false, fmt.Errorf("add peer record response was not populated")
+	}
+
+	p, err := peer.IDFromBytes(res.AddPeerRecord.Peer)
+	if err != nil {
+		return nil, false, err
+	}
+	addrs := make([]multiaddr.Multiaddr, 0, len(res.AddPeerRecord.Addrs))
+	for _, b := range res.AddPeerRecord.Addrs {
+		addr, err := multiaddr.NewMultiaddrBytes(b)
+		if err != nil {
+			return nil, false, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return &peer.AddrInfo{ID: p, Addrs: addrs}, res.AddPeerRecord.GetAccepted(), nil
+}
@@ -37,7 +37,7 @@ func (c *Client) doPubsub(psReq *pb.PSRequest) (*pb.PSResponse, error) {
 	}
 
 	if msg.GetType() == pb.Response_ERROR {
-		err := fmt.Errorf("error from daemon in %s response: %s", req.GetType().String(), msg.GetError())
+		err := fmt.Errorf("error from daemon in %s response: %w", req.GetType().String(), daemonError(msg.GetError()))
 		log.Errorf(err.Error())
 		return nil, err
 	}
@@ -67,7 +67,7 @@ func (c *Client) streamPubsubRequest(ctx context.Context, psReq *pb.PSRequest) (
 	}
 
 	if msg.GetType() == pb.Response_ERROR {
-		err := fmt.Errorf("error from daemon in %s response: %s", req.GetType().String(), msg.GetError())
+		err := fmt.Errorf("error from daemon in %s response: %w", req.GetType().String(), daemonError(msg.GetError()))
 		log.Errorf(err.Error())
 		return nil, err
 	}
@@ -0,0 +1,37 @@
+package p2pclient
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/network"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// GetReachability returns whether the daemon is reachable by peers on the
+// public internet, as determined by AutoNAT, and whether that is forced by
+// its config instead.
+func (c *Client) GetReachability() (network.Reachability, bool, error) {
+	req := &pb.Request{Type: pb.Request_GET_REACHABILITY.Enum()}
+	msg, err := c.doRequest(req)
+	if err != nil {
+		return network.ReachabilityUnknown, false, err
+	}
+	if msg.Reachability == nil {
+		return network.ReachabilityUnknown, false, fmt.Errorf("reachability response was not populated")
+	}
+
+	return reachabilityFromProto(msg.Reachability.GetReachability()), msg.Reachability.GetForced(), nil
+}
+
+// reachabilityFromProto converts the reachability of a response.
+func reachabilityFromProto(r pb.Reachability) network.Reachability {
+	switch r {
+	case pb.Reachability_PUBLIC:
+		return network.ReachabilityPublic
+	case pb.Reachability_PRIVATE:
+		return network.ReachabilityPrivate
+	default:
+		return network.ReachabilityUnknown
+	}
+}
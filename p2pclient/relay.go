@@ -0,0 +1,112 @@
+package p2pclient
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// RelayReservation is a slot the daemon reserved on a relay, which peers can
+// reach it through.
+type RelayReservation struct {
+	Relay peer.ID
+	// Expiration is when the reservation expires, unless the daemon
+	// refreshes it first
+	Expiration time.Time
+	// Addrs are the /p2p-circuit addresses the daemon can be reached at
+	// through the relay
+	Addrs []multiaddr.Multiaddr
+	// LimitDuration is how long the relay keeps relayed connections open;
+	// unlimited if 0
+	LimitDuration time.Duration
+	// LimitData is how many bytes the relay relays in each direction of a
+	// connection; unlimited if 0
+	LimitData uint64
+	// Error is why the daemon last failed to refresh the reservation, if it
+	// did
+	Error string
+}
+
+func (c *Client) doRelay(req *pb.Request) ([]*RelayReservation, error) {
+	msg, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	rsvps := make([]*RelayReservation, 0, len(msg.RelayReservations))
+	for _, info := range msg.RelayReservations {
+		rsvp, err := convertRelayReservation(info)
+		if err != nil {
+			return nil, err
+		}
+		rsvps = append(rsvps, rsvp)
+	}
+	return rsvps, nil
+}
+
+func convertRelayReservation(info *pb.RelayReservation) (*RelayReservation, error) {
+	p, err := peer.IDFromBytes(info.Relay)
+	if err != nil {
+		return nil, err
+	}
+	addrs := make([]multiaddr.Multiaddr, 0, len(info.Addrs))
+	for _, b := range info.Addrs {
+		addr, err := multiaddr.NewMultiaddrBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return &RelayReservation{
+		Relay:         p,
+		Expiration:    time.Unix(info.GetExpiration(), 0),
+		Addrs:         addrs,
+		LimitDuration: time.Duration(info.GetLimitDuration()) * time.Second,
+		LimitData:     info.GetLimitData(),
+		Error:         info.GetError(),
+	}, nil
+}
+
+// ReserveRelay reserves a slot on the relay p, at addrs or the addresses
+// the daemon knows of p. The daemon refreshes the reservation until it is
+// cancelled with CancelRelayReservation.
+func (c *Client) ReserveRelay(p peer.ID, addrs []multiaddr.Multiaddr) (*RelayReservation, error) {
+	addrbytes := make([][]byte, len(addrs))
+	for i, addr := range addrs {
+		addrbytes[i] = addr.Bytes()
+	}
+
+	rsvps, err := c.doRelay(&pb.Request{
+		Type: pb.Request_RELAY_RESERVE.Enum(),
+		RelayReserve: &pb.RelayReserveRequest{
+			Peer:  []byte(p),
+			Addrs: addrbytes,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rsvps) != 1 {
+		return nil, fmt.Errorf("relay reserve response was not populated")
+	}
+	return rsvps[0], nil
+}
+
+// RelayReservations lists the reservations the daemon keeps on relays.
+func (c *Client) RelayReservations() ([]*RelayReservation, error) {
+	return c.doRelay(&pb.Request{Type: pb.Request_RELAY_LIST_RESERVATIONS.Enum()})
+}
+
+// CancelRelayReservation stops the daemon from refreshing its reservation on
+// the relay p, which lapses at its expiration.
+func (c *Client) CancelRelayReservation(p peer.ID) error {
+	_, err := c.doRelay(&pb.Request{
+		Type:        pb.Request_RELAY_CANCEL.Enum(),
+		RelayCancel: &pb.RelayCancelRequest{Peer: []byte(p)},
+	})
+	return err
+}
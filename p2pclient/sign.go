@@ -0,0 +1,136 @@
+package p2pclient
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// Envelope is the content of an envelope signed by a peer.
+type Envelope struct {
+	Peer        peer.ID
+	PublicKey   crypto.PubKey
+	PayloadType []byte
+	Payload     []byte
+}
+
+// Sign signs data with the daemon's private key, returning the signature and
+// the daemon's public key. A domain, if not empty, separates signatures of
+// the same data for different purposes, and must be given to verify them.
+func (c *Client) Sign(data []byte, domain string) ([]byte, crypto.PubKey, error) {
+	req := &pb.Request{
+		Type: pb.Request_SIGN.Enum(),
+		Sign: &pb.SignRequest{Data: data},
+	}
+	if domain != "" {
+		req.Sign.Domain = &domain
+	}
+
+	res, err := c.doRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.Sign == nil {
+		return nil, nil, fmt.Errorf("sign response was not populated")
+	}
+
+	pub, err := crypto.UnmarshalPublicKey(res.Sign.PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.Sign.Signature, pub, nil
+}
+
+// Verify checks that sig is a signature of data in domain by peer p, whose
+// public key must be embedded in its ID or known to the daemon.
+func (c *Client) Verify(data, sig []byte, domain string, p peer.ID) (bool, error) {
+	return c.verify(&pb.VerifyRequest{
+		Data:      data,
+		Signature: sig,
+		Peer:      []byte(p),
+	}, domain)
+}
+
+// VerifyWithKey checks that sig is a signature of data in domain by the
+// holder of the private key of pub.
+func (c *Client) VerifyWithKey(data, sig []byte, domain string, pub crypto.PubKey) (bool, error) {
+	pubBytes, err := crypto.MarshalPublicKey(pub)
+	if err != nil {
+		return false, err
+	}
+	return c.verify(&pb.VerifyRequest{
+		Data:      data,
+		Signature: sig,
+		PublicKey: pubBytes,
+	}, domain)
+}
+
+func (c *Client) verify(verifyReq *pb.VerifyRequest, domain string) (bool, error) {
+	if domain != "" {
+		verifyReq.Domain = &domain
+	}
+	res, err := c.doRequest(&pb.Request{
+		Type:   pb.Request_VERIFY.Enum(),
+		Verify: verifyReq,
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.GetVerify().GetValid(), nil
+}
+
+// SealEnvelope signs payload, of the type payloadType, in domain with the
+// daemon's private key, returning the marshaled envelope.
+func (c *Client) SealEnvelope(domain string, payloadType, payload []byte) ([]byte, error) {
+	res, err := c.doRequest(&pb.Request{
+		Type: pb.Request_SEAL_ENVELOPE.Enum(),
+		SealEnvelope: &pb.SealEnvelopeRequest{
+			Domain:      &domain,
+			PayloadType: payloadType,
+			Payload:     payload,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.SealEnvelope == nil {
+		return nil, fmt.Errorf("seal envelope response was not populated")
+	}
+	return res.SealEnvelope.Envelope, nil
+}
+
+// OpenEnvelope checks the signature of a marshaled envelope sealed in domain,
+// and returns its content.
+func (c *Client) OpenEnvelope(envelope []byte, domain string) (*Envelope, error) {
+	res, err := c.doRequest(&pb.Request{
+		Type: pb.Request_OPEN_ENVELOPE.Enum(),
+		OpenEnvelope: &pb.OpenEnvelopeRequest{
+			Envelope: envelope,
+			Domain:   &domain,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.OpenEnvelope == nil {
+		return nil, fmt.Errorf("open envelope response was not populated")
+	}
+
+	p, err := peer.IDFromBytes(res.OpenEnvelope.Peer)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := crypto.UnmarshalPublicKey(res.OpenEnvelope.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Envelope{
+		Peer:        p,
+		PublicKey:   pub,
+		PayloadType: res.OpenEnvelope.PayloadType,
+		Payload:     res.OpenEnvelope.Payload,
+	}, nil
+}
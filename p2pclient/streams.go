@@ -3,6 +3,7 @@ package p2pclient
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -12,6 +13,7 @@ import (
 	ggio "github.com/gogo/protobuf/io"
 	proto "github.com/gogo/protobuf/proto"
 	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	"github.com/libp2p/go-libp2p-daemon/shm"
 	ma "github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr/net"
 )
@@ -69,6 +71,23 @@ func readMsgBytesSafe(r *byteReaderConn) (*bytes.Buffer, error) {
 	return out, nil
 }
 
+// acceptShm maps the shared memory segment the daemon passes over c after
+// announcing it in a StreamInfo.
+func acceptShm(c net.Conn, size uint64) (io.ReadWriteCloser, error) {
+	uc, ok := c.(shm.UnixConn)
+	if !ok {
+		return nil, errors.New("shared memory segment offered over a non-unix socket")
+	}
+	return shm.Accept(uc, int(size))
+}
+
+func (c *Client) shmRequested() *bool {
+	if !c.shm.Load() || !shm.Supported {
+		return nil
+	}
+	return proto.Bool(true)
+}
+
 func readMsgSafe(c *byteReaderConn, msg proto.Message) error {
 	header, err := readMsgBytesSafe(c)
 	if err != nil {
@@ -98,6 +117,7 @@ func (c *Client) NewStream(peer peer.ID, protos []string) (*StreamInfo, io.ReadW
 		StreamOpen: &pb.StreamOpenRequest{
 			Peer:  []byte(peer),
 			Proto: protos,
+			Shm:   c.shmRequested(),
 		},
 	}
 
@@ -113,18 +133,35 @@ func (c *Client) NewStream(peer peer.ID, protos []string) (*StreamInfo, io.ReadW
 		return nil, nil, err
 	}
 	if err := resp.GetError(); err != nil {
-		return nil, nil, fmt.Errorf("error from daemon: %s", err.GetMsg())
+		return nil, nil, fmt.Errorf("error from daemon: %w", daemonError(err))
 	}
 	info, err := convertStreamInfo(resp.GetStreamInfo())
 	if err != nil {
 		return nil, nil, fmt.Errorf("parsing stream info: %s", err)
 	}
 
+	if size := resp.GetStreamInfo().GetShmSize(); size > 0 {
+		stream, err := acceptShm(controlconn, size)
+		if err != nil {
+			control.Close()
+			return nil, nil, fmt.Errorf("accepting shared memory segment: %s", err)
+		}
+		return info, stream, nil
+	}
+
 	return info, control, nil
 }
 
-// Close stops the listener address.
+// Close stops the listener address and closes the connections streams are
+// passed over.
 func (c *Client) Close() error {
+	c.mhandlers.Lock()
+	for _, conn := range c.handlerConns {
+		conn.Close()
+	}
+	c.handlerConns = nil
+	c.mhandlers.Unlock()
+
 	if c.listener != nil {
 		err := c.listener.Close()
 		return err
@@ -148,22 +185,65 @@ func (c *Client) streamDispatcher() {
 			conn.Close()
 			continue
 		}
-		streamInfo, err := convertStreamInfo(info)
+
+		c.dispatchStream(info, rawconn, conn)
+	}
+}
+
+// dispatchStream hands a stream delivered over rawconn to the handler
+// registered for its protocol.
+func (c *Client) dispatchStream(info *pb.StreamInfo, rawconn net.Conn, conn io.ReadWriteCloser) {
+	streamInfo, err := convertStreamInfo(info)
+	if err != nil {
+		log.Errorw("error parsing stream info", "error", err)
+		conn.Close()
+		return
+	}
+
+	c.mhandlers.Lock()
+	handler, ok := c.handlers[streamInfo.Proto]
+	c.mhandlers.Unlock()
+	if !ok {
+		conn.Close()
+		return
+	}
+
+	size := info.GetShmSize()
+	if size == 0 {
+		go handler(streamInfo, conn)
+		return
+	}
+
+	go func() {
+		stream, err := acceptShm(rawconn, size)
 		if err != nil {
-			log.Errorw("error parsing stream info", "error", err)
+			log.Errorw("error accepting shared memory segment", "error", err)
 			conn.Close()
-			continue
+			return
 		}
+		handler(streamInfo, stream)
+	}()
+}
 
-		c.mhandlers.Lock()
-		handler, ok := c.handlers[streamInfo.Proto]
-		c.mhandlers.Unlock()
-		if !ok {
-			conn.Close()
-			continue
+// receiveStreams dispatches the streams the daemon passes over control until
+// the connection is closed.
+func (c *Client) receiveStreams(control *byteReaderConn, uc shm.UnixConn) {
+	for {
+		info := &pb.StreamInfo{}
+		err := readMsgSafe(control, info)
+		if err != nil {
+			log.Debugw("stream handler connection closed", "error", err)
+			return
 		}
 
-		go handler(streamInfo, conn)
+		conn, err := receiveConn(uc)
+		if err != nil {
+			log.Errorw("error receiving stream", "error", err)
+			control.Close()
+			return
+		}
+
+		c.dispatchStream(info, conn, conn)
 	}
 }
 
@@ -186,8 +266,13 @@ type StreamHandlerFunc func(*StreamInfo, io.ReadWriteCloser)
 
 // NewStreamHandler establishes an inbound multi-address and starts a listener.
 // All inbound connections to the listener are delegated to the provided
-// handler.
+// handler. A client without a listener receives its streams as file
+// descriptors over a control connection kept open for the purpose instead.
 func (c *Client) NewStreamHandler(protos []string, handler StreamHandlerFunc) error {
+	if c.listener == nil {
+		return c.newStreamHandlerConn(protos, handler)
+	}
+
 	control, err := c.newControlConn()
 	if err != nil {
 		return err
@@ -202,15 +287,64 @@ func (c *Client) NewStreamHandler(protos []string, handler StreamHandlerFunc) er
 		StreamHandler: &pb.StreamHandlerRequest{
 			Addr:  c.listenMaddr.Bytes(),
 			Proto: protos,
+			Shm:   c.shmRequested(),
+		},
+	}
+	if err := w.WriteMsg(req); err != nil {
+		return err
+	}
+
+	for _, proto := range protos {
+		c.handlers[proto] = handler
+	}
+
+	return nil
+}
+
+func (c *Client) newStreamHandlerConn(protos []string, handler StreamHandlerFunc) error {
+	controlconn, err := c.newControlConn()
+	if err != nil {
+		return err
+	}
+	uc, ok := controlconn.(shm.UnixConn)
+	if !ok {
+		controlconn.Close()
+		return errors.New("receiving streams without a listener requires a unix socket control address")
+	}
+	control := &byteReaderConn{controlconn}
+	w := ggio.NewDelimitedWriter(control)
+
+	req := &pb.Request{
+		Type: pb.Request_STREAM_HANDLER.Enum(),
+		StreamHandler: &pb.StreamHandlerRequest{
+			Proto:  protos,
+			Shm:    c.shmRequested(),
+			PassFd: proto.Bool(true),
 		},
 	}
 	if err := w.WriteMsg(req); err != nil {
+		control.Close()
 		return err
 	}
 
+	resp := &pb.Response{}
+	if err := readMsgSafe(control, resp); err != nil {
+		control.Close()
+		return err
+	}
+	if err := resp.GetError(); err != nil {
+		control.Close()
+		return fmt.Errorf("error from daemon: %w", daemonError(err))
+	}
+
+	c.mhandlers.Lock()
 	for _, proto := range protos {
 		c.handlers[proto] = handler
 	}
+	c.handlerConns = append(c.handlerConns, controlconn)
+	c.mhandlers.Unlock()
+
+	go c.receiveStreams(control, uc)
 
 	return nil
 }
@@ -17,10 +17,11 @@ import (
 
 	p2pd "github.com/libp2p/go-libp2p-daemon"
 	config "github.com/libp2p/go-libp2p-daemon/config"
-	mplex "github.com/libp2p/go-libp2p-mplex"
 	ps "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 	insecure "github.com/libp2p/go-libp2p/core/sec/insecure"
-	"github.com/libp2p/go-libp2p/p2p/muxer/yamux"
 	connmgr "github.com/libp2p/go-libp2p/p2p/net/connmgr"
 	noise "github.com/libp2p/go-libp2p/p2p/security/noise"
 	tls "github.com/libp2p/go-libp2p/p2p/security/tls"
@@ -66,11 +67,14 @@ func main() {
 	maddrString := flag.String("listen", "/unix/tmp/p2pd.sock", "daemon control listen multiaddr")
 	quiet := flag.Bool("q", false, "be quiet")
 	id := flag.String("id", "", "peer identity; private key file")
+	idGenerate := flag.Bool("idGenerate", false, "generates a key and writes it to the -id file if missing")
+	idPassphraseFile := flag.String("idPassphraseFile", "", "a file holding the passphrase of an encrypted identity; read from $"+p2pd.PassphraseEnv+" or prompted for otherwise")
 	bootstrap := flag.Bool("b", false, "connects to bootstrap peers and bootstraps the dht if enabled")
 	bootstrapPeers := flag.String("bootstrapPeers", "", "comma separated list of bootstrap peers; defaults to the IPFS DHT peers")
 	dht := flag.Bool("dht", false, "Enables the DHT in full node mode")
 	dhtClient := flag.Bool("dhtClient", false, "Enables the DHT in client mode")
 	dhtServer := flag.Bool("dhtServer", false, "Enables the DHT in server mode (use 'dht' unless you actually need this)")
+	dhtDatastore := flag.String("dhtDatastore", "", "a directory to persist the records the DHT stores in across restarts")
 	connMgr := flag.Bool("connManager", false, "Enables the Connection Manager")
 	connMgrLo := flag.Int("connLo", 256, "Connection Manager Low Water mark")
 	connMgrHi := flag.Int("connHi", 512, "Connection Manager High Water mark")
@@ -82,17 +86,19 @@ func main() {
 	pubsubSignStrict := flag.Bool("pubsubSignStrict", true, "Enables or disables pubsub strict signature verification")
 	gossipsubHeartbeatInterval := flag.Duration("gossipsubHeartbeatInterval", 0, "Specifies the gossipsub heartbeat interval")
 	gossipsubHeartbeatInitialDelay := flag.Duration("gossipsubHeartbeatInitialDelay", 0, "Specifies the gossipsub initial heartbeat delay")
-	relayEnabled := flag.Bool("relay", true, "Enables circuit relay")
-	relayActive := flag.Bool("relayActive", false, "Enables active mode for relay")
-	relayHop := flag.Bool("relayHop", false, "Enables hop for relay")
-	relayHopLimit := flag.Int("relayHopLimit", 0, "Sets the hop limit for hop relays")
-	relayDiscovery := flag.Bool("relayDiscovery", false, "Enables passive discovery for relay")
+	relayEnabled := flag.Bool("relay", true, "Enables the circuit relay v2 service")
+	relayActive := flag.Bool("relayActive", false, "Deprecated: has no effect with circuit relay v2")
+	relayHop := flag.Bool("relayHop", false, "Deprecated: has no effect with circuit relay v2")
+	relayHopLimit := flag.Int("relayHopLimit", 0, "Deprecated: has no effect with circuit relay v2")
+	relayDiscovery := flag.Bool("relayDiscovery", false, "Deprecated: has no effect with circuit relay v2")
 	autoRelay := flag.Bool("autoRelay", false, "Enables autorelay")
+	staticRelays := flag.String("staticRelays", "", "comma separated list of /p2p multiaddrs of the relays autorelay uses; defaults to relays found through the DHT")
 	autonat := flag.Bool("autonat", false, "Enables the AutoNAT service")
+	holePunching := flag.Bool("holePunching", false, "Enables hole punching to upgrade relayed connections to direct ones")
 	hostAddrs := flag.String("hostAddrs", "", "comma separated list of multiaddrs the host should listen on")
 	announceAddrs := flag.String("announceAddrs", "", "comma separated list of multiaddrs the host should announce to the network")
 	noListen := flag.Bool("noListenAddrs", false, "sets the host to listen on no addresses")
-	metricsAddr := flag.String("metricsAddr", "", "an address to bind the metrics handler to")
+	metricsAddr := flag.String("metricsAddr", "", "an address to bind the metrics and diagnostics handlers to")
 	configFilename := flag.String("f", "", "a file from which to read a json representation of the deamon config")
 	configStdin := flag.Bool("i", false, "have the daemon read the json config from stdin")
 	pprof := flag.Bool("pprof", false, "Enables the HTTP pprof handler, listening on the first port "+
@@ -102,18 +108,28 @@ func main() {
 	useNoise := flag.Bool("noise", true, "Enables Noise channel security protocol")
 	useTls := flag.Bool("tls", true, "Enables TLS1.3 channel security protocol")
 	usePlaintext := flag.Bool("plaintext", true, "Enables Plaintext channel security protocol")
-	forceReachabilityPublic := flag.Bool("forceReachabilityPublic", false, "Set up ForceReachability as public for autonat")
-	forceReachabilityPrivate := flag.Bool("forceReachabilityPrivate", false, "Set up ForceReachability as private for autonat")
-	muxer := flag.String("muxer", "yamux", "muxer to use for connections")
+	forceReachabilityPublic := flag.Bool("forceReachabilityPublic", false, "Assumes the daemon is publicly reachable instead of running AutoNAT")
+	forceReachabilityPrivate := flag.Bool("forceReachabilityPrivate", false, "Assumes the daemon is not publicly reachable instead of running AutoNAT")
+	muxer := flag.String("muxer", "", "comma separated list of muxers to offer, in order of preference; yamux or mplex (default yamux)")
+	transports := flag.String("transports", "", "comma separated list of transports to enable; tcp, quic, websocket, webtransport or webrtc (default all)")
 	echoEnabled := flag.Bool("echo", true, "Enables echo protocol")
+	diagnosticsFile := flag.String("diagnosticsFile", "", "a file to write diagnostics snapshots to on SIGUSR1; defaults to stdout")
+	peerstorePath := flag.String("peerstore", "", "a directory to persist the peerstore in across restarts")
+	auditFile := flag.String("auditFile", "", "a file to append an audit log of mutating control requests to")
+	mdnsEnabled := flag.Bool("mdns", false, "Enables mDNS discovery of peers on the local network")
+	mdnsServiceName := flag.String("mdnsServiceName", "", "the mDNS service name to announce and browse; defaults to "+config.DefaultMDNSServiceName)
+	mdnsAutoConnect := flag.Bool("mdnsAutoConnect", false, "Connects to the peers discovered with mDNS")
+	resourceLimits := flag.String("resourceLimits", "", "a json file of resource manager limits overriding the defaults")
+	swarmKey := flag.String("swarmKey", "", "a swarm.key file with the pre-shared key of a private network to join")
 
 	flag.Parse()
 
 	var c config.Config
 	opts := []libp2p.Option{
 		libp2p.UserAgent(p2pd.UserAgent()),
-		libp2p.DefaultTransports,
 	}
+	// dopts set up the daemon, along with its host
+	var dopts []p2pd.Option
 
 	if *configStdin {
 		stdin := bufio.NewReader(os.Stdin)
@@ -146,6 +162,14 @@ func main() {
 		c.ID = *id
 	}
 
+	if *idGenerate {
+		c.IDGenerate.Enabled = true
+	}
+
+	if *idPassphraseFile != "" {
+		c.IDPassphraseFile = *idPassphraseFile
+	}
+
 	if *hostAddrs != "" {
 		addrStrings := strings.Split(*hostAddrs, ",")
 		ha := make([]multiaddr.Multiaddr, len(addrStrings))
@@ -203,6 +227,19 @@ func main() {
 		c.Relay.Auto = true
 	}
 
+	if *staticRelays != "" {
+		addrStrings := strings.Split(*staticRelays, ",")
+		srs := make([]multiaddr.Multiaddr, len(addrStrings))
+		for i, s := range addrStrings {
+			ma, err := multiaddr.NewMultiaddr(s)
+			if err != nil {
+				log.Fatal(err)
+			}
+			(srs)[i] = ma
+		}
+		c.Relay.StaticRelays = srs
+	}
+
 	if *noListen {
 		c.NoListen = true
 	}
@@ -211,6 +248,18 @@ func main() {
 		c.AutoNat = true
 	}
 
+	if *forceReachabilityPublic {
+		c.ForceReachabilityPublic = true
+	}
+
+	if *forceReachabilityPrivate {
+		c.ForceReachabilityPrivate = true
+	}
+
+	if *holePunching {
+		c.HolePunching = true
+	}
+
 	if *echoEnabled {
 		c.Echo = true
 	}
@@ -245,10 +294,25 @@ func main() {
 		c.Bootstrap.Enabled = true
 	}
 
-	if *muxer == "mplex" {
-		opts = append(opts, libp2p.Muxer("/mplex/6.7.0", mplex.DefaultTransport))
-	} else if *muxer == "yamux" {
-		opts = append(opts, libp2p.Muxer("/yamux/1.0.0", yamux.DefaultTransport))
+	if *muxer != "" {
+		c.Transports.Muxers = strings.Split(*muxer, ",")
+	}
+
+	if *transports != "" {
+		enabled := make(map[string]bool)
+		for _, name := range strings.Split(*transports, ",") {
+			switch name {
+			case "tcp", "quic", "websocket", "webtransport", "webrtc":
+				enabled[name] = true
+			default:
+				log.Fatalf("unknown transport %s", name)
+			}
+		}
+		c.Transports.TCP.Enabled = enabled["tcp"]
+		c.Transports.QUIC.Enabled = enabled["quic"]
+		c.Transports.WebSocket.Enabled = enabled["websocket"]
+		c.Transports.WebTransport.Enabled = enabled["webtransport"]
+		c.Transports.WebRTC.Enabled = enabled["webrtc"]
 	}
 
 	if *quiet {
@@ -259,6 +323,39 @@ func main() {
 		c.MetricsAddress = *metricsAddr
 	}
 
+	if *diagnosticsFile != "" {
+		c.Diagnostics.File = *diagnosticsFile
+	}
+
+	if *peerstorePath != "" {
+		c.Peerstore.Path = *peerstorePath
+	}
+
+	if *auditFile != "" {
+		c.Audit.Enabled = true
+		c.Audit.File = *auditFile
+	}
+
+	if *resourceLimits != "" {
+		c.ResourceManager.Limits = *resourceLimits
+	}
+
+	if *swarmKey != "" {
+		c.PrivateNetwork.KeyFile = *swarmKey
+	}
+
+	if *mdnsEnabled {
+		c.MDNS.Enabled = true
+	}
+
+	if *mdnsServiceName != "" {
+		c.MDNS.ServiceName = *mdnsServiceName
+	}
+
+	if *mdnsAutoConnect {
+		c.MDNS.AutoConnect = true
+	}
+
 	if *dht {
 		c.DHT.Mode = config.DHTFullMode
 	} else if *dhtClient {
@@ -267,6 +364,10 @@ func main() {
 		c.DHT.Mode = config.DHTServerMode
 	}
 
+	if *dhtDatastore != "" {
+		c.DHT.Datastore.Path = *dhtDatastore
+	}
+
 	if *pprof {
 		c.PProf.Enabled = true
 		if pprofPort != nil {
@@ -295,16 +396,48 @@ func main() {
 
 	// collect opts
 	if c.ID != "" {
-		key, err := p2pd.ReadIdentity(c.ID)
-		if err != nil {
-			log.Fatal(err)
+		passphrase := func() ([]byte, error) {
+			return p2pd.ReadPassphrase(c.IDPassphraseFile, fmt.Sprintf("Passphrase for %s: ", c.ID))
+		}
+
+		var key crypto.PrivKey
+		if c.IDGenerate.Enabled {
+			typ, err := p2pd.KeyType(c.IDGenerate.Type)
+			if err != nil {
+				log.Fatal(err)
+			}
+			key, err = p2pd.ReadOrGenerateIdentity(c.ID, typ, c.IDGenerate.Bits, passphrase)
+			if err != nil {
+				log.Fatal(err)
+			}
+		} else {
+			key, err = p2pd.ReadIdentityPassphrase(c.ID, passphrase)
+			if err != nil {
+				log.Fatal(err)
+			}
 		}
 
 		opts = append(opts, libp2p.Identity(key))
 	}
 
+	transportOpts, err := p2pd.TransportOptions(c.Transports)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts = append(opts, transportOpts)
+
+	if c.PrivateNetwork.KeyFile != "" {
+		psk, err := p2pd.ReadSwarmKey(c.PrivateNetwork.KeyFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		dopts = append(dopts, p2pd.WithPrivateNetwork(psk))
+	}
+
 	if len(c.HostAddresses) > 0 {
 		opts = append(opts, libp2p.ListenAddrs(c.HostAddresses...))
+	} else if !c.NoListen {
+		opts = append(opts, p2pd.DefaultListenAddrs(c.Transports))
 	}
 
 	if len(c.AnnounceAddresses) > 0 {
@@ -324,6 +457,20 @@ func main() {
 		opts = append(opts, libp2p.ConnectionManager(cm))
 	}
 
+	if c.Peerstore.Path != "" {
+		pstore, err := p2pd.NewPeerstore(context.Background(), c.Peerstore)
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts = append(opts, libp2p.Peerstore(pstore))
+	}
+
+	rm, err := p2pd.NewResourceManager(c.ResourceManager)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts = append(opts, libp2p.ResourceManager(rm))
+
 	if c.NatPortMap {
 		opts = append(opts, libp2p.NATPortMap())
 	}
@@ -332,6 +479,28 @@ func main() {
 		opts = append(opts, libp2p.EnableNATService())
 	}
 
+	if c.ForceReachabilityPublic {
+		dopts = append(dopts, p2pd.WithForcedReachability(network.ReachabilityPublic))
+	} else if c.ForceReachabilityPrivate {
+		dopts = append(dopts, p2pd.WithForcedReachability(network.ReachabilityPrivate))
+	}
+
+	if c.Relay.Auto {
+		if len(c.Relay.StaticRelays) > 0 {
+			relays, err := peer.AddrInfosFromP2pAddrs(c.Relay.StaticRelays...)
+			if err != nil {
+				log.Fatal(err)
+			}
+			opts = append(opts, libp2p.EnableAutoRelayWithStaticRelays(relays))
+		} else {
+			dopts = append(dopts, p2pd.WithAutoRelayDHT())
+		}
+	}
+
+	if c.HolePunching {
+		dopts = append(dopts, p2pd.WithHolePunching())
+	}
+
 	if c.NoListen {
 		opts = append(opts,
 			libp2p.NoListenAddrs,
@@ -356,20 +525,51 @@ func main() {
 	}
 	opts = append(opts, securityOpts...)
 
-	if *forceReachabilityPrivate && *forceReachabilityPublic {
-		log.Fatal("forceReachability must be public or private, not both")
-	} else if *forceReachabilityPrivate {
-		opts = append(opts, libp2p.ForceReachabilityPrivate())
-	} else if *forceReachabilityPublic {
-		opts = append(opts, libp2p.ForceReachabilityPublic())
+	if c.DHT.Mode != "" {
+		dhtStore, err := p2pd.NewDHTStore(c.DHT.Datastore)
+		if err != nil {
+			log.Fatal(err)
+		}
+		dopts = append(dopts, p2pd.WithDHTStore(dhtStore))
 	}
 
 	// start daemon
-	d, err := p2pd.NewDaemon(context.Background(), &c.ListenAddr, c.DHT.Mode, opts...)
+	dopts = append(dopts, p2pd.WithHostOptions(opts...))
+	d, err := p2pd.NewDaemonWithOptions(context.Background(), &c.ListenAddr, c.DHT.Mode, dopts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = d.LoadConnectionGater(c.ConnectionGater)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if c.Diagnostics.File != "" {
+		d.SetDiagnosticsFile(c.Diagnostics.File)
+	}
+
+	if c.Audit.Enabled {
+		err = d.EnableAudit(c.Audit)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if c.RateLimit.Enabled {
+		err = d.EnableRateLimits(c.RateLimit)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if c.Tracing.Enabled {
+		err = d.EnableTracing(c.Tracing)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	if c.PubSub.Enabled {
 		if c.PubSub.GossipSubHeartbeat.Interval > 0 {
 			ps.GossipSubHeartbeatInterval = c.PubSub.GossipSubHeartbeat.Interval
@@ -385,7 +585,11 @@ func main() {
 	}
 
 	if c.Relay.Enabled {
-		err = d.EnableRelayV2()
+		relayOpts, err := p2pd.RelayServiceOptions(c.Relay.Service)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = d.EnableRelayV2(relayOpts...)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -398,6 +602,13 @@ func main() {
 		}
 	}
 
+	if c.MDNS.Enabled {
+		err = d.EnableMDNS(c.MDNS)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	if len(c.Bootstrap.Peers) > 0 {
 		p2pd.BootstrapPeers = c.Bootstrap.Peers
 	}
@@ -426,6 +637,7 @@ func main() {
 
 	if c.MetricsAddress != "" {
 		http.Handle("/metrics", promhttp.Handler())
+		http.Handle("/diagnostics", d.DiagnosticsHandler())
 		go func() { log.Println(http.ListenAndServe(c.MetricsAddress, nil)) }()
 	}
 
@@ -24,42 +24,135 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// whether the daemon is reachable by peers on the public internet, as
+// determined by AutoNAT
+type Reachability int32
+
+const (
+	Reachability_UNKNOWN Reachability = 0
+	Reachability_PUBLIC  Reachability = 1
+	Reachability_PRIVATE Reachability = 2
+)
+
+var Reachability_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "PUBLIC",
+	2: "PRIVATE",
+}
+
+var Reachability_value = map[string]int32{
+	"UNKNOWN": 0,
+	"PUBLIC":  1,
+	"PRIVATE": 2,
+}
+
+func (x Reachability) Enum() *Reachability {
+	p := new(Reachability)
+	*p = x
+	return p
+}
+
+func (x Reachability) String() string {
+	return proto.EnumName(Reachability_name, int32(x))
+}
+
+func (x *Reachability) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(Reachability_value, data, "Reachability")
+	if err != nil {
+		return err
+	}
+	*x = Reachability(value)
+	return nil
+}
+
+func (Reachability) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{0}
+}
+
 type Request_Type int32
 
 const (
-	Request_IDENTIFY       Request_Type = 0
-	Request_CONNECT        Request_Type = 1
-	Request_STREAM_OPEN    Request_Type = 2
-	Request_STREAM_HANDLER Request_Type = 3
-	Request_DHT            Request_Type = 4
-	Request_LIST_PEERS     Request_Type = 5
-	Request_CONNMANAGER    Request_Type = 6
-	Request_DISCONNECT     Request_Type = 7
-	Request_PUBSUB         Request_Type = 8
+	Request_IDENTIFY                Request_Type = 0
+	Request_CONNECT                 Request_Type = 1
+	Request_STREAM_OPEN             Request_Type = 2
+	Request_STREAM_HANDLER          Request_Type = 3
+	Request_DHT                     Request_Type = 4
+	Request_LIST_PEERS              Request_Type = 5
+	Request_CONNMANAGER             Request_Type = 6
+	Request_DISCONNECT              Request_Type = 7
+	Request_PUBSUB                  Request_Type = 8
+	Request_DIAGNOSTICS             Request_Type = 9
+	Request_RESOURCE_USAGE          Request_Type = 10
+	Request_CANCEL                  Request_Type = 11
+	Request_CONNGATER               Request_Type = 12
+	Request_SIGN                    Request_Type = 13
+	Request_VERIFY                  Request_Type = 14
+	Request_SEAL_ENVELOPE           Request_Type = 15
+	Request_OPEN_ENVELOPE           Request_Type = 16
+	Request_PEER_RECORD             Request_Type = 17
+	Request_ADD_PEER_RECORD         Request_Type = 18
+	Request_EVENTS                  Request_Type = 19
+	Request_DIRECT_CONNECT          Request_Type = 20
+	Request_RELAY_RESERVE           Request_Type = 21
+	Request_RELAY_LIST_RESERVATIONS Request_Type = 22
+	Request_RELAY_CANCEL            Request_Type = 23
+	Request_GET_REACHABILITY        Request_Type = 24
 )
 
 var Request_Type_name = map[int32]string{
-	0: "IDENTIFY",
-	1: "CONNECT",
-	2: "STREAM_OPEN",
-	3: "STREAM_HANDLER",
-	4: "DHT",
-	5: "LIST_PEERS",
-	6: "CONNMANAGER",
-	7: "DISCONNECT",
-	8: "PUBSUB",
+	0:  "IDENTIFY",
+	1:  "CONNECT",
+	2:  "STREAM_OPEN",
+	3:  "STREAM_HANDLER",
+	4:  "DHT",
+	5:  "LIST_PEERS",
+	6:  "CONNMANAGER",
+	7:  "DISCONNECT",
+	8:  "PUBSUB",
+	9:  "DIAGNOSTICS",
+	10: "RESOURCE_USAGE",
+	11: "CANCEL",
+	12: "CONNGATER",
+	13: "SIGN",
+	14: "VERIFY",
+	15: "SEAL_ENVELOPE",
+	16: "OPEN_ENVELOPE",
+	17: "PEER_RECORD",
+	18: "ADD_PEER_RECORD",
+	19: "EVENTS",
+	20: "DIRECT_CONNECT",
+	21: "RELAY_RESERVE",
+	22: "RELAY_LIST_RESERVATIONS",
+	23: "RELAY_CANCEL",
+	24: "GET_REACHABILITY",
 }
 
 var Request_Type_value = map[string]int32{
-	"IDENTIFY":       0,
-	"CONNECT":        1,
-	"STREAM_OPEN":    2,
-	"STREAM_HANDLER": 3,
-	"DHT":            4,
-	"LIST_PEERS":     5,
-	"CONNMANAGER":    6,
-	"DISCONNECT":     7,
-	"PUBSUB":         8,
+	"IDENTIFY":                0,
+	"CONNECT":                 1,
+	"STREAM_OPEN":             2,
+	"STREAM_HANDLER":          3,
+	"DHT":                     4,
+	"LIST_PEERS":              5,
+	"CONNMANAGER":             6,
+	"DISCONNECT":              7,
+	"PUBSUB":                  8,
+	"DIAGNOSTICS":             9,
+	"RESOURCE_USAGE":          10,
+	"CANCEL":                  11,
+	"CONNGATER":               12,
+	"SIGN":                    13,
+	"VERIFY":                  14,
+	"SEAL_ENVELOPE":           15,
+	"OPEN_ENVELOPE":           16,
+	"PEER_RECORD":             17,
+	"ADD_PEER_RECORD":         18,
+	"EVENTS":                  19,
+	"DIRECT_CONNECT":          20,
+	"RELAY_RESERVE":           21,
+	"RELAY_LIST_RESERVATIONS": 22,
+	"RELAY_CANCEL":            23,
+	"GET_REACHABILITY":        24,
 }
 
 func (x Request_Type) Enum() *Request_Type {
@@ -125,6 +218,49 @@ func (Response_Type) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_7333f0e9b622f7df, []int{1, 0}
 }
 
+type ErrorResponse_Code int32
+
+const (
+	ErrorResponse_UNKNOWN      ErrorResponse_Code = 0
+	ErrorResponse_RATE_LIMITED ErrorResponse_Code = 1
+	ErrorResponse_CANCELLED    ErrorResponse_Code = 2
+)
+
+var ErrorResponse_Code_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "RATE_LIMITED",
+	2: "CANCELLED",
+}
+
+var ErrorResponse_Code_value = map[string]int32{
+	"UNKNOWN":      0,
+	"RATE_LIMITED": 1,
+	"CANCELLED":    2,
+}
+
+func (x ErrorResponse_Code) Enum() *ErrorResponse_Code {
+	p := new(ErrorResponse_Code)
+	*p = x
+	return p
+}
+
+func (x ErrorResponse_Code) String() string {
+	return proto.EnumName(ErrorResponse_Code_name, int32(x))
+}
+
+func (x *ErrorResponse_Code) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(ErrorResponse_Code_value, data, "ErrorResponse_Code")
+	if err != nil {
+		return err
+	}
+	*x = ErrorResponse_Code(value)
+	return nil
+}
+
+func (ErrorResponse_Code) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{7, 0}
+}
+
 type DHTRequest_Type int32
 
 const (
@@ -137,6 +273,7 @@ const (
 	DHTRequest_SEARCH_VALUE                 DHTRequest_Type = 6
 	DHTRequest_PUT_VALUE                    DHTRequest_Type = 7
 	DHTRequest_PROVIDE                      DHTRequest_Type = 8
+	DHTRequest_STORAGE_STATS                DHTRequest_Type = 9
 )
 
 var DHTRequest_Type_name = map[int32]string{
@@ -149,6 +286,7 @@ var DHTRequest_Type_name = map[int32]string{
 	6: "SEARCH_VALUE",
 	7: "PUT_VALUE",
 	8: "PROVIDE",
+	9: "STORAGE_STATS",
 }
 
 var DHTRequest_Type_value = map[string]int32{
@@ -161,6 +299,7 @@ var DHTRequest_Type_value = map[string]int32{
 	"SEARCH_VALUE":                 6,
 	"PUT_VALUE":                    7,
 	"PROVIDE":                      8,
+	"STORAGE_STATS":                9,
 }
 
 func (x DHTRequest_Type) Enum() *DHTRequest_Type {
@@ -183,7 +322,7 @@ func (x *DHTRequest_Type) UnmarshalJSON(data []byte) error {
 }
 
 func (DHTRequest_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{8, 0}
+	return fileDescriptor_7333f0e9b622f7df, []int{9, 0}
 }
 
 type DHTResponse_Type int32
@@ -226,7 +365,7 @@ func (x *DHTResponse_Type) UnmarshalJSON(data []byte) error {
 }
 
 func (DHTResponse_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{9, 0}
+	return fileDescriptor_7333f0e9b622f7df, []int{10, 0}
 }
 
 type ConnManagerRequest_Type int32
@@ -269,7 +408,155 @@ func (x *ConnManagerRequest_Type) UnmarshalJSON(data []byte) error {
 }
 
 func (ConnManagerRequest_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{11, 0}
+	return fileDescriptor_7333f0e9b622f7df, []int{13, 0}
+}
+
+type ConnGaterRequest_Type int32
+
+const (
+	ConnGaterRequest_BLOCK_PEER     ConnGaterRequest_Type = 0
+	ConnGaterRequest_UNBLOCK_PEER   ConnGaterRequest_Type = 1
+	ConnGaterRequest_BLOCK_SUBNET   ConnGaterRequest_Type = 2
+	ConnGaterRequest_UNBLOCK_SUBNET ConnGaterRequest_Type = 3
+	ConnGaterRequest_LIST_BLOCKED   ConnGaterRequest_Type = 4
+)
+
+var ConnGaterRequest_Type_name = map[int32]string{
+	0: "BLOCK_PEER",
+	1: "UNBLOCK_PEER",
+	2: "BLOCK_SUBNET",
+	3: "UNBLOCK_SUBNET",
+	4: "LIST_BLOCKED",
+}
+
+var ConnGaterRequest_Type_value = map[string]int32{
+	"BLOCK_PEER":     0,
+	"UNBLOCK_PEER":   1,
+	"BLOCK_SUBNET":   2,
+	"UNBLOCK_SUBNET": 3,
+	"LIST_BLOCKED":   4,
+}
+
+func (x ConnGaterRequest_Type) Enum() *ConnGaterRequest_Type {
+	p := new(ConnGaterRequest_Type)
+	*p = x
+	return p
+}
+
+func (x ConnGaterRequest_Type) String() string {
+	return proto.EnumName(ConnGaterRequest_Type_name, int32(x))
+}
+
+func (x *ConnGaterRequest_Type) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(ConnGaterRequest_Type_value, data, "ConnGaterRequest_Type")
+	if err != nil {
+		return err
+	}
+	*x = ConnGaterRequest_Type(value)
+	return nil
+}
+
+func (ConnGaterRequest_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{14, 0}
+}
+
+type Event_Type int32
+
+const (
+	Event_HOLE_PUNCH Event_Type = 0
+	// a change of the reachability of the daemon
+	Event_REACHABILITY Event_Type = 1
+	// a peer discovered on the local network, with its addresses
+	Event_PEER_DISCOVERED Event_Type = 2
+)
+
+var Event_Type_name = map[int32]string{
+	0: "HOLE_PUNCH",
+	1: "REACHABILITY",
+	2: "PEER_DISCOVERED",
+}
+
+var Event_Type_value = map[string]int32{
+	"HOLE_PUNCH":      0,
+	"REACHABILITY":    1,
+	"PEER_DISCOVERED": 2,
+}
+
+func (x Event_Type) Enum() *Event_Type {
+	p := new(Event_Type)
+	*p = x
+	return p
+}
+
+func (x Event_Type) String() string {
+	return proto.EnumName(Event_Type_name, int32(x))
+}
+
+func (x *Event_Type) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(Event_Type_value, data, "Event_Type")
+	if err != nil {
+		return err
+	}
+	*x = Event_Type(value)
+	return nil
+}
+
+func (Event_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{28, 0}
+}
+
+type HolePunchEvent_Type int32
+
+const (
+	// a direct dial to the public addresses of the peer, before hole punching
+	HolePunchEvent_DIRECT_DIAL HolePunchEvent_Type = 0
+	// a failure to coordinate a hole punch with the peer
+	HolePunchEvent_PROTOCOL_ERROR HolePunchEvent_Type = 1
+	// the start of a hole punch, with the addresses of the peer
+	HolePunchEvent_START HolePunchEvent_Type = 2
+	// an attempt to connect to the peer through the hole
+	HolePunchEvent_ATTEMPT HolePunchEvent_Type = 3
+	// the end of a hole punch
+	HolePunchEvent_END HolePunchEvent_Type = 4
+)
+
+var HolePunchEvent_Type_name = map[int32]string{
+	0: "DIRECT_DIAL",
+	1: "PROTOCOL_ERROR",
+	2: "START",
+	3: "ATTEMPT",
+	4: "END",
+}
+
+var HolePunchEvent_Type_value = map[string]int32{
+	"DIRECT_DIAL":    0,
+	"PROTOCOL_ERROR": 1,
+	"START":          2,
+	"ATTEMPT":        3,
+	"END":            4,
+}
+
+func (x HolePunchEvent_Type) Enum() *HolePunchEvent_Type {
+	p := new(HolePunchEvent_Type)
+	*p = x
+	return p
+}
+
+func (x HolePunchEvent_Type) String() string {
+	return proto.EnumName(HolePunchEvent_Type_name, int32(x))
+}
+
+func (x *HolePunchEvent_Type) UnmarshalJSON(data []byte) error {
+	value, err := proto.UnmarshalJSONEnum(HolePunchEvent_Type_value, data, "HolePunchEvent_Type")
+	if err != nil {
+		return err
+	}
+	*x = HolePunchEvent_Type(value)
+	return nil
+}
+
+func (HolePunchEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{29, 0}
 }
 
 type PSRequest_Type int32
@@ -315,18 +602,34 @@ func (x *PSRequest_Type) UnmarshalJSON(data []byte) error {
 }
 
 func (PSRequest_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{13, 0}
+	return fileDescriptor_7333f0e9b622f7df, []int{36, 0}
 }
 
 type Request struct {
-	Type                 *Request_Type         `protobuf:"varint,1,req,name=type,enum=p2pd.pb.Request_Type" json:"type,omitempty"`
-	Connect              *ConnectRequest       `protobuf:"bytes,2,opt,name=connect" json:"connect,omitempty"`
-	StreamOpen           *StreamOpenRequest    `protobuf:"bytes,3,opt,name=streamOpen" json:"streamOpen,omitempty"`
-	StreamHandler        *StreamHandlerRequest `protobuf:"bytes,4,opt,name=streamHandler" json:"streamHandler,omitempty"`
-	Dht                  *DHTRequest           `protobuf:"bytes,5,opt,name=dht" json:"dht,omitempty"`
-	ConnManager          *ConnManagerRequest   `protobuf:"bytes,6,opt,name=connManager" json:"connManager,omitempty"`
-	Disconnect           *DisconnectRequest    `protobuf:"bytes,7,opt,name=disconnect" json:"disconnect,omitempty"`
-	Pubsub               *PSRequest            `protobuf:"bytes,8,opt,name=pubsub" json:"pubsub,omitempty"`
+	Type          *Request_Type         `protobuf:"varint,1,req,name=type,enum=p2pd.pb.Request_Type" json:"type,omitempty"`
+	Connect       *ConnectRequest       `protobuf:"bytes,2,opt,name=connect" json:"connect,omitempty"`
+	StreamOpen    *StreamOpenRequest    `protobuf:"bytes,3,opt,name=streamOpen" json:"streamOpen,omitempty"`
+	StreamHandler *StreamHandlerRequest `protobuf:"bytes,4,opt,name=streamHandler" json:"streamHandler,omitempty"`
+	Dht           *DHTRequest           `protobuf:"bytes,5,opt,name=dht" json:"dht,omitempty"`
+	ConnManager   *ConnManagerRequest   `protobuf:"bytes,6,opt,name=connManager" json:"connManager,omitempty"`
+	Disconnect    *DisconnectRequest    `protobuf:"bytes,7,opt,name=disconnect" json:"disconnect,omitempty"`
+	Pubsub        *PSRequest            `protobuf:"bytes,8,opt,name=pubsub" json:"pubsub,omitempty"`
+	// W3C trace context of the client's span, continued by the daemon
+	Traceparent *string `protobuf:"bytes,9,opt,name=traceparent" json:"traceparent,omitempty"`
+	Tracestate  *string `protobuf:"bytes,10,opt,name=tracestate" json:"tracestate,omitempty"`
+	// id is echoed in the response, and names the request in a CANCEL request
+	Id                   *uint64               `protobuf:"varint,11,opt,name=id" json:"id,omitempty"`
+	Cancel               *CancelRequest        `protobuf:"bytes,12,opt,name=cancel" json:"cancel,omitempty"`
+	ConnGater            *ConnGaterRequest     `protobuf:"bytes,13,opt,name=connGater" json:"connGater,omitempty"`
+	Sign                 *SignRequest          `protobuf:"bytes,14,opt,name=sign" json:"sign,omitempty"`
+	Verify               *VerifyRequest        `protobuf:"bytes,15,opt,name=verify" json:"verify,omitempty"`
+	SealEnvelope         *SealEnvelopeRequest  `protobuf:"bytes,16,opt,name=sealEnvelope" json:"sealEnvelope,omitempty"`
+	OpenEnvelope         *OpenEnvelopeRequest  `protobuf:"bytes,17,opt,name=openEnvelope" json:"openEnvelope,omitempty"`
+	AddPeerRecord        *AddPeerRecordRequest `protobuf:"bytes,18,opt,name=addPeerRecord" json:"addPeerRecord,omitempty"`
+	Events               *EventsRequest        `protobuf:"bytes,19,opt,name=events" json:"events,omitempty"`
+	DirectConnect        *DirectConnectRequest `protobuf:"bytes,20,opt,name=directConnect" json:"directConnect,omitempty"`
+	RelayReserve         *RelayReserveRequest  `protobuf:"bytes,21,opt,name=relayReserve" json:"relayReserve,omitempty"`
+	RelayCancel          *RelayCancelRequest   `protobuf:"bytes,22,opt,name=relayCancel" json:"relayCancel,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -421,17 +724,127 @@ func (m *Request) GetPubsub() *PSRequest {
 	return nil
 }
 
+func (m *Request) GetTraceparent() string {
+	if m != nil && m.Traceparent != nil {
+		return *m.Traceparent
+	}
+	return ""
+}
+
+func (m *Request) GetTracestate() string {
+	if m != nil && m.Tracestate != nil {
+		return *m.Tracestate
+	}
+	return ""
+}
+
+func (m *Request) GetId() uint64 {
+	if m != nil && m.Id != nil {
+		return *m.Id
+	}
+	return 0
+}
+
+func (m *Request) GetCancel() *CancelRequest {
+	if m != nil {
+		return m.Cancel
+	}
+	return nil
+}
+
+func (m *Request) GetConnGater() *ConnGaterRequest {
+	if m != nil {
+		return m.ConnGater
+	}
+	return nil
+}
+
+func (m *Request) GetSign() *SignRequest {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+func (m *Request) GetVerify() *VerifyRequest {
+	if m != nil {
+		return m.Verify
+	}
+	return nil
+}
+
+func (m *Request) GetSealEnvelope() *SealEnvelopeRequest {
+	if m != nil {
+		return m.SealEnvelope
+	}
+	return nil
+}
+
+func (m *Request) GetOpenEnvelope() *OpenEnvelopeRequest {
+	if m != nil {
+		return m.OpenEnvelope
+	}
+	return nil
+}
+
+func (m *Request) GetAddPeerRecord() *AddPeerRecordRequest {
+	if m != nil {
+		return m.AddPeerRecord
+	}
+	return nil
+}
+
+func (m *Request) GetEvents() *EventsRequest {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *Request) GetDirectConnect() *DirectConnectRequest {
+	if m != nil {
+		return m.DirectConnect
+	}
+	return nil
+}
+
+func (m *Request) GetRelayReserve() *RelayReserveRequest {
+	if m != nil {
+		return m.RelayReserve
+	}
+	return nil
+}
+
+func (m *Request) GetRelayCancel() *RelayCancelRequest {
+	if m != nil {
+		return m.RelayCancel
+	}
+	return nil
+}
+
 type Response struct {
-	Type                 *Response_Type    `protobuf:"varint,1,req,name=type,enum=p2pd.pb.Response_Type" json:"type,omitempty"`
-	Error                *ErrorResponse    `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
-	StreamInfo           *StreamInfo       `protobuf:"bytes,3,opt,name=streamInfo" json:"streamInfo,omitempty"`
-	Identify             *IdentifyResponse `protobuf:"bytes,4,opt,name=identify" json:"identify,omitempty"`
-	Dht                  *DHTResponse      `protobuf:"bytes,5,opt,name=dht" json:"dht,omitempty"`
-	Peers                []*PeerInfo       `protobuf:"bytes,6,rep,name=peers" json:"peers,omitempty"`
-	Pubsub               *PSResponse       `protobuf:"bytes,7,opt,name=pubsub" json:"pubsub,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Type                 *Response_Type         `protobuf:"varint,1,req,name=type,enum=p2pd.pb.Response_Type" json:"type,omitempty"`
+	Error                *ErrorResponse         `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+	StreamInfo           *StreamInfo            `protobuf:"bytes,3,opt,name=streamInfo" json:"streamInfo,omitempty"`
+	Identify             *IdentifyResponse      `protobuf:"bytes,4,opt,name=identify" json:"identify,omitempty"`
+	Dht                  *DHTResponse           `protobuf:"bytes,5,opt,name=dht" json:"dht,omitempty"`
+	Peers                []*PeerInfo            `protobuf:"bytes,6,rep,name=peers" json:"peers,omitempty"`
+	Pubsub               *PSResponse            `protobuf:"bytes,7,opt,name=pubsub" json:"pubsub,omitempty"`
+	Diagnostics          *DiagnosticsResponse   `protobuf:"bytes,8,opt,name=diagnostics" json:"diagnostics,omitempty"`
+	ResourceUsage        *ResourceUsageResponse `protobuf:"bytes,9,opt,name=resourceUsage" json:"resourceUsage,omitempty"`
+	Id                   *uint64                `protobuf:"varint,10,opt,name=id" json:"id,omitempty"`
+	ConnGater            *ConnGaterResponse     `protobuf:"bytes,11,opt,name=connGater" json:"connGater,omitempty"`
+	Sign                 *SignResponse          `protobuf:"bytes,12,opt,name=sign" json:"sign,omitempty"`
+	Verify               *VerifyResponse        `protobuf:"bytes,13,opt,name=verify" json:"verify,omitempty"`
+	SealEnvelope         *SealEnvelopeResponse  `protobuf:"bytes,14,opt,name=sealEnvelope" json:"sealEnvelope,omitempty"`
+	OpenEnvelope         *OpenEnvelopeResponse  `protobuf:"bytes,15,opt,name=openEnvelope" json:"openEnvelope,omitempty"`
+	PeerRecord           *PeerRecordResponse    `protobuf:"bytes,16,opt,name=peerRecord" json:"peerRecord,omitempty"`
+	AddPeerRecord        *AddPeerRecordResponse `protobuf:"bytes,17,opt,name=addPeerRecord" json:"addPeerRecord,omitempty"`
+	RelayReservations    []*RelayReservation    `protobuf:"bytes,18,rep,name=relayReservations" json:"relayReservations,omitempty"`
+	Reachability         *ReachabilityResponse  `protobuf:"bytes,19,opt,name=reachability" json:"reachability,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
 }
 
 func (m *Response) Reset()         { *m = Response{} }
@@ -516,9 +929,95 @@ func (m *Response) GetPubsub() *PSResponse {
 	return nil
 }
 
+func (m *Response) GetDiagnostics() *DiagnosticsResponse {
+	if m != nil {
+		return m.Diagnostics
+	}
+	return nil
+}
+
+func (m *Response) GetResourceUsage() *ResourceUsageResponse {
+	if m != nil {
+		return m.ResourceUsage
+	}
+	return nil
+}
+
+func (m *Response) GetId() uint64 {
+	if m != nil && m.Id != nil {
+		return *m.Id
+	}
+	return 0
+}
+
+func (m *Response) GetConnGater() *ConnGaterResponse {
+	if m != nil {
+		return m.ConnGater
+	}
+	return nil
+}
+
+func (m *Response) GetSign() *SignResponse {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+func (m *Response) GetVerify() *VerifyResponse {
+	if m != nil {
+		return m.Verify
+	}
+	return nil
+}
+
+func (m *Response) GetSealEnvelope() *SealEnvelopeResponse {
+	if m != nil {
+		return m.SealEnvelope
+	}
+	return nil
+}
+
+func (m *Response) GetOpenEnvelope() *OpenEnvelopeResponse {
+	if m != nil {
+		return m.OpenEnvelope
+	}
+	return nil
+}
+
+func (m *Response) GetPeerRecord() *PeerRecordResponse {
+	if m != nil {
+		return m.PeerRecord
+	}
+	return nil
+}
+
+func (m *Response) GetAddPeerRecord() *AddPeerRecordResponse {
+	if m != nil {
+		return m.AddPeerRecord
+	}
+	return nil
+}
+
+func (m *Response) GetRelayReservations() []*RelayReservation {
+	if m != nil {
+		return m.RelayReservations
+	}
+	return nil
+}
+
+func (m *Response) GetReachability() *ReachabilityResponse {
+	if m != nil {
+		return m.Reachability
+	}
+	return nil
+}
+
 type IdentifyResponse struct {
-	Id                   []byte   `protobuf:"bytes,1,req,name=id" json:"id,omitempty"`
-	Addrs                [][]byte `protobuf:"bytes,2,rep,name=addrs" json:"addrs,omitempty"`
+	Id    []byte   `protobuf:"bytes,1,req,name=id" json:"id,omitempty"`
+	Addrs [][]byte `protobuf:"bytes,2,rep,name=addrs" json:"addrs,omitempty"`
+	// the marshaled envelope of the daemon's signed peer record, if it has one
+	PeerRecord           []byte   `protobuf:"bytes,3,opt,name=peerRecord" json:"peerRecord,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -571,10 +1070,18 @@ func (m *IdentifyResponse) GetAddrs() [][]byte {
 	return nil
 }
 
+func (m *IdentifyResponse) GetPeerRecord() []byte {
+	if m != nil {
+		return m.PeerRecord
+	}
+	return nil
+}
+
 type ConnectRequest struct {
 	Peer                 []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
 	Addrs                [][]byte `protobuf:"bytes,2,rep,name=addrs" json:"addrs,omitempty"`
 	Timeout              *int64   `protobuf:"varint,3,opt,name=timeout" json:"timeout,omitempty"`
+	TimeoutMillis        *int64   `protobuf:"varint,4,opt,name=timeoutMillis" json:"timeoutMillis,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -634,10 +1141,19 @@ func (m *ConnectRequest) GetTimeout() int64 {
 	return 0
 }
 
+func (m *ConnectRequest) GetTimeoutMillis() int64 {
+	if m != nil && m.TimeoutMillis != nil {
+		return *m.TimeoutMillis
+	}
+	return 0
+}
+
 type StreamOpenRequest struct {
 	Peer                 []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
 	Proto                []string `protobuf:"bytes,2,rep,name=proto" json:"proto,omitempty"`
 	Timeout              *int64   `protobuf:"varint,3,opt,name=timeout" json:"timeout,omitempty"`
+	Shm                  *bool    `protobuf:"varint,4,opt,name=shm" json:"shm,omitempty"`
+	TimeoutMillis        *int64   `protobuf:"varint,5,opt,name=timeoutMillis" json:"timeoutMillis,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -697,9 +1213,25 @@ func (m *StreamOpenRequest) GetTimeout() int64 {
 	return 0
 }
 
-type StreamHandlerRequest struct {
-	Addr                 []byte   `protobuf:"bytes,1,req,name=addr" json:"addr,omitempty"`
+func (m *StreamOpenRequest) GetShm() bool {
+	if m != nil && m.Shm != nil {
+		return *m.Shm
+	}
+	return false
+}
+
+func (m *StreamOpenRequest) GetTimeoutMillis() int64 {
+	if m != nil && m.TimeoutMillis != nil {
+		return *m.TimeoutMillis
+	}
+	return 0
+}
+
+type StreamHandlerRequest struct {
+	Addr                 []byte   `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
 	Proto                []string `protobuf:"bytes,2,rep,name=proto" json:"proto,omitempty"`
+	Shm                  *bool    `protobuf:"varint,3,opt,name=shm" json:"shm,omitempty"`
+	PassFd               *bool    `protobuf:"varint,4,opt,name=passFd" json:"passFd,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -752,18 +1284,80 @@ func (m *StreamHandlerRequest) GetProto() []string {
 	return nil
 }
 
-type ErrorResponse struct {
-	Msg                  *string  `protobuf:"bytes,1,req,name=msg" json:"msg,omitempty"`
+func (m *StreamHandlerRequest) GetShm() bool {
+	if m != nil && m.Shm != nil {
+		return *m.Shm
+	}
+	return false
+}
+
+func (m *StreamHandlerRequest) GetPassFd() bool {
+	if m != nil && m.PassFd != nil {
+		return *m.PassFd
+	}
+	return false
+}
+
+type CancelRequest struct {
+	Id                   *uint64  `protobuf:"varint,1,req,name=id" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
+func (m *CancelRequest) Reset()         { *m = CancelRequest{} }
+func (m *CancelRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelRequest) ProtoMessage()    {}
+func (*CancelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{6}
+}
+func (m *CancelRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CancelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CancelRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CancelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelRequest.Merge(m, src)
+}
+func (m *CancelRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CancelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelRequest proto.InternalMessageInfo
+
+func (m *CancelRequest) GetId() uint64 {
+	if m != nil && m.Id != nil {
+		return *m.Id
+	}
+	return 0
+}
+
+type ErrorResponse struct {
+	Msg                  *string             `protobuf:"bytes,1,req,name=msg" json:"msg,omitempty"`
+	Code                 *ErrorResponse_Code `protobuf:"varint,2,opt,name=code,enum=p2pd.pb.ErrorResponse_Code" json:"code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
 func (m *ErrorResponse) Reset()         { *m = ErrorResponse{} }
 func (m *ErrorResponse) String() string { return proto.CompactTextString(m) }
 func (*ErrorResponse) ProtoMessage()    {}
 func (*ErrorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{6}
+	return fileDescriptor_7333f0e9b622f7df, []int{7}
 }
 func (m *ErrorResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -799,10 +1393,18 @@ func (m *ErrorResponse) GetMsg() string {
 	return ""
 }
 
+func (m *ErrorResponse) GetCode() ErrorResponse_Code {
+	if m != nil && m.Code != nil {
+		return *m.Code
+	}
+	return ErrorResponse_UNKNOWN
+}
+
 type StreamInfo struct {
 	Peer                 []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
 	Addr                 []byte   `protobuf:"bytes,2,req,name=addr" json:"addr,omitempty"`
 	Proto                *string  `protobuf:"bytes,3,req,name=proto" json:"proto,omitempty"`
+	ShmSize              *uint64  `protobuf:"varint,4,opt,name=shmSize" json:"shmSize,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -812,7 +1414,7 @@ func (m *StreamInfo) Reset()         { *m = StreamInfo{} }
 func (m *StreamInfo) String() string { return proto.CompactTextString(m) }
 func (*StreamInfo) ProtoMessage()    {}
 func (*StreamInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{7}
+	return fileDescriptor_7333f0e9b622f7df, []int{8}
 }
 func (m *StreamInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -862,6 +1464,13 @@ func (m *StreamInfo) GetProto() string {
 	return ""
 }
 
+func (m *StreamInfo) GetShmSize() uint64 {
+	if m != nil && m.ShmSize != nil {
+		return *m.ShmSize
+	}
+	return 0
+}
+
 type DHTRequest struct {
 	Type                 *DHTRequest_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.DHTRequest_Type" json:"type,omitempty"`
 	Peer                 []byte           `protobuf:"bytes,2,opt,name=peer" json:"peer,omitempty"`
@@ -870,6 +1479,7 @@ type DHTRequest struct {
 	Value                []byte           `protobuf:"bytes,5,opt,name=value" json:"value,omitempty"`
 	Count                *int32           `protobuf:"varint,6,opt,name=count" json:"count,omitempty"`
 	Timeout              *int64           `protobuf:"varint,7,opt,name=timeout" json:"timeout,omitempty"`
+	TimeoutMillis        *int64           `protobuf:"varint,8,opt,name=timeoutMillis" json:"timeoutMillis,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -879,7 +1489,7 @@ func (m *DHTRequest) Reset()         { *m = DHTRequest{} }
 func (m *DHTRequest) String() string { return proto.CompactTextString(m) }
 func (*DHTRequest) ProtoMessage()    {}
 func (*DHTRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{8}
+	return fileDescriptor_7333f0e9b622f7df, []int{9}
 }
 func (m *DHTRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -957,10 +1567,18 @@ func (m *DHTRequest) GetTimeout() int64 {
 	return 0
 }
 
+func (m *DHTRequest) GetTimeoutMillis() int64 {
+	if m != nil && m.TimeoutMillis != nil {
+		return *m.TimeoutMillis
+	}
+	return 0
+}
+
 type DHTResponse struct {
 	Type                 *DHTResponse_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.DHTResponse_Type" json:"type,omitempty"`
 	Peer                 *PeerInfo         `protobuf:"bytes,2,opt,name=peer" json:"peer,omitempty"`
 	Value                []byte            `protobuf:"bytes,3,opt,name=value" json:"value,omitempty"`
+	Storage              *DHTStorageStats  `protobuf:"bytes,4,opt,name=storage" json:"storage,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -970,7 +1588,7 @@ func (m *DHTResponse) Reset()         { *m = DHTResponse{} }
 func (m *DHTResponse) String() string { return proto.CompactTextString(m) }
 func (*DHTResponse) ProtoMessage()    {}
 func (*DHTResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{9}
+	return fileDescriptor_7333f0e9b622f7df, []int{10}
 }
 func (m *DHTResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1020,6 +1638,79 @@ func (m *DHTResponse) GetValue() []byte {
 	return nil
 }
 
+func (m *DHTResponse) GetStorage() *DHTStorageStats {
+	if m != nil {
+		return m.Storage
+	}
+	return nil
+}
+
+type DHTStorageStats struct {
+	// records is the number of value records stored locally
+	Records *int64 `protobuf:"varint,1,req,name=records" json:"records,omitempty"`
+	// providers is the number of provider records stored locally
+	Providers *int64 `protobuf:"varint,2,req,name=providers" json:"providers,omitempty"`
+	// providedKeys is the number of keys the provider records are for
+	ProvidedKeys         *int64   `protobuf:"varint,3,req,name=providedKeys" json:"providedKeys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DHTStorageStats) Reset()         { *m = DHTStorageStats{} }
+func (m *DHTStorageStats) String() string { return proto.CompactTextString(m) }
+func (*DHTStorageStats) ProtoMessage()    {}
+func (*DHTStorageStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{11}
+}
+func (m *DHTStorageStats) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DHTStorageStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DHTStorageStats.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DHTStorageStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DHTStorageStats.Merge(m, src)
+}
+func (m *DHTStorageStats) XXX_Size() int {
+	return m.Size()
+}
+func (m *DHTStorageStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_DHTStorageStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DHTStorageStats proto.InternalMessageInfo
+
+func (m *DHTStorageStats) GetRecords() int64 {
+	if m != nil && m.Records != nil {
+		return *m.Records
+	}
+	return 0
+}
+
+func (m *DHTStorageStats) GetProviders() int64 {
+	if m != nil && m.Providers != nil {
+		return *m.Providers
+	}
+	return 0
+}
+
+func (m *DHTStorageStats) GetProvidedKeys() int64 {
+	if m != nil && m.ProvidedKeys != nil {
+		return *m.ProvidedKeys
+	}
+	return 0
+}
+
 type PeerInfo struct {
 	Id                   []byte   `protobuf:"bytes,1,req,name=id" json:"id,omitempty"`
 	Addrs                [][]byte `protobuf:"bytes,2,rep,name=addrs" json:"addrs,omitempty"`
@@ -1032,7 +1723,7 @@ func (m *PeerInfo) Reset()         { *m = PeerInfo{} }
 func (m *PeerInfo) String() string { return proto.CompactTextString(m) }
 func (*PeerInfo) ProtoMessage()    {}
 func (*PeerInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{10}
+	return fileDescriptor_7333f0e9b622f7df, []int{12}
 }
 func (m *PeerInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1089,7 +1780,7 @@ func (m *ConnManagerRequest) Reset()         { *m = ConnManagerRequest{} }
 func (m *ConnManagerRequest) String() string { return proto.CompactTextString(m) }
 func (*ConnManagerRequest) ProtoMessage()    {}
 func (*ConnManagerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{11}
+	return fileDescriptor_7333f0e9b622f7df, []int{13}
 }
 func (m *ConnManagerRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1146,25 +1837,28 @@ func (m *ConnManagerRequest) GetWeight() int64 {
 	return 0
 }
 
-type DisconnectRequest struct {
-	Peer                 []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+type ConnGaterRequest struct {
+	Type *ConnGaterRequest_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.ConnGaterRequest_Type" json:"type,omitempty"`
+	Peer []byte                 `protobuf:"bytes,2,opt,name=peer" json:"peer,omitempty"`
+	// subnet in CIDR notation, such as 10.0.0.0/8 or 2001:db8::/32
+	Subnet               *string  `protobuf:"bytes,3,opt,name=subnet" json:"subnet,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DisconnectRequest) Reset()         { *m = DisconnectRequest{} }
-func (m *DisconnectRequest) String() string { return proto.CompactTextString(m) }
-func (*DisconnectRequest) ProtoMessage()    {}
-func (*DisconnectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{12}
+func (m *ConnGaterRequest) Reset()         { *m = ConnGaterRequest{} }
+func (m *ConnGaterRequest) String() string { return proto.CompactTextString(m) }
+func (*ConnGaterRequest) ProtoMessage()    {}
+func (*ConnGaterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{14}
 }
-func (m *DisconnectRequest) XXX_Unmarshal(b []byte) error {
+func (m *ConnGaterRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DisconnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ConnGaterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DisconnectRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ConnGaterRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1174,46 +1868,60 @@ func (m *DisconnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *DisconnectRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DisconnectRequest.Merge(m, src)
+func (m *ConnGaterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnGaterRequest.Merge(m, src)
 }
-func (m *DisconnectRequest) XXX_Size() int {
+func (m *ConnGaterRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *DisconnectRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DisconnectRequest.DiscardUnknown(m)
+func (m *ConnGaterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnGaterRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DisconnectRequest proto.InternalMessageInfo
+var xxx_messageInfo_ConnGaterRequest proto.InternalMessageInfo
 
-func (m *DisconnectRequest) GetPeer() []byte {
+func (m *ConnGaterRequest) GetType() ConnGaterRequest_Type {
+	if m != nil && m.Type != nil {
+		return *m.Type
+	}
+	return ConnGaterRequest_BLOCK_PEER
+}
+
+func (m *ConnGaterRequest) GetPeer() []byte {
 	if m != nil {
 		return m.Peer
 	}
 	return nil
 }
 
-type PSRequest struct {
-	Type                 *PSRequest_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.PSRequest_Type" json:"type,omitempty"`
-	Topic                *string         `protobuf:"bytes,2,opt,name=topic" json:"topic,omitempty"`
-	Data                 []byte          `protobuf:"bytes,3,opt,name=data" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+func (m *ConnGaterRequest) GetSubnet() string {
+	if m != nil && m.Subnet != nil {
+		return *m.Subnet
+	}
+	return ""
 }
 
-func (m *PSRequest) Reset()         { *m = PSRequest{} }
-func (m *PSRequest) String() string { return proto.CompactTextString(m) }
-func (*PSRequest) ProtoMessage()    {}
-func (*PSRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{13}
+type ConnGaterResponse struct {
+	BlockedPeers         [][]byte `protobuf:"bytes,1,rep,name=blockedPeers" json:"blockedPeers,omitempty"`
+	BlockedSubnets       []string `protobuf:"bytes,2,rep,name=blockedSubnets" json:"blockedSubnets,omitempty"`
+	AllowedPeers         [][]byte `protobuf:"bytes,3,rep,name=allowedPeers" json:"allowedPeers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *PSRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *ConnGaterResponse) Reset()         { *m = ConnGaterResponse{} }
+func (m *ConnGaterResponse) String() string { return proto.CompactTextString(m) }
+func (*ConnGaterResponse) ProtoMessage()    {}
+func (*ConnGaterResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{15}
+}
+func (m *ConnGaterResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PSRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ConnGaterResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PSRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ConnGaterResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1223,63 +1931,61 @@ func (m *PSRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *PSRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PSRequest.Merge(m, src)
+func (m *ConnGaterResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnGaterResponse.Merge(m, src)
 }
-func (m *PSRequest) XXX_Size() int {
+func (m *ConnGaterResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *PSRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_PSRequest.DiscardUnknown(m)
+func (m *ConnGaterResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnGaterResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PSRequest proto.InternalMessageInfo
+var xxx_messageInfo_ConnGaterResponse proto.InternalMessageInfo
 
-func (m *PSRequest) GetType() PSRequest_Type {
-	if m != nil && m.Type != nil {
-		return *m.Type
+func (m *ConnGaterResponse) GetBlockedPeers() [][]byte {
+	if m != nil {
+		return m.BlockedPeers
 	}
-	return PSRequest_GET_TOPICS
+	return nil
 }
 
-func (m *PSRequest) GetTopic() string {
-	if m != nil && m.Topic != nil {
-		return *m.Topic
+func (m *ConnGaterResponse) GetBlockedSubnets() []string {
+	if m != nil {
+		return m.BlockedSubnets
 	}
-	return ""
+	return nil
 }
 
-func (m *PSRequest) GetData() []byte {
+func (m *ConnGaterResponse) GetAllowedPeers() [][]byte {
 	if m != nil {
-		return m.Data
+		return m.AllowedPeers
 	}
 	return nil
 }
 
-type PSMessage struct {
-	From                 []byte   `protobuf:"bytes,1,opt,name=from" json:"from,omitempty"`
-	Data                 []byte   `protobuf:"bytes,2,opt,name=data" json:"data,omitempty"`
-	Seqno                []byte   `protobuf:"bytes,3,opt,name=seqno" json:"seqno,omitempty"`
-	TopicIDs             []string `protobuf:"bytes,4,rep,name=topicIDs" json:"topicIDs,omitempty"`
-	Signature            []byte   `protobuf:"bytes,5,opt,name=signature" json:"signature,omitempty"`
-	Key                  []byte   `protobuf:"bytes,6,opt,name=key" json:"key,omitempty"`
+type SignRequest struct {
+	Data []byte `protobuf:"bytes,1,req,name=data" json:"data,omitempty"`
+	// domain, if set, separates the signatures of data for different
+	// purposes; it is signed, length-prefixed, ahead of data
+	Domain               *string  `protobuf:"bytes,2,opt,name=domain" json:"domain,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PSMessage) Reset()         { *m = PSMessage{} }
-func (m *PSMessage) String() string { return proto.CompactTextString(m) }
-func (*PSMessage) ProtoMessage()    {}
-func (*PSMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{14}
+func (m *SignRequest) Reset()         { *m = SignRequest{} }
+func (m *SignRequest) String() string { return proto.CompactTextString(m) }
+func (*SignRequest) ProtoMessage()    {}
+func (*SignRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{16}
 }
-func (m *PSMessage) XXX_Unmarshal(b []byte) error {
+func (m *SignRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PSMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SignRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PSMessage.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SignRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1289,80 +1995,113 @@ func (m *PSMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *PSMessage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PSMessage.Merge(m, src)
+func (m *SignRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignRequest.Merge(m, src)
 }
-func (m *PSMessage) XXX_Size() int {
+func (m *SignRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *PSMessage) XXX_DiscardUnknown() {
-	xxx_messageInfo_PSMessage.DiscardUnknown(m)
+func (m *SignRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PSMessage proto.InternalMessageInfo
+var xxx_messageInfo_SignRequest proto.InternalMessageInfo
 
-func (m *PSMessage) GetFrom() []byte {
+func (m *SignRequest) GetData() []byte {
 	if m != nil {
-		return m.From
+		return m.Data
 	}
 	return nil
 }
 
-func (m *PSMessage) GetData() []byte {
-	if m != nil {
-		return m.Data
+func (m *SignRequest) GetDomain() string {
+	if m != nil && m.Domain != nil {
+		return *m.Domain
 	}
-	return nil
+	return ""
 }
 
-func (m *PSMessage) GetSeqno() []byte {
-	if m != nil {
-		return m.Seqno
-	}
-	return nil
+type SignResponse struct {
+	Signature []byte `protobuf:"bytes,1,req,name=signature" json:"signature,omitempty"`
+	// the daemon's marshaled public key
+	PublicKey            []byte   `protobuf:"bytes,2,req,name=publicKey" json:"publicKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PSMessage) GetTopicIDs() []string {
-	if m != nil {
-		return m.TopicIDs
+func (m *SignResponse) Reset()         { *m = SignResponse{} }
+func (m *SignResponse) String() string { return proto.CompactTextString(m) }
+func (*SignResponse) ProtoMessage()    {}
+func (*SignResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{17}
+}
+func (m *SignResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
+}
+func (m *SignResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignResponse.Merge(m, src)
+}
+func (m *SignResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignResponse.DiscardUnknown(m)
 }
 
-func (m *PSMessage) GetSignature() []byte {
+var xxx_messageInfo_SignResponse proto.InternalMessageInfo
+
+func (m *SignResponse) GetSignature() []byte {
 	if m != nil {
 		return m.Signature
 	}
 	return nil
 }
 
-func (m *PSMessage) GetKey() []byte {
+func (m *SignResponse) GetPublicKey() []byte {
 	if m != nil {
-		return m.Key
+		return m.PublicKey
 	}
 	return nil
 }
 
-type PSResponse struct {
-	Topics               []string `protobuf:"bytes,1,rep,name=topics" json:"topics,omitempty"`
-	PeerIDs              [][]byte `protobuf:"bytes,2,rep,name=peerIDs" json:"peerIDs,omitempty"`
+type VerifyRequest struct {
+	Data      []byte  `protobuf:"bytes,1,req,name=data" json:"data,omitempty"`
+	Signature []byte  `protobuf:"bytes,2,req,name=signature" json:"signature,omitempty"`
+	Domain    *string `protobuf:"bytes,3,opt,name=domain" json:"domain,omitempty"`
+	// the signer is given by its peer ID, if its public key can be found from
+	// it, or by its marshaled public key
+	Peer                 []byte   `protobuf:"bytes,4,opt,name=peer" json:"peer,omitempty"`
+	PublicKey            []byte   `protobuf:"bytes,5,opt,name=publicKey" json:"publicKey,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PSResponse) Reset()         { *m = PSResponse{} }
-func (m *PSResponse) String() string { return proto.CompactTextString(m) }
-func (*PSResponse) ProtoMessage()    {}
-func (*PSResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_7333f0e9b622f7df, []int{15}
+func (m *VerifyRequest) Reset()         { *m = VerifyRequest{} }
+func (m *VerifyRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyRequest) ProtoMessage()    {}
+func (*VerifyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{18}
 }
-func (m *PSResponse) XXX_Unmarshal(b []byte) error {
+func (m *VerifyRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *PSResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *VerifyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_PSResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_VerifyRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1372,1508 +2111,12328 @@ func (m *PSResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *PSResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PSResponse.Merge(m, src)
+func (m *VerifyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyRequest.Merge(m, src)
 }
-func (m *PSResponse) XXX_Size() int {
+func (m *VerifyRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *PSResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_PSResponse.DiscardUnknown(m)
+func (m *VerifyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PSResponse proto.InternalMessageInfo
+var xxx_messageInfo_VerifyRequest proto.InternalMessageInfo
 
-func (m *PSResponse) GetTopics() []string {
+func (m *VerifyRequest) GetData() []byte {
 	if m != nil {
-		return m.Topics
+		return m.Data
 	}
 	return nil
 }
 
-func (m *PSResponse) GetPeerIDs() [][]byte {
+func (m *VerifyRequest) GetSignature() []byte {
 	if m != nil {
-		return m.PeerIDs
+		return m.Signature
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("p2pd.pb.Request_Type", Request_Type_name, Request_Type_value)
-	proto.RegisterEnum("p2pd.pb.Response_Type", Response_Type_name, Response_Type_value)
-	proto.RegisterEnum("p2pd.pb.DHTRequest_Type", DHTRequest_Type_name, DHTRequest_Type_value)
-	proto.RegisterEnum("p2pd.pb.DHTResponse_Type", DHTResponse_Type_name, DHTResponse_Type_value)
-	proto.RegisterEnum("p2pd.pb.ConnManagerRequest_Type", ConnManagerRequest_Type_name, ConnManagerRequest_Type_value)
-	proto.RegisterEnum("p2pd.pb.PSRequest_Type", PSRequest_Type_name, PSRequest_Type_value)
-	proto.RegisterType((*Request)(nil), "p2pd.pb.Request")
-	proto.RegisterType((*Response)(nil), "p2pd.pb.Response")
-	proto.RegisterType((*IdentifyResponse)(nil), "p2pd.pb.IdentifyResponse")
-	proto.RegisterType((*ConnectRequest)(nil), "p2pd.pb.ConnectRequest")
-	proto.RegisterType((*StreamOpenRequest)(nil), "p2pd.pb.StreamOpenRequest")
-	proto.RegisterType((*StreamHandlerRequest)(nil), "p2pd.pb.StreamHandlerRequest")
-	proto.RegisterType((*ErrorResponse)(nil), "p2pd.pb.ErrorResponse")
-	proto.RegisterType((*StreamInfo)(nil), "p2pd.pb.StreamInfo")
-	proto.RegisterType((*DHTRequest)(nil), "p2pd.pb.DHTRequest")
-	proto.RegisterType((*DHTResponse)(nil), "p2pd.pb.DHTResponse")
-	proto.RegisterType((*PeerInfo)(nil), "p2pd.pb.PeerInfo")
-	proto.RegisterType((*ConnManagerRequest)(nil), "p2pd.pb.ConnManagerRequest")
-	proto.RegisterType((*DisconnectRequest)(nil), "p2pd.pb.DisconnectRequest")
-	proto.RegisterType((*PSRequest)(nil), "p2pd.pb.PSRequest")
-	proto.RegisterType((*PSMessage)(nil), "p2pd.pb.PSMessage")
-	proto.RegisterType((*PSResponse)(nil), "p2pd.pb.PSResponse")
+func (m *VerifyRequest) GetDomain() string {
+	if m != nil && m.Domain != nil {
+		return *m.Domain
+	}
+	return ""
 }
 
-func init() { proto.RegisterFile("p2pd.proto", fileDescriptor_7333f0e9b622f7df) }
-
-var fileDescriptor_7333f0e9b622f7df = []byte{
-	// 1123 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x41, 0x8f, 0xdb, 0x44,
-	0x14, 0xae, 0xed, 0x24, 0x4e, 0x5e, 0xb2, 0x61, 0x76, 0xba, 0x6d, 0xbd, 0xa5, 0xac, 0x82, 0xa5,
-	0xa5, 0x4b, 0x5b, 0x56, 0xb0, 0x80, 0x84, 0x90, 0x40, 0x24, 0xb1, 0xbb, 0x31, 0xdd, 0x4d, 0xa2,
-	0xb1, 0x53, 0xd4, 0x53, 0xe4, 0x5d, 0xcf, 0xa6, 0x11, 0x5d, 0x3b, 0xb5, 0x1d, 0xd0, 0xfe, 0x07,
-	0xae, 0xdc, 0x39, 0xf1, 0x07, 0xb8, 0xf0, 0x07, 0x90, 0x38, 0xf2, 0x13, 0xd0, 0xde, 0xb8, 0xf0,
-	0x1b, 0xd0, 0x8c, 0x67, 0x1c, 0x3b, 0x1b, 0xaa, 0xde, 0xe6, 0xcd, 0x7c, 0xdf, 0x7b, 0x6f, 0xde,
-	0x7c, 0xef, 0x0d, 0xc0, 0xe2, 0x68, 0x11, 0x1c, 0x2e, 0xe2, 0x28, 0x8d, 0xb0, 0x9e, 0xad, 0xcf,
-	0xcc, 0x3f, 0x2a, 0xa0, 0x13, 0xfa, 0x7a, 0x49, 0x93, 0x14, 0x7f, 0x08, 0x95, 0xf4, 0x6a, 0x41,
-	0x0d, 0xa5, 0xa3, 0x1e, 0xb4, 0x8f, 0xee, 0x1c, 0x0a, 0xcc, 0xa1, 0x38, 0x3f, 0xf4, 0xae, 0x16,
-	0x94, 0x70, 0x08, 0xfe, 0x04, 0xf4, 0xf3, 0x28, 0x0c, 0xe9, 0x79, 0x6a, 0xa8, 0x1d, 0xe5, 0xa0,
-	0x79, 0x74, 0x2f, 0x47, 0xf7, 0xb3, 0x7d, 0x41, 0x22, 0x12, 0x87, 0xbf, 0x04, 0x48, 0xd2, 0x98,
-	0xfa, 0x97, 0xa3, 0x05, 0x0d, 0x0d, 0x8d, 0xb3, 0xee, 0xe7, 0x2c, 0x37, 0x3f, 0x92, 0xc4, 0x02,
-	0x1a, 0xf7, 0x61, 0x2b, 0xb3, 0x06, 0x7e, 0x18, 0xbc, 0xa2, 0xb1, 0x51, 0xe1, 0xf4, 0xf7, 0xd6,
-	0xe8, 0xe2, 0x54, 0x7a, 0x28, 0x73, 0xf0, 0x3e, 0x68, 0xc1, 0xcb, 0xd4, 0xa8, 0x72, 0xea, 0xed,
-	0x9c, 0x6a, 0x0d, 0x3c, 0x49, 0x60, 0xe7, 0xf8, 0x2b, 0x68, 0xb2, 0x94, 0x4f, 0xfd, 0xd0, 0x9f,
-	0xd1, 0xd8, 0xa8, 0x71, 0xf8, 0xbb, 0xa5, 0xeb, 0x89, 0x33, 0x49, 0x2b, 0xe2, 0xd9, 0x35, 0x83,
-	0x79, 0x22, 0x8b, 0xa3, 0xaf, 0x5d, 0xd3, 0xca, 0x8f, 0xf2, 0x6b, 0xae, 0xd0, 0xf8, 0x11, 0xd4,
-	0x16, 0xcb, 0xb3, 0x64, 0x79, 0x66, 0xd4, 0x39, 0x0f, 0xe7, 0xbc, 0xb1, 0x2b, 0xf1, 0x02, 0x61,
-	0xfe, 0xa4, 0x40, 0x85, 0x3d, 0x08, 0x6e, 0x41, 0xdd, 0xb1, 0xec, 0xa1, 0xe7, 0x3c, 0x7d, 0x81,
-	0x6e, 0xe1, 0x26, 0xe8, 0xfd, 0xd1, 0x70, 0x68, 0xf7, 0x3d, 0xa4, 0xe0, 0x77, 0xa0, 0xe9, 0x7a,
-	0xc4, 0xee, 0x9e, 0x4e, 0x47, 0x63, 0x7b, 0x88, 0x54, 0x8c, 0xa1, 0x2d, 0x36, 0x06, 0xdd, 0xa1,
-	0x75, 0x62, 0x13, 0xa4, 0x61, 0x1d, 0x34, 0x6b, 0xe0, 0xa1, 0x0a, 0x6e, 0x03, 0x9c, 0x38, 0xae,
-	0x37, 0x1d, 0xdb, 0x36, 0x71, 0x51, 0x95, 0xb1, 0x99, 0xab, 0xd3, 0xee, 0xb0, 0x7b, 0x6c, 0x13,
-	0x54, 0x63, 0x00, 0xcb, 0x71, 0xa5, 0x7b, 0x1d, 0x03, 0xd4, 0xc6, 0x93, 0x9e, 0x3b, 0xe9, 0xa1,
-	0xba, 0xf9, 0x8f, 0x0a, 0x75, 0x42, 0x93, 0x45, 0x14, 0x26, 0x14, 0x3f, 0x2a, 0x09, 0xe9, 0x6e,
-	0x41, 0x48, 0x19, 0xa0, 0xa8, 0xa4, 0x27, 0x50, 0xa5, 0x71, 0x1c, 0xc5, 0x42, 0x47, 0x2b, 0xb0,
-	0xcd, 0x76, 0x25, 0x83, 0x64, 0x20, 0xfc, 0xa9, 0x14, 0x91, 0x13, 0x5e, 0x44, 0x42, 0x44, 0xb7,
-	0xd7, 0x54, 0xc0, 0x8e, 0x48, 0x01, 0x86, 0x3f, 0x87, 0xfa, 0x3c, 0xa0, 0x61, 0x3a, 0xbf, 0xb8,
-	0x12, 0xc2, 0xd9, 0xcd, 0x29, 0x8e, 0x38, 0xc8, 0x03, 0xe5, 0x50, 0xfc, 0x41, 0x51, 0x2f, 0x3b,
-	0x65, 0xbd, 0x08, 0x30, 0x17, 0xcc, 0x43, 0xa8, 0x2e, 0x28, 0x8d, 0x13, 0xa3, 0xd6, 0xd1, 0x0e,
-	0x9a, 0x47, 0xdb, 0xab, 0x47, 0xa3, 0x34, 0xe6, 0xc9, 0x64, 0xe7, 0xf8, 0x71, 0xfe, 0xbc, 0xfa,
-	0x5a, 0xe2, 0xec, 0x79, 0x85, 0x4b, 0xf9, 0xbe, 0xbb, 0xe2, 0x79, 0x6b, 0xa0, 0x8e, 0x9e, 0xa1,
-	0x5b, 0xb8, 0x01, 0x55, 0x9b, 0x90, 0x11, 0x41, 0x8a, 0xf9, 0x05, 0xa0, 0xf5, 0xb4, 0x71, 0x1b,
-	0xd4, 0x79, 0xc0, 0x0b, 0xde, 0x22, 0xea, 0x3c, 0xc0, 0x3b, 0x50, 0xf5, 0x83, 0x20, 0x4e, 0x0c,
-	0xb5, 0xa3, 0x1d, 0xb4, 0x48, 0x66, 0x98, 0x1e, 0xb4, 0xcb, 0xed, 0x89, 0x31, 0x54, 0x58, 0x72,
-	0x82, 0xc9, 0xd7, 0x9b, 0xb9, 0xd8, 0x00, 0x3d, 0x9d, 0x5f, 0xd2, 0x68, 0x99, 0xf2, 0xba, 0x6b,
-	0x44, 0x9a, 0xe6, 0x77, 0xb0, 0x7d, 0xa3, 0x7d, 0xff, 0xcf, 0x31, 0x1f, 0x3f, 0xdc, 0x71, 0x83,
-	0x64, 0xc6, 0x1b, 0x1c, 0x7f, 0x03, 0x3b, 0x9b, 0x1a, 0x9b, 0xf9, 0x66, 0x39, 0x49, 0xdf, 0x6c,
-	0xbd, 0xd9, 0xb7, 0xf9, 0x3e, 0x6c, 0x95, 0x74, 0x84, 0x11, 0x68, 0x97, 0xc9, 0x8c, 0x33, 0x1b,
-	0x84, 0x2d, 0xcd, 0x6f, 0x01, 0x56, 0xba, 0xd9, 0x98, 0xb6, 0x0c, 0xa7, 0x6e, 0x0a, 0xa7, 0x71,
-	0x4f, 0x22, 0xdc, 0xbf, 0x2a, 0xc0, 0x6a, 0x9e, 0xe0, 0x27, 0xa5, 0x3e, 0x30, 0x36, 0x8c, 0x9c,
-	0x62, 0x27, 0xc8, 0xd0, 0xac, 0x11, 0x64, 0x68, 0x04, 0xda, 0xf9, 0x3c, 0xe0, 0x75, 0x69, 0x11,
-	0xb6, 0x64, 0x3b, 0xdf, 0xd3, 0x4c, 0xc7, 0x2d, 0xc2, 0x96, 0x2c, 0x95, 0x1f, 0xfc, 0x57, 0x4b,
-	0xca, 0x95, 0xda, 0x22, 0x99, 0xc1, 0x76, 0xcf, 0xa3, 0x65, 0x98, 0xf2, 0x01, 0x56, 0x25, 0x99,
-	0x51, 0xac, 0xb5, 0x5e, 0xae, 0xf5, 0x6f, 0x72, 0x9e, 0x6c, 0x41, 0xe3, 0xa9, 0x33, 0xb4, 0xf8,
-	0x18, 0x40, 0xb7, 0x70, 0x07, 0x1e, 0xe4, 0xa6, 0x3b, 0x15, 0xcd, 0x6f, 0x5b, 0x53, 0x6f, 0x94,
-	0x21, 0x14, 0x36, 0x54, 0x32, 0x04, 0x19, 0x3d, 0x77, 0x2c, 0x36, 0x3b, 0x54, 0x7c, 0x07, 0xb6,
-	0x8f, 0x6d, 0x6f, 0xda, 0x3f, 0x19, 0xb9, 0x76, 0x3e, 0x52, 0x34, 0x06, 0x65, 0xdb, 0xe3, 0x49,
-	0xef, 0xc4, 0xe9, 0x4f, 0x9f, 0xd9, 0x2f, 0x50, 0x85, 0xc5, 0x63, 0x7b, 0xcf, 0xbb, 0x27, 0x13,
-	0x1b, 0x55, 0x31, 0x82, 0x96, 0x6b, 0x77, 0x49, 0x7f, 0x20, 0x76, 0x6a, 0x0c, 0x30, 0x9e, 0x48,
-	0x80, 0xce, 0x26, 0x9c, 0x88, 0x84, 0xea, 0xe6, 0x2f, 0x0a, 0x34, 0x0b, 0x0d, 0x89, 0x3f, 0x2a,
-	0x55, 0x7c, 0x77, 0x53, 0xd3, 0x16, 0x4b, 0xbe, 0x5f, 0x28, 0xf9, 0xc6, 0xce, 0xcd, 0x75, 0x9b,
-	0x55, 0x58, 0x2b, 0x54, 0xd8, 0xdc, 0x17, 0x05, 0x6b, 0x40, 0xb5, 0x67, 0x1f, 0x3b, 0xc3, 0xac,
-	0x49, 0xb3, 0x34, 0x15, 0x36, 0x56, 0xed, 0xa1, 0x85, 0x54, 0xf3, 0x63, 0xa8, 0x4b, 0x77, 0x6f,
-	0xd9, 0xa5, 0xbf, 0x2b, 0x80, 0x6f, 0x7e, 0x33, 0xf8, 0xb3, 0xd2, 0xdd, 0x3a, 0x6f, 0xf8, 0x91,
-	0xde, 0x42, 0x55, 0xa9, 0x3f, 0xe3, 0xb7, 0x69, 0x10, 0xb6, 0xc4, 0x77, 0xa1, 0xf6, 0x23, 0x9d,
-	0xcf, 0x5e, 0xa6, 0x5c, 0x58, 0x1a, 0x11, 0x96, 0x79, 0xb8, 0xfa, 0x64, 0xbc, 0xee, 0xb1, 0xd4,
-	0x44, 0x1b, 0x60, 0x32, 0xcc, 0x6d, 0x05, 0xd7, 0xa1, 0xe2, 0x11, 0xe7, 0x14, 0xa9, 0xe6, 0x43,
-	0xd8, 0xbe, 0xf1, 0xc5, 0x6d, 0xea, 0x29, 0xf3, 0x57, 0x05, 0x1a, 0xf9, 0xa7, 0x86, 0x1f, 0x97,
-	0xae, 0x76, 0xef, 0xe6, 0xb7, 0x57, 0xbc, 0xd1, 0x0e, 0x54, 0xd3, 0x68, 0x31, 0x3f, 0xe7, 0x57,
-	0x6a, 0x90, 0xcc, 0x60, 0x41, 0x02, 0x3f, 0xf5, 0xc5, 0x13, 0xf1, 0xb5, 0xd9, 0x13, 0xd9, 0xb7,
-	0x01, 0x98, 0xc4, 0xbc, 0xd1, 0xd8, 0xe9, 0xbb, 0x59, 0xfe, 0x85, 0x9f, 0x4e, 0xe1, 0x92, 0x62,
-	0x92, 0x74, 0x07, 0x48, 0x65, 0x72, 0x73, 0x27, 0x3d, 0xb7, 0x4f, 0x9c, 0x9e, 0x8d, 0x34, 0xf3,
-	0x67, 0x9e, 0xe8, 0x29, 0x4d, 0x12, 0x7f, 0xc6, 0xab, 0x79, 0x11, 0x47, 0x97, 0x86, 0x92, 0x45,
-	0x61, 0xeb, 0x3c, 0xb2, 0xba, 0x8a, 0xcc, 0x72, 0x4c, 0xe8, 0xeb, 0x30, 0x92, 0x8a, 0xe1, 0x06,
-	0xbe, 0x0f, 0x75, 0x9e, 0xac, 0x63, 0x25, 0x46, 0x85, 0x8f, 0xa9, 0xdc, 0xc6, 0x0f, 0xa0, 0x91,
-	0xcc, 0x67, 0xa1, 0x9f, 0x2e, 0x63, 0xd9, 0xc9, 0xab, 0x0d, 0xd9, 0xf5, 0xb5, 0xbc, 0xeb, 0xcd,
-	0xaf, 0x01, 0x56, 0xbf, 0x06, 0x7b, 0x3f, 0xee, 0x29, 0x31, 0x14, 0xee, 0x57, 0x58, 0xac, 0xdf,
-	0x59, 0xb9, 0x59, 0xc0, 0x4c, 0x62, 0xd2, 0xec, 0xb5, 0xfe, 0xbc, 0xde, 0x53, 0xfe, 0xba, 0xde,
-	0x53, 0xfe, 0xbe, 0xde, 0x53, 0xfe, 0x0b, 0x00, 0x00, 0xff, 0xff, 0x7d, 0x4e, 0x88, 0x0e, 0x1c,
-	0x0a, 0x00, 0x00,
+func (m *VerifyRequest) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
+	}
+	return nil
 }
 
-func (m *Request) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *VerifyRequest) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Request) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type VerifyResponse struct {
+	Valid                *bool    `protobuf:"varint,1,req,name=valid" json:"valid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Request) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Pubsub != nil {
-		{
-			size, err := m.Pubsub.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x42
-	}
-	if m.Disconnect != nil {
-		{
-			size, err := m.Disconnect.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+func (m *VerifyResponse) Reset()         { *m = VerifyResponse{} }
+func (m *VerifyResponse) String() string { return proto.CompactTextString(m) }
+func (*VerifyResponse) ProtoMessage()    {}
+func (*VerifyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{19}
+}
+func (m *VerifyResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VerifyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VerifyResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x3a
+		return b[:n], nil
 	}
-	if m.ConnManager != nil {
-		{
-			size, err := m.ConnManager.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x32
+}
+func (m *VerifyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyResponse.Merge(m, src)
+}
+func (m *VerifyResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *VerifyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyResponse proto.InternalMessageInfo
+
+func (m *VerifyResponse) GetValid() bool {
+	if m != nil && m.Valid != nil {
+		return *m.Valid
 	}
-	if m.Dht != nil {
-		{
-			size, err := m.Dht.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+	return false
+}
+
+type SealEnvelopeRequest struct {
+	Domain               *string  `protobuf:"bytes,1,req,name=domain" json:"domain,omitempty"`
+	PayloadType          []byte   `protobuf:"bytes,2,req,name=payloadType" json:"payloadType,omitempty"`
+	Payload              []byte   `protobuf:"bytes,3,req,name=payload" json:"payload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SealEnvelopeRequest) Reset()         { *m = SealEnvelopeRequest{} }
+func (m *SealEnvelopeRequest) String() string { return proto.CompactTextString(m) }
+func (*SealEnvelopeRequest) ProtoMessage()    {}
+func (*SealEnvelopeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{20}
+}
+func (m *SealEnvelopeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SealEnvelopeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SealEnvelopeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x2a
+		return b[:n], nil
 	}
-	if m.StreamHandler != nil {
-		{
-			size, err := m.StreamHandler.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
+}
+func (m *SealEnvelopeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SealEnvelopeRequest.Merge(m, src)
+}
+func (m *SealEnvelopeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SealEnvelopeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SealEnvelopeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SealEnvelopeRequest proto.InternalMessageInfo
+
+func (m *SealEnvelopeRequest) GetDomain() string {
+	if m != nil && m.Domain != nil {
+		return *m.Domain
 	}
-	if m.StreamOpen != nil {
-		{
-			size, err := m.StreamOpen.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+	return ""
+}
+
+func (m *SealEnvelopeRequest) GetPayloadType() []byte {
+	if m != nil {
+		return m.PayloadType
 	}
-	if m.Connect != nil {
-		{
-			size, err := m.Connect.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	return nil
+}
+
+func (m *SealEnvelopeRequest) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
 	}
-	if m.Type == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	return nil
+}
+
+type SealEnvelopeResponse struct {
+	// the marshaled envelope
+	Envelope             []byte   `protobuf:"bytes,1,req,name=envelope" json:"envelope,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SealEnvelopeResponse) Reset()         { *m = SealEnvelopeResponse{} }
+func (m *SealEnvelopeResponse) String() string { return proto.CompactTextString(m) }
+func (*SealEnvelopeResponse) ProtoMessage()    {}
+func (*SealEnvelopeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{21}
+}
+func (m *SealEnvelopeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SealEnvelopeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SealEnvelopeResponse.Marshal(b, m, deterministic)
 	} else {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
-		i--
-		dAtA[i] = 0x8
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *SealEnvelopeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SealEnvelopeResponse.Merge(m, src)
+}
+func (m *SealEnvelopeResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SealEnvelopeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SealEnvelopeResponse.DiscardUnknown(m)
 }
 
-func (m *Response) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_SealEnvelopeResponse proto.InternalMessageInfo
+
+func (m *SealEnvelopeResponse) GetEnvelope() []byte {
+	if m != nil {
+		return m.Envelope
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Response) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type OpenEnvelopeRequest struct {
+	Envelope             []byte   `protobuf:"bytes,1,req,name=envelope" json:"envelope,omitempty"`
+	Domain               *string  `protobuf:"bytes,2,req,name=domain" json:"domain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Pubsub != nil {
-		{
-			size, err := m.Pubsub.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+func (m *OpenEnvelopeRequest) Reset()         { *m = OpenEnvelopeRequest{} }
+func (m *OpenEnvelopeRequest) String() string { return proto.CompactTextString(m) }
+func (*OpenEnvelopeRequest) ProtoMessage()    {}
+func (*OpenEnvelopeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{22}
+}
+func (m *OpenEnvelopeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OpenEnvelopeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OpenEnvelopeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x3a
+		return b[:n], nil
 	}
-	if len(m.Peers) > 0 {
-		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintP2Pd(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x32
-		}
+}
+func (m *OpenEnvelopeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpenEnvelopeRequest.Merge(m, src)
+}
+func (m *OpenEnvelopeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *OpenEnvelopeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpenEnvelopeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OpenEnvelopeRequest proto.InternalMessageInfo
+
+func (m *OpenEnvelopeRequest) GetEnvelope() []byte {
+	if m != nil {
+		return m.Envelope
 	}
-	if m.Dht != nil {
-		{
-			size, err := m.Dht.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x2a
+	return nil
+}
+
+func (m *OpenEnvelopeRequest) GetDomain() string {
+	if m != nil && m.Domain != nil {
+		return *m.Domain
 	}
-	if m.Identify != nil {
-		{
-			size, err := m.Identify.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+	return ""
+}
+
+type OpenEnvelopeResponse struct {
+	Peer                 []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	PublicKey            []byte   `protobuf:"bytes,2,req,name=publicKey" json:"publicKey,omitempty"`
+	PayloadType          []byte   `protobuf:"bytes,3,req,name=payloadType" json:"payloadType,omitempty"`
+	Payload              []byte   `protobuf:"bytes,4,req,name=payload" json:"payload,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OpenEnvelopeResponse) Reset()         { *m = OpenEnvelopeResponse{} }
+func (m *OpenEnvelopeResponse) String() string { return proto.CompactTextString(m) }
+func (*OpenEnvelopeResponse) ProtoMessage()    {}
+func (*OpenEnvelopeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{23}
+}
+func (m *OpenEnvelopeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *OpenEnvelopeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_OpenEnvelopeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x22
+		return b[:n], nil
 	}
-	if m.StreamInfo != nil {
-		{
-			size, err := m.StreamInfo.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+}
+func (m *OpenEnvelopeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OpenEnvelopeResponse.Merge(m, src)
+}
+func (m *OpenEnvelopeResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *OpenEnvelopeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_OpenEnvelopeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OpenEnvelopeResponse proto.InternalMessageInfo
+
+func (m *OpenEnvelopeResponse) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	if m.Error != nil {
-		{
-			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+	return nil
+}
+
+func (m *OpenEnvelopeResponse) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
 	}
-	if m.Type == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
-	} else {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
-		i--
-		dAtA[i] = 0x8
+	return nil
+}
+
+func (m *OpenEnvelopeResponse) GetPayloadType() []byte {
+	if m != nil {
+		return m.PayloadType
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *IdentifyResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *OpenEnvelopeResponse) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *IdentifyResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type PeerRecordResponse struct {
+	// the marshaled envelope of the daemon's signed peer record
+	Envelope             []byte   `protobuf:"bytes,1,req,name=envelope" json:"envelope,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *IdentifyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Addrs) > 0 {
-		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Addrs[iNdEx])
-			copy(dAtA[i:], m.Addrs[iNdEx])
-			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	if m.Id == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+func (m *PeerRecordResponse) Reset()         { *m = PeerRecordResponse{} }
+func (m *PeerRecordResponse) String() string { return proto.CompactTextString(m) }
+func (*PeerRecordResponse) ProtoMessage()    {}
+func (*PeerRecordResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{24}
+}
+func (m *PeerRecordResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PeerRecordResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PeerRecordResponse.Marshal(b, m, deterministic)
 	} else {
-		i -= len(m.Id)
-		copy(dAtA[i:], m.Id)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Id)))
-		i--
-		dAtA[i] = 0xa
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *PeerRecordResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PeerRecordResponse.Merge(m, src)
+}
+func (m *PeerRecordResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PeerRecordResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PeerRecordResponse.DiscardUnknown(m)
 }
 
-func (m *ConnectRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_PeerRecordResponse proto.InternalMessageInfo
+
+func (m *PeerRecordResponse) GetEnvelope() []byte {
+	if m != nil {
+		return m.Envelope
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ConnectRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type AddPeerRecordRequest struct {
+	// the marshaled envelope of a peer's signed peer record
+	Envelope []byte `protobuf:"bytes,1,req,name=envelope" json:"envelope,omitempty"`
+	// how long the addresses in the record are kept, in seconds
+	Ttl                  *int64   `protobuf:"varint,2,opt,name=ttl" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ConnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Timeout != nil {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Addrs) > 0 {
-		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Addrs[iNdEx])
-			copy(dAtA[i:], m.Addrs[iNdEx])
-			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
-			i--
-			dAtA[i] = 0x12
+func (m *AddPeerRecordRequest) Reset()         { *m = AddPeerRecordRequest{} }
+func (m *AddPeerRecordRequest) String() string { return proto.CompactTextString(m) }
+func (*AddPeerRecordRequest) ProtoMessage()    {}
+func (*AddPeerRecordRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{25}
+}
+func (m *AddPeerRecordRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AddPeerRecordRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AddPeerRecordRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.Peer == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
-	} else {
-		i -= len(m.Peer)
-		copy(dAtA[i:], m.Peer)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
-		i--
-		dAtA[i] = 0xa
+}
+func (m *AddPeerRecordRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddPeerRecordRequest.Merge(m, src)
+}
+func (m *AddPeerRecordRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *AddPeerRecordRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddPeerRecordRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddPeerRecordRequest proto.InternalMessageInfo
+
+func (m *AddPeerRecordRequest) GetEnvelope() []byte {
+	if m != nil {
+		return m.Envelope
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *StreamOpenRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *AddPeerRecordRequest) GetTtl() int64 {
+	if m != nil && m.Ttl != nil {
+		return *m.Ttl
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *StreamOpenRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type AddPeerRecordResponse struct {
+	Peer  []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	Addrs [][]byte `protobuf:"bytes,2,rep,name=addrs" json:"addrs,omitempty"`
+	// accepted is false if the daemon had a newer record of the peer
+	Accepted             *bool    `protobuf:"varint,3,req,name=accepted" json:"accepted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StreamOpenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Timeout != nil {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Proto) > 0 {
-		for iNdEx := len(m.Proto) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Proto[iNdEx])
-			copy(dAtA[i:], m.Proto[iNdEx])
-			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Proto[iNdEx])))
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	if m.Peer == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+func (m *AddPeerRecordResponse) Reset()         { *m = AddPeerRecordResponse{} }
+func (m *AddPeerRecordResponse) String() string { return proto.CompactTextString(m) }
+func (*AddPeerRecordResponse) ProtoMessage()    {}
+func (*AddPeerRecordResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{26}
+}
+func (m *AddPeerRecordResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AddPeerRecordResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AddPeerRecordResponse.Marshal(b, m, deterministic)
 	} else {
-		i -= len(m.Peer)
-		copy(dAtA[i:], m.Peer)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
-		i--
-		dAtA[i] = 0xa
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *AddPeerRecordResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddPeerRecordResponse.Merge(m, src)
+}
+func (m *AddPeerRecordResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *AddPeerRecordResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddPeerRecordResponse.DiscardUnknown(m)
 }
 
-func (m *StreamHandlerRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_AddPeerRecordResponse proto.InternalMessageInfo
+
+func (m *AddPeerRecordResponse) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *StreamHandlerRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *AddPeerRecordResponse) GetAddrs() [][]byte {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
 }
 
-func (m *StreamHandlerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Proto) > 0 {
-		for iNdEx := len(m.Proto) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Proto[iNdEx])
-			copy(dAtA[i:], m.Proto[iNdEx])
-			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Proto[iNdEx])))
-			i--
-			dAtA[i] = 0x12
-		}
+func (m *AddPeerRecordResponse) GetAccepted() bool {
+	if m != nil && m.Accepted != nil {
+		return *m.Accepted
 	}
-	if m.Addr == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
+	return false
+}
+
+type EventsRequest struct {
+	// the types of events to receive; all of them if empty
+	Types                []Event_Type `protobuf:"varint,1,rep,name=types,enum=p2pd.pb.Event_Type" json:"types,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *EventsRequest) Reset()         { *m = EventsRequest{} }
+func (m *EventsRequest) String() string { return proto.CompactTextString(m) }
+func (*EventsRequest) ProtoMessage()    {}
+func (*EventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{27}
+}
+func (m *EventsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventsRequest.Marshal(b, m, deterministic)
 	} else {
-		i -= len(m.Addr)
-		copy(dAtA[i:], m.Addr)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addr)))
-		i--
-		dAtA[i] = 0xa
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *EventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventsRequest.Merge(m, src)
+}
+func (m *EventsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventsRequest.DiscardUnknown(m)
 }
 
-func (m *ErrorResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_EventsRequest proto.InternalMessageInfo
+
+func (m *EventsRequest) GetTypes() []Event_Type {
+	if m != nil {
+		return m.Types
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ErrorResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type Event struct {
+	Type *Event_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.Event_Type" json:"type,omitempty"`
+	// when the event happened, in UNIX nanoseconds
+	Time *int64 `protobuf:"varint,2,req,name=time" json:"time,omitempty"`
+	// the remote peer the event is about
+	Peer                 []byte          `protobuf:"bytes,3,opt,name=peer" json:"peer,omitempty"`
+	HolePunch            *HolePunchEvent `protobuf:"bytes,4,opt,name=holePunch" json:"holePunch,omitempty"`
+	Reachability         *Reachability   `protobuf:"varint,5,opt,name=reachability,enum=p2pd.pb.Reachability" json:"reachability,omitempty"`
+	Addrs                [][]byte        `protobuf:"bytes,6,rep,name=addrs" json:"addrs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
 }
 
-func (m *ErrorResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Msg == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("msg")
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{28}
+}
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Event.Marshal(b, m, deterministic)
 	} else {
-		i -= len(*m.Msg)
-		copy(dAtA[i:], *m.Msg)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Msg)))
-		i--
-		dAtA[i] = 0xa
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return m.Size()
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
 }
 
-func (m *StreamInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetType() Event_Type {
+	if m != nil && m.Type != nil {
+		return *m.Type
 	}
-	return dAtA[:n], nil
+	return Event_HOLE_PUNCH
 }
 
-func (m *StreamInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *Event) GetTime() int64 {
+	if m != nil && m.Time != nil {
+		return *m.Time
+	}
+	return 0
 }
 
-func (m *StreamInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *Event) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	if m.Proto == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
-	} else {
-		i -= len(*m.Proto)
-		copy(dAtA[i:], *m.Proto)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Proto)))
-		i--
-		dAtA[i] = 0x1a
+	return nil
+}
+
+func (m *Event) GetHolePunch() *HolePunchEvent {
+	if m != nil {
+		return m.HolePunch
 	}
-	if m.Addr == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
-	} else {
-		i -= len(m.Addr)
-		copy(dAtA[i:], m.Addr)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addr)))
-		i--
-		dAtA[i] = 0x12
+	return nil
+}
+
+func (m *Event) GetReachability() Reachability {
+	if m != nil && m.Reachability != nil {
+		return *m.Reachability
 	}
-	if m.Peer == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	return Reachability_UNKNOWN
+}
+
+func (m *Event) GetAddrs() [][]byte {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
+type HolePunchEvent struct {
+	Type *HolePunchEvent_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.HolePunchEvent_Type" json:"type,omitempty"`
+	// whether a DIRECT_DIAL or END succeeded
+	Success *bool   `protobuf:"varint,2,opt,name=success" json:"success,omitempty"`
+	Error   *string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	// how long a DIRECT_DIAL or END took, in milliseconds
+	ElapsedMillis *int64 `protobuf:"varint,4,opt,name=elapsedMillis" json:"elapsedMillis,omitempty"`
+	// the round trip time to the peer measured at START, in milliseconds
+	RttMillis *int64   `protobuf:"varint,5,opt,name=rttMillis" json:"rttMillis,omitempty"`
+	Addrs     [][]byte `protobuf:"bytes,6,rep,name=addrs" json:"addrs,omitempty"`
+	// the number of the ATTEMPT
+	Attempt              *int32   `protobuf:"varint,7,opt,name=attempt" json:"attempt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HolePunchEvent) Reset()         { *m = HolePunchEvent{} }
+func (m *HolePunchEvent) String() string { return proto.CompactTextString(m) }
+func (*HolePunchEvent) ProtoMessage()    {}
+func (*HolePunchEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{29}
+}
+func (m *HolePunchEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HolePunchEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HolePunchEvent.Marshal(b, m, deterministic)
 	} else {
-		i -= len(m.Peer)
-		copy(dAtA[i:], m.Peer)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
-		i--
-		dAtA[i] = 0xa
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *HolePunchEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HolePunchEvent.Merge(m, src)
+}
+func (m *HolePunchEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *HolePunchEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_HolePunchEvent.DiscardUnknown(m)
 }
 
-func (m *DHTRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_HolePunchEvent proto.InternalMessageInfo
+
+func (m *HolePunchEvent) GetType() HolePunchEvent_Type {
+	if m != nil && m.Type != nil {
+		return *m.Type
 	}
-	return dAtA[:n], nil
+	return HolePunchEvent_DIRECT_DIAL
 }
 
-func (m *DHTRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *HolePunchEvent) GetSuccess() bool {
+	if m != nil && m.Success != nil {
+		return *m.Success
+	}
+	return false
 }
 
-func (m *DHTRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (m *HolePunchEvent) GetError() string {
+	if m != nil && m.Error != nil {
+		return *m.Error
 	}
-	if m.Timeout != nil {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
-		i--
-		dAtA[i] = 0x38
+	return ""
+}
+
+func (m *HolePunchEvent) GetElapsedMillis() int64 {
+	if m != nil && m.ElapsedMillis != nil {
+		return *m.ElapsedMillis
 	}
-	if m.Count != nil {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Count))
-		i--
-		dAtA[i] = 0x30
-	}
-	if m.Value != nil {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0x2a
-	}
-	if m.Key != nil {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.Cid != nil {
-		i -= len(m.Cid)
-		copy(dAtA[i:], m.Cid)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Cid)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Peer != nil {
-		i -= len(m.Peer)
-		copy(dAtA[i:], m.Peer)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
-		i--
-		dAtA[i] = 0x12
+	return 0
+}
+
+func (m *HolePunchEvent) GetRttMillis() int64 {
+	if m != nil && m.RttMillis != nil {
+		return *m.RttMillis
 	}
-	if m.Type == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
-	} else {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
-		i--
-		dAtA[i] = 0x8
+	return 0
+}
+
+func (m *HolePunchEvent) GetAddrs() [][]byte {
+	if m != nil {
+		return m.Addrs
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *DHTResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *HolePunchEvent) GetAttempt() int32 {
+	if m != nil && m.Attempt != nil {
+		return *m.Attempt
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *DHTResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type DirectConnectRequest struct {
+	Peer                 []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	Timeout              *int64   `protobuf:"varint,2,opt,name=timeout" json:"timeout,omitempty"`
+	TimeoutMillis        *int64   `protobuf:"varint,3,opt,name=timeoutMillis" json:"timeoutMillis,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DHTResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Value != nil {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Peer != nil {
-		{
-			size, err := m.Peer.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintP2Pd(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Type == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+func (m *DirectConnectRequest) Reset()         { *m = DirectConnectRequest{} }
+func (m *DirectConnectRequest) String() string { return proto.CompactTextString(m) }
+func (*DirectConnectRequest) ProtoMessage()    {}
+func (*DirectConnectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{30}
+}
+func (m *DirectConnectRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DirectConnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DirectConnectRequest.Marshal(b, m, deterministic)
 	} else {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
-		i--
-		dAtA[i] = 0x8
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *DirectConnectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DirectConnectRequest.Merge(m, src)
+}
+func (m *DirectConnectRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DirectConnectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DirectConnectRequest.DiscardUnknown(m)
 }
 
-func (m *PeerInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DirectConnectRequest proto.InternalMessageInfo
+
+func (m *DirectConnectRequest) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *PeerInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *DirectConnectRequest) GetTimeout() int64 {
+	if m != nil && m.Timeout != nil {
+		return *m.Timeout
+	}
+	return 0
 }
 
-func (m *PeerInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.Addrs) > 0 {
-		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Addrs[iNdEx])
-			copy(dAtA[i:], m.Addrs[iNdEx])
-			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
-			i--
-			dAtA[i] = 0x12
-		}
+func (m *DirectConnectRequest) GetTimeoutMillis() int64 {
+	if m != nil && m.TimeoutMillis != nil {
+		return *m.TimeoutMillis
 	}
-	if m.Id == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	return 0
+}
+
+type RelayReserveRequest struct {
+	// the relay to reserve a slot on
+	Peer                 []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	Addrs                [][]byte `protobuf:"bytes,2,rep,name=addrs" json:"addrs,omitempty"`
+	Timeout              *int64   `protobuf:"varint,3,opt,name=timeout" json:"timeout,omitempty"`
+	TimeoutMillis        *int64   `protobuf:"varint,4,opt,name=timeoutMillis" json:"timeoutMillis,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RelayReserveRequest) Reset()         { *m = RelayReserveRequest{} }
+func (m *RelayReserveRequest) String() string { return proto.CompactTextString(m) }
+func (*RelayReserveRequest) ProtoMessage()    {}
+func (*RelayReserveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{31}
+}
+func (m *RelayReserveRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RelayReserveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RelayReserveRequest.Marshal(b, m, deterministic)
 	} else {
-		i -= len(m.Id)
-		copy(dAtA[i:], m.Id)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Id)))
-		i--
-		dAtA[i] = 0xa
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *RelayReserveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RelayReserveRequest.Merge(m, src)
+}
+func (m *RelayReserveRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RelayReserveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RelayReserveRequest.DiscardUnknown(m)
 }
 
-func (m *ConnManagerRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RelayReserveRequest proto.InternalMessageInfo
+
+func (m *RelayReserveRequest) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *ConnManagerRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *RelayReserveRequest) GetAddrs() [][]byte {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
 }
 
-func (m *ConnManagerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Weight != nil {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Weight))
-		i--
-		dAtA[i] = 0x20
-	}
-	if m.Tag != nil {
-		i -= len(*m.Tag)
-		copy(dAtA[i:], *m.Tag)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Tag)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Peer != nil {
-		i -= len(m.Peer)
-		copy(dAtA[i:], m.Peer)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Type == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
-	} else {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
-		i--
-		dAtA[i] = 0x8
+func (m *RelayReserveRequest) GetTimeout() int64 {
+	if m != nil && m.Timeout != nil {
+		return *m.Timeout
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *DisconnectRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *RelayReserveRequest) GetTimeoutMillis() int64 {
+	if m != nil && m.TimeoutMillis != nil {
+		return *m.TimeoutMillis
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *DisconnectRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type RelayCancelRequest struct {
+	Peer                 []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DisconnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Peer == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+func (m *RelayCancelRequest) Reset()         { *m = RelayCancelRequest{} }
+func (m *RelayCancelRequest) String() string { return proto.CompactTextString(m) }
+func (*RelayCancelRequest) ProtoMessage()    {}
+func (*RelayCancelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{32}
+}
+func (m *RelayCancelRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RelayCancelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RelayCancelRequest.Marshal(b, m, deterministic)
 	} else {
-		i -= len(m.Peer)
-		copy(dAtA[i:], m.Peer)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
-		i--
-		dAtA[i] = 0xa
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *RelayCancelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RelayCancelRequest.Merge(m, src)
+}
+func (m *RelayCancelRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RelayCancelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RelayCancelRequest.DiscardUnknown(m)
 }
 
-func (m *PSRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RelayCancelRequest proto.InternalMessageInfo
+
+func (m *RelayCancelRequest) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *PSRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type RelayReservation struct {
+	Relay []byte `protobuf:"bytes,1,req,name=relay" json:"relay,omitempty"`
+	// when the reservation expires unless refreshed, in UNIX seconds
+	Expiration *int64 `protobuf:"varint,2,req,name=expiration" json:"expiration,omitempty"`
+	// the /p2p-circuit addresses peers can reach the daemon at through the relay
+	Addrs [][]byte `protobuf:"bytes,3,rep,name=addrs" json:"addrs,omitempty"`
+	// how long the relay keeps relayed connections open, in seconds; unlimited
+	// if 0
+	LimitDuration *int64 `protobuf:"varint,4,opt,name=limitDuration" json:"limitDuration,omitempty"`
+	// how many bytes the relay relays in each direction of a connection;
+	// unlimited if 0
+	LimitData *uint64 `protobuf:"varint,5,opt,name=limitData" json:"limitData,omitempty"`
+	// why the last refresh of the reservation failed, if it did
+	Error                *string  `protobuf:"bytes,6,opt,name=error" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PSRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Data != nil {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Topic != nil {
-		i -= len(*m.Topic)
-		copy(dAtA[i:], *m.Topic)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Topic)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Type == nil {
-		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+func (m *RelayReservation) Reset()         { *m = RelayReservation{} }
+func (m *RelayReservation) String() string { return proto.CompactTextString(m) }
+func (*RelayReservation) ProtoMessage()    {}
+func (*RelayReservation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{33}
+}
+func (m *RelayReservation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RelayReservation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RelayReservation.Marshal(b, m, deterministic)
 	} else {
-		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
-		i--
-		dAtA[i] = 0x8
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *RelayReservation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RelayReservation.Merge(m, src)
+}
+func (m *RelayReservation) XXX_Size() int {
+	return m.Size()
+}
+func (m *RelayReservation) XXX_DiscardUnknown() {
+	xxx_messageInfo_RelayReservation.DiscardUnknown(m)
 }
 
-func (m *PSMessage) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RelayReservation proto.InternalMessageInfo
+
+func (m *RelayReservation) GetRelay() []byte {
+	if m != nil {
+		return m.Relay
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *PSMessage) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *RelayReservation) GetExpiration() int64 {
+	if m != nil && m.Expiration != nil {
+		return *m.Expiration
+	}
+	return 0
 }
 
-func (m *PSMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if m.Key != nil {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0x32
-	}
-	if m.Signature != nil {
-		i -= len(m.Signature)
-		copy(dAtA[i:], m.Signature)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Signature)))
-		i--
-		dAtA[i] = 0x2a
-	}
-	if len(m.TopicIDs) > 0 {
-		for iNdEx := len(m.TopicIDs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.TopicIDs[iNdEx])
-			copy(dAtA[i:], m.TopicIDs[iNdEx])
-			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.TopicIDs[iNdEx])))
-			i--
-			dAtA[i] = 0x22
-		}
-	}
-	if m.Seqno != nil {
-		i -= len(m.Seqno)
-		copy(dAtA[i:], m.Seqno)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Seqno)))
-		i--
-		dAtA[i] = 0x1a
+func (m *RelayReservation) GetAddrs() [][]byte {
+	if m != nil {
+		return m.Addrs
 	}
-	if m.Data != nil {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0x12
+	return nil
+}
+
+func (m *RelayReservation) GetLimitDuration() int64 {
+	if m != nil && m.LimitDuration != nil {
+		return *m.LimitDuration
 	}
-	if m.From != nil {
-		i -= len(m.From)
-		copy(dAtA[i:], m.From)
-		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.From)))
-		i--
-		dAtA[i] = 0xa
+	return 0
+}
+
+func (m *RelayReservation) GetLimitData() uint64 {
+	if m != nil && m.LimitData != nil {
+		return *m.LimitData
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *PSResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *RelayReservation) GetError() string {
+	if m != nil && m.Error != nil {
+		return *m.Error
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *PSResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ReachabilityResponse struct {
+	Reachability *Reachability `protobuf:"varint,1,req,name=reachability,enum=p2pd.pb.Reachability" json:"reachability,omitempty"`
+	// whether the reachability is forced by the daemon's config, rather than
+	// determined by AutoNAT
+	Forced               *bool    `protobuf:"varint,2,opt,name=forced" json:"forced,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PSResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
-	}
-	if len(m.PeerIDs) > 0 {
-		for iNdEx := len(m.PeerIDs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.PeerIDs[iNdEx])
-			copy(dAtA[i:], m.PeerIDs[iNdEx])
-			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.PeerIDs[iNdEx])))
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	if len(m.Topics) > 0 {
-		for iNdEx := len(m.Topics) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Topics[iNdEx])
-			copy(dAtA[i:], m.Topics[iNdEx])
-			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Topics[iNdEx])))
-			i--
-			dAtA[i] = 0xa
+func (m *ReachabilityResponse) Reset()         { *m = ReachabilityResponse{} }
+func (m *ReachabilityResponse) String() string { return proto.CompactTextString(m) }
+func (*ReachabilityResponse) ProtoMessage()    {}
+func (*ReachabilityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{34}
+}
+func (m *ReachabilityResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReachabilityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReachabilityResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ReachabilityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReachabilityResponse.Merge(m, src)
+}
+func (m *ReachabilityResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReachabilityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReachabilityResponse.DiscardUnknown(m)
 }
 
-func encodeVarintP2Pd(dAtA []byte, offset int, v uint64) int {
-	offset -= sovP2Pd(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+var xxx_messageInfo_ReachabilityResponse proto.InternalMessageInfo
+
+func (m *ReachabilityResponse) GetReachability() Reachability {
+	if m != nil && m.Reachability != nil {
+		return *m.Reachability
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return Reachability_UNKNOWN
 }
-func (m *Request) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *ReachabilityResponse) GetForced() bool {
+	if m != nil && m.Forced != nil {
+		return *m.Forced
 	}
-	var l int
-	_ = l
-	if m.Type != nil {
-		n += 1 + sovP2Pd(uint64(*m.Type))
+	return false
+}
+
+type DisconnectRequest struct {
+	Peer                 []byte   `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DisconnectRequest) Reset()         { *m = DisconnectRequest{} }
+func (m *DisconnectRequest) String() string { return proto.CompactTextString(m) }
+func (*DisconnectRequest) ProtoMessage()    {}
+func (*DisconnectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{35}
+}
+func (m *DisconnectRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DisconnectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DisconnectRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Connect != nil {
-		l = m.Connect.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.StreamOpen != nil {
-		l = m.StreamOpen.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.StreamHandler != nil {
-		l = m.StreamHandler.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Dht != nil {
-		l = m.Dht.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.ConnManager != nil {
-		l = m.ConnManager.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Disconnect != nil {
-		l = m.Disconnect.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Pubsub != nil {
-		l = m.Pubsub.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+}
+func (m *DisconnectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DisconnectRequest.Merge(m, src)
+}
+func (m *DisconnectRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DisconnectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DisconnectRequest.DiscardUnknown(m)
 }
 
-func (m *Response) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Type != nil {
-		n += 1 + sovP2Pd(uint64(*m.Type))
-	}
-	if m.Error != nil {
-		l = m.Error.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.StreamInfo != nil {
-		l = m.StreamInfo.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Identify != nil {
-		l = m.Identify.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.Dht != nil {
-		l = m.Dht.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if len(m.Peers) > 0 {
-		for _, e := range m.Peers {
-			l = e.Size()
-			n += 1 + l + sovP2Pd(uint64(l))
-		}
-	}
-	if m.Pubsub != nil {
-		l = m.Pubsub.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+var xxx_messageInfo_DisconnectRequest proto.InternalMessageInfo
+
+func (m *DisconnectRequest) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	return n
+	return nil
 }
 
-func (m *IdentifyResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Id != nil {
-		l = len(m.Id)
-		n += 1 + l + sovP2Pd(uint64(l))
-	}
-	if len(m.Addrs) > 0 {
-		for _, b := range m.Addrs {
-			l = len(b)
-			n += 1 + l + sovP2Pd(uint64(l))
+type PSRequest struct {
+	Type                 *PSRequest_Type `protobuf:"varint,1,req,name=type,enum=p2pd.pb.PSRequest_Type" json:"type,omitempty"`
+	Topic                *string         `protobuf:"bytes,2,opt,name=topic" json:"topic,omitempty"`
+	Data                 []byte          `protobuf:"bytes,3,opt,name=data" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *PSRequest) Reset()         { *m = PSRequest{} }
+func (m *PSRequest) String() string { return proto.CompactTextString(m) }
+func (*PSRequest) ProtoMessage()    {}
+func (*PSRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{36}
+}
+func (m *PSRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PSRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PSRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+}
+func (m *PSRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PSRequest.Merge(m, src)
+}
+func (m *PSRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PSRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PSRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PSRequest proto.InternalMessageInfo
+
+func (m *PSRequest) GetType() PSRequest_Type {
+	if m != nil && m.Type != nil {
+		return *m.Type
 	}
-	return n
+	return PSRequest_GET_TOPICS
 }
 
-func (m *ConnectRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PSRequest) GetTopic() string {
+	if m != nil && m.Topic != nil {
+		return *m.Topic
 	}
-	var l int
-	_ = l
-	if m.Peer != nil {
-		l = len(m.Peer)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return ""
+}
+
+func (m *PSRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
-	if len(m.Addrs) > 0 {
-		for _, b := range m.Addrs {
-			l = len(b)
-			n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+type PSMessage struct {
+	From                 []byte   `protobuf:"bytes,1,opt,name=from" json:"from,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data" json:"data,omitempty"`
+	Seqno                []byte   `protobuf:"bytes,3,opt,name=seqno" json:"seqno,omitempty"`
+	TopicIDs             []string `protobuf:"bytes,4,rep,name=topicIDs" json:"topicIDs,omitempty"`
+	Signature            []byte   `protobuf:"bytes,5,opt,name=signature" json:"signature,omitempty"`
+	Key                  []byte   `protobuf:"bytes,6,opt,name=key" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PSMessage) Reset()         { *m = PSMessage{} }
+func (m *PSMessage) String() string { return proto.CompactTextString(m) }
+func (*PSMessage) ProtoMessage()    {}
+func (*PSMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{37}
+}
+func (m *PSMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PSMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PSMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.Timeout != nil {
-		n += 1 + sovP2Pd(uint64(*m.Timeout))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+}
+func (m *PSMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PSMessage.Merge(m, src)
+}
+func (m *PSMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *PSMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_PSMessage.DiscardUnknown(m)
 }
 
-func (m *StreamOpenRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Peer != nil {
-		l = len(m.Peer)
-		n += 1 + l + sovP2Pd(uint64(l))
+var xxx_messageInfo_PSMessage proto.InternalMessageInfo
+
+func (m *PSMessage) GetFrom() []byte {
+	if m != nil {
+		return m.From
 	}
-	if len(m.Proto) > 0 {
-		for _, s := range m.Proto {
-			l = len(s)
-			n += 1 + l + sovP2Pd(uint64(l))
-		}
+	return nil
+}
+
+func (m *PSMessage) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
-	if m.Timeout != nil {
-		n += 1 + sovP2Pd(uint64(*m.Timeout))
+	return nil
+}
+
+func (m *PSMessage) GetSeqno() []byte {
+	if m != nil {
+		return m.Seqno
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+
+func (m *PSMessage) GetTopicIDs() []string {
+	if m != nil {
+		return m.TopicIDs
 	}
-	return n
+	return nil
 }
 
-func (m *StreamHandlerRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PSMessage) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
 	}
-	var l int
-	_ = l
-	if m.Addr != nil {
-		l = len(m.Addr)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+func (m *PSMessage) GetKey() []byte {
+	if m != nil {
+		return m.Key
 	}
-	if len(m.Proto) > 0 {
-		for _, s := range m.Proto {
-			l = len(s)
-			n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+type PSResponse struct {
+	Topics               []string `protobuf:"bytes,1,rep,name=topics" json:"topics,omitempty"`
+	PeerIDs              [][]byte `protobuf:"bytes,2,rep,name=peerIDs" json:"peerIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PSResponse) Reset()         { *m = PSResponse{} }
+func (m *PSResponse) String() string { return proto.CompactTextString(m) }
+func (*PSResponse) ProtoMessage()    {}
+func (*PSResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{38}
+}
+func (m *PSResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PSResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PSResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+}
+func (m *PSResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PSResponse.Merge(m, src)
+}
+func (m *PSResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PSResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PSResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PSResponse proto.InternalMessageInfo
+
+func (m *PSResponse) GetTopics() []string {
+	if m != nil {
+		return m.Topics
 	}
-	return n
+	return nil
 }
 
-func (m *ErrorResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *PSResponse) GetPeerIDs() [][]byte {
+	if m != nil {
+		return m.PeerIDs
 	}
-	var l int
-	_ = l
-	if m.Msg != nil {
-		l = len(*m.Msg)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+type DiagnosticsResponse struct {
+	RoutingTable []*DiagnosticsBucket  `protobuf:"bytes,1,rep,name=routingTable" json:"routingTable,omitempty"`
+	Conns        []*DiagnosticsConn    `protobuf:"bytes,2,rep,name=conns" json:"conns,omitempty"`
+	Topics       []*DiagnosticsTopic   `protobuf:"bytes,3,rep,name=topics" json:"topics,omitempty"`
+	Handlers     []*DiagnosticsHandler `protobuf:"bytes,4,rep,name=handlers" json:"handlers,omitempty"`
+	Goroutines   *int64                `protobuf:"varint,5,opt,name=goroutines" json:"goroutines,omitempty"`
+	// whether the daemon only connects with peers sharing its pre-shared key
+	PrivateNetwork *bool `protobuf:"varint,6,opt,name=privateNetwork" json:"privateNetwork,omitempty"`
+	// whether hole punching is enabled
+	HolePunching *bool `protobuf:"varint,7,opt,name=holePunching" json:"holePunching,omitempty"`
+	// whether the daemon punches holes: once it has a public address and is
+	// behind a NAT
+	HolePunchingActive   *bool    `protobuf:"varint,8,opt,name=holePunchingActive" json:"holePunchingActive,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiagnosticsResponse) Reset()         { *m = DiagnosticsResponse{} }
+func (m *DiagnosticsResponse) String() string { return proto.CompactTextString(m) }
+func (*DiagnosticsResponse) ProtoMessage()    {}
+func (*DiagnosticsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{39}
+}
+func (m *DiagnosticsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DiagnosticsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DiagnosticsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+}
+func (m *DiagnosticsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiagnosticsResponse.Merge(m, src)
+}
+func (m *DiagnosticsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *DiagnosticsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiagnosticsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiagnosticsResponse proto.InternalMessageInfo
+
+func (m *DiagnosticsResponse) GetRoutingTable() []*DiagnosticsBucket {
+	if m != nil {
+		return m.RoutingTable
 	}
-	return n
+	return nil
 }
 
-func (m *StreamInfo) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DiagnosticsResponse) GetConns() []*DiagnosticsConn {
+	if m != nil {
+		return m.Conns
 	}
-	var l int
-	_ = l
-	if m.Peer != nil {
-		l = len(m.Peer)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+func (m *DiagnosticsResponse) GetTopics() []*DiagnosticsTopic {
+	if m != nil {
+		return m.Topics
 	}
-	if m.Addr != nil {
-		l = len(m.Addr)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+func (m *DiagnosticsResponse) GetHandlers() []*DiagnosticsHandler {
+	if m != nil {
+		return m.Handlers
 	}
-	if m.Proto != nil {
-		l = len(*m.Proto)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+func (m *DiagnosticsResponse) GetGoroutines() int64 {
+	if m != nil && m.Goroutines != nil {
+		return *m.Goroutines
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return 0
+}
+
+func (m *DiagnosticsResponse) GetPrivateNetwork() bool {
+	if m != nil && m.PrivateNetwork != nil {
+		return *m.PrivateNetwork
 	}
-	return n
+	return false
 }
 
-func (m *DHTRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DiagnosticsResponse) GetHolePunching() bool {
+	if m != nil && m.HolePunching != nil {
+		return *m.HolePunching
 	}
-	var l int
-	_ = l
-	if m.Type != nil {
-		n += 1 + sovP2Pd(uint64(*m.Type))
+	return false
+}
+
+func (m *DiagnosticsResponse) GetHolePunchingActive() bool {
+	if m != nil && m.HolePunchingActive != nil {
+		return *m.HolePunchingActive
 	}
-	if m.Peer != nil {
-		l = len(m.Peer)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return false
+}
+
+// DHT routing table peers sharing a common prefix length with our ID
+type DiagnosticsBucket struct {
+	Cpl                  *uint32  `protobuf:"varint,1,req,name=cpl" json:"cpl,omitempty"`
+	Peers                [][]byte `protobuf:"bytes,2,rep,name=peers" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiagnosticsBucket) Reset()         { *m = DiagnosticsBucket{} }
+func (m *DiagnosticsBucket) String() string { return proto.CompactTextString(m) }
+func (*DiagnosticsBucket) ProtoMessage()    {}
+func (*DiagnosticsBucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{40}
+}
+func (m *DiagnosticsBucket) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DiagnosticsBucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DiagnosticsBucket.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Cid != nil {
-		l = len(m.Cid)
-		n += 1 + l + sovP2Pd(uint64(l))
+}
+func (m *DiagnosticsBucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiagnosticsBucket.Merge(m, src)
+}
+func (m *DiagnosticsBucket) XXX_Size() int {
+	return m.Size()
+}
+func (m *DiagnosticsBucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiagnosticsBucket.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiagnosticsBucket proto.InternalMessageInfo
+
+func (m *DiagnosticsBucket) GetCpl() uint32 {
+	if m != nil && m.Cpl != nil {
+		return *m.Cpl
 	}
-	if m.Key != nil {
-		l = len(m.Key)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return 0
+}
+
+func (m *DiagnosticsBucket) GetPeers() [][]byte {
+	if m != nil {
+		return m.Peers
 	}
-	if m.Value != nil {
-		l = len(m.Value)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+type DiagnosticsConn struct {
+	Peer                 []byte               `protobuf:"bytes,1,req,name=peer" json:"peer,omitempty"`
+	Addr                 []byte               `protobuf:"bytes,2,req,name=addr" json:"addr,omitempty"`
+	Direction            *string              `protobuf:"bytes,3,opt,name=direction" json:"direction,omitempty"`
+	AgentVersion         *string              `protobuf:"bytes,4,opt,name=agentVersion" json:"agentVersion,omitempty"`
+	ProtocolVersion      *string              `protobuf:"bytes,5,opt,name=protocolVersion" json:"protocolVersion,omitempty"`
+	Protocols            []string             `protobuf:"bytes,6,rep,name=protocols" json:"protocols,omitempty"`
+	Streams              []*DiagnosticsStream `protobuf:"bytes,7,rep,name=streams" json:"streams,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DiagnosticsConn) Reset()         { *m = DiagnosticsConn{} }
+func (m *DiagnosticsConn) String() string { return proto.CompactTextString(m) }
+func (*DiagnosticsConn) ProtoMessage()    {}
+func (*DiagnosticsConn) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{41}
+}
+func (m *DiagnosticsConn) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DiagnosticsConn) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DiagnosticsConn.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Count != nil {
-		n += 1 + sovP2Pd(uint64(*m.Count))
+}
+func (m *DiagnosticsConn) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiagnosticsConn.Merge(m, src)
+}
+func (m *DiagnosticsConn) XXX_Size() int {
+	return m.Size()
+}
+func (m *DiagnosticsConn) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiagnosticsConn.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiagnosticsConn proto.InternalMessageInfo
+
+func (m *DiagnosticsConn) GetPeer() []byte {
+	if m != nil {
+		return m.Peer
 	}
-	if m.Timeout != nil {
-		n += 1 + sovP2Pd(uint64(*m.Timeout))
+	return nil
+}
+
+func (m *DiagnosticsConn) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+
+func (m *DiagnosticsConn) GetDirection() string {
+	if m != nil && m.Direction != nil {
+		return *m.Direction
 	}
-	return n
+	return ""
 }
 
-func (m *DHTResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DiagnosticsConn) GetAgentVersion() string {
+	if m != nil && m.AgentVersion != nil {
+		return *m.AgentVersion
 	}
-	var l int
-	_ = l
-	if m.Type != nil {
-		n += 1 + sovP2Pd(uint64(*m.Type))
+	return ""
+}
+
+func (m *DiagnosticsConn) GetProtocolVersion() string {
+	if m != nil && m.ProtocolVersion != nil {
+		return *m.ProtocolVersion
 	}
-	if m.Peer != nil {
-		l = m.Peer.Size()
-		n += 1 + l + sovP2Pd(uint64(l))
+	return ""
+}
+
+func (m *DiagnosticsConn) GetProtocols() []string {
+	if m != nil {
+		return m.Protocols
 	}
-	if m.Value != nil {
-		l = len(m.Value)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+func (m *DiagnosticsConn) GetStreams() []*DiagnosticsStream {
+	if m != nil {
+		return m.Streams
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+
+type DiagnosticsStream struct {
+	Proto                *string  `protobuf:"bytes,1,opt,name=proto" json:"proto,omitempty"`
+	Direction            *string  `protobuf:"bytes,2,opt,name=direction" json:"direction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiagnosticsStream) Reset()         { *m = DiagnosticsStream{} }
+func (m *DiagnosticsStream) String() string { return proto.CompactTextString(m) }
+func (*DiagnosticsStream) ProtoMessage()    {}
+func (*DiagnosticsStream) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{42}
+}
+func (m *DiagnosticsStream) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DiagnosticsStream) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DiagnosticsStream.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *DiagnosticsStream) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiagnosticsStream.Merge(m, src)
+}
+func (m *DiagnosticsStream) XXX_Size() int {
+	return m.Size()
+}
+func (m *DiagnosticsStream) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiagnosticsStream.DiscardUnknown(m)
 }
 
-func (m *PeerInfo) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_DiagnosticsStream proto.InternalMessageInfo
+
+func (m *DiagnosticsStream) GetProto() string {
+	if m != nil && m.Proto != nil {
+		return *m.Proto
 	}
-	var l int
-	_ = l
-	if m.Id != nil {
-		l = len(m.Id)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return ""
+}
+
+func (m *DiagnosticsStream) GetDirection() string {
+	if m != nil && m.Direction != nil {
+		return *m.Direction
 	}
-	if len(m.Addrs) > 0 {
-		for _, b := range m.Addrs {
-			l = len(b)
-			n += 1 + l + sovP2Pd(uint64(l))
+	return ""
+}
+
+type DiagnosticsTopic struct {
+	Topic                *string  `protobuf:"bytes,1,req,name=topic" json:"topic,omitempty"`
+	Peers                [][]byte `protobuf:"bytes,2,rep,name=peers" json:"peers,omitempty"`
+	MeshPeers            [][]byte `protobuf:"bytes,3,rep,name=meshPeers" json:"meshPeers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiagnosticsTopic) Reset()         { *m = DiagnosticsTopic{} }
+func (m *DiagnosticsTopic) String() string { return proto.CompactTextString(m) }
+func (*DiagnosticsTopic) ProtoMessage()    {}
+func (*DiagnosticsTopic) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{43}
+}
+func (m *DiagnosticsTopic) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DiagnosticsTopic) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DiagnosticsTopic.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+}
+func (m *DiagnosticsTopic) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiagnosticsTopic.Merge(m, src)
+}
+func (m *DiagnosticsTopic) XXX_Size() int {
+	return m.Size()
+}
+func (m *DiagnosticsTopic) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiagnosticsTopic.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiagnosticsTopic proto.InternalMessageInfo
+
+func (m *DiagnosticsTopic) GetTopic() string {
+	if m != nil && m.Topic != nil {
+		return *m.Topic
 	}
-	return n
+	return ""
 }
 
-func (m *ConnManagerRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DiagnosticsTopic) GetPeers() [][]byte {
+	if m != nil {
+		return m.Peers
 	}
-	var l int
-	_ = l
-	if m.Type != nil {
-		n += 1 + sovP2Pd(uint64(*m.Type))
+	return nil
+}
+
+func (m *DiagnosticsTopic) GetMeshPeers() [][]byte {
+	if m != nil {
+		return m.MeshPeers
 	}
-	if m.Peer != nil {
-		l = len(m.Peer)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+type DiagnosticsHandler struct {
+	Proto                *string  `protobuf:"bytes,1,req,name=proto" json:"proto,omitempty"`
+	Addr                 []byte   `protobuf:"bytes,2,opt,name=addr" json:"addr,omitempty"`
+	PassFd               *bool    `protobuf:"varint,3,opt,name=passFd" json:"passFd,omitempty"`
+	Shm                  *bool    `protobuf:"varint,4,opt,name=shm" json:"shm,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DiagnosticsHandler) Reset()         { *m = DiagnosticsHandler{} }
+func (m *DiagnosticsHandler) String() string { return proto.CompactTextString(m) }
+func (*DiagnosticsHandler) ProtoMessage()    {}
+func (*DiagnosticsHandler) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{44}
+}
+func (m *DiagnosticsHandler) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DiagnosticsHandler) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DiagnosticsHandler.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Tag != nil {
-		l = len(*m.Tag)
-		n += 1 + l + sovP2Pd(uint64(l))
+}
+func (m *DiagnosticsHandler) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiagnosticsHandler.Merge(m, src)
+}
+func (m *DiagnosticsHandler) XXX_Size() int {
+	return m.Size()
+}
+func (m *DiagnosticsHandler) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiagnosticsHandler.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiagnosticsHandler proto.InternalMessageInfo
+
+func (m *DiagnosticsHandler) GetProto() string {
+	if m != nil && m.Proto != nil {
+		return *m.Proto
 	}
-	if m.Weight != nil {
-		n += 1 + sovP2Pd(uint64(*m.Weight))
+	return ""
+}
+
+func (m *DiagnosticsHandler) GetAddr() []byte {
+	if m != nil {
+		return m.Addr
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+
+func (m *DiagnosticsHandler) GetPassFd() bool {
+	if m != nil && m.PassFd != nil {
+		return *m.PassFd
 	}
-	return n
+	return false
 }
 
-func (m *DisconnectRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *DiagnosticsHandler) GetShm() bool {
+	if m != nil && m.Shm != nil {
+		return *m.Shm
 	}
-	var l int
-	_ = l
-	if m.Peer != nil {
-		l = len(m.Peer)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return false
+}
+
+type ResourceUsageResponse struct {
+	System               *ResourceScopeUsage   `protobuf:"bytes,1,req,name=system" json:"system,omitempty"`
+	Transient            *ResourceScopeUsage   `protobuf:"bytes,2,req,name=transient" json:"transient,omitempty"`
+	Services             []*ResourceScopeUsage `protobuf:"bytes,3,rep,name=services" json:"services,omitempty"`
+	Protocols            []*ResourceScopeUsage `protobuf:"bytes,4,rep,name=protocols" json:"protocols,omitempty"`
+	Peers                []*ResourceScopeUsage `protobuf:"bytes,5,rep,name=peers" json:"peers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ResourceUsageResponse) Reset()         { *m = ResourceUsageResponse{} }
+func (m *ResourceUsageResponse) String() string { return proto.CompactTextString(m) }
+func (*ResourceUsageResponse) ProtoMessage()    {}
+func (*ResourceUsageResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{45}
+}
+func (m *ResourceUsageResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResourceUsageResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResourceUsageResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+}
+func (m *ResourceUsageResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceUsageResponse.Merge(m, src)
+}
+func (m *ResourceUsageResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResourceUsageResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceUsageResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceUsageResponse proto.InternalMessageInfo
+
+func (m *ResourceUsageResponse) GetSystem() *ResourceScopeUsage {
+	if m != nil {
+		return m.System
 	}
-	return n
+	return nil
 }
 
-func (m *PSRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ResourceUsageResponse) GetTransient() *ResourceScopeUsage {
+	if m != nil {
+		return m.Transient
 	}
-	var l int
-	_ = l
-	if m.Type != nil {
-		n += 1 + sovP2Pd(uint64(*m.Type))
+	return nil
+}
+
+func (m *ResourceUsageResponse) GetServices() []*ResourceScopeUsage {
+	if m != nil {
+		return m.Services
 	}
-	if m.Topic != nil {
-		l = len(*m.Topic)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+func (m *ResourceUsageResponse) GetProtocols() []*ResourceScopeUsage {
+	if m != nil {
+		return m.Protocols
 	}
-	if m.Data != nil {
-		l = len(m.Data)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return nil
+}
+
+func (m *ResourceUsageResponse) GetPeers() []*ResourceScopeUsage {
+	if m != nil {
+		return m.Peers
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return nil
+}
+
+// current usage of a resource manager scope, along with its limits
+type ResourceScopeUsage struct {
+	// service or protocol name, or peer ID
+	Name                 *string  `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Memory               *int64   `protobuf:"varint,2,opt,name=memory" json:"memory,omitempty"`
+	MemoryLimit          *int64   `protobuf:"varint,3,opt,name=memoryLimit" json:"memoryLimit,omitempty"`
+	StreamsInbound       *int64   `protobuf:"varint,4,opt,name=streamsInbound" json:"streamsInbound,omitempty"`
+	StreamsInboundLimit  *int64   `protobuf:"varint,5,opt,name=streamsInboundLimit" json:"streamsInboundLimit,omitempty"`
+	StreamsOutbound      *int64   `protobuf:"varint,6,opt,name=streamsOutbound" json:"streamsOutbound,omitempty"`
+	StreamsOutboundLimit *int64   `protobuf:"varint,7,opt,name=streamsOutboundLimit" json:"streamsOutboundLimit,omitempty"`
+	ConnsInbound         *int64   `protobuf:"varint,8,opt,name=connsInbound" json:"connsInbound,omitempty"`
+	ConnsInboundLimit    *int64   `protobuf:"varint,9,opt,name=connsInboundLimit" json:"connsInboundLimit,omitempty"`
+	ConnsOutbound        *int64   `protobuf:"varint,10,opt,name=connsOutbound" json:"connsOutbound,omitempty"`
+	ConnsOutboundLimit   *int64   `protobuf:"varint,11,opt,name=connsOutboundLimit" json:"connsOutboundLimit,omitempty"`
+	Fd                   *int64   `protobuf:"varint,12,opt,name=fd" json:"fd,omitempty"`
+	FdLimit              *int64   `protobuf:"varint,13,opt,name=fdLimit" json:"fdLimit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResourceScopeUsage) Reset()         { *m = ResourceScopeUsage{} }
+func (m *ResourceScopeUsage) String() string { return proto.CompactTextString(m) }
+func (*ResourceScopeUsage) ProtoMessage()    {}
+func (*ResourceScopeUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_7333f0e9b622f7df, []int{46}
+}
+func (m *ResourceScopeUsage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResourceScopeUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResourceScopeUsage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *ResourceScopeUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceScopeUsage.Merge(m, src)
+}
+func (m *ResourceScopeUsage) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResourceScopeUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceScopeUsage.DiscardUnknown(m)
 }
 
-func (m *PSMessage) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_ResourceScopeUsage proto.InternalMessageInfo
+
+func (m *ResourceScopeUsage) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
 	}
-	var l int
-	_ = l
-	if m.From != nil {
-		l = len(m.From)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return ""
+}
+
+func (m *ResourceScopeUsage) GetMemory() int64 {
+	if m != nil && m.Memory != nil {
+		return *m.Memory
 	}
-	if m.Data != nil {
-		l = len(m.Data)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return 0
+}
+
+func (m *ResourceScopeUsage) GetMemoryLimit() int64 {
+	if m != nil && m.MemoryLimit != nil {
+		return *m.MemoryLimit
 	}
-	if m.Seqno != nil {
-		l = len(m.Seqno)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return 0
+}
+
+func (m *ResourceScopeUsage) GetStreamsInbound() int64 {
+	if m != nil && m.StreamsInbound != nil {
+		return *m.StreamsInbound
 	}
-	if len(m.TopicIDs) > 0 {
-		for _, s := range m.TopicIDs {
-			l = len(s)
-			n += 1 + l + sovP2Pd(uint64(l))
-		}
+	return 0
+}
+
+func (m *ResourceScopeUsage) GetStreamsInboundLimit() int64 {
+	if m != nil && m.StreamsInboundLimit != nil {
+		return *m.StreamsInboundLimit
 	}
-	if m.Signature != nil {
-		l = len(m.Signature)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return 0
+}
+
+func (m *ResourceScopeUsage) GetStreamsOutbound() int64 {
+	if m != nil && m.StreamsOutbound != nil {
+		return *m.StreamsOutbound
 	}
-	if m.Key != nil {
-		l = len(m.Key)
-		n += 1 + l + sovP2Pd(uint64(l))
+	return 0
+}
+
+func (m *ResourceScopeUsage) GetStreamsOutboundLimit() int64 {
+	if m != nil && m.StreamsOutboundLimit != nil {
+		return *m.StreamsOutboundLimit
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return 0
+}
+
+func (m *ResourceScopeUsage) GetConnsInbound() int64 {
+	if m != nil && m.ConnsInbound != nil {
+		return *m.ConnsInbound
 	}
-	return n
+	return 0
 }
 
-func (m *PSResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ResourceScopeUsage) GetConnsInboundLimit() int64 {
+	if m != nil && m.ConnsInboundLimit != nil {
+		return *m.ConnsInboundLimit
+	}
+	return 0
+}
+
+func (m *ResourceScopeUsage) GetConnsOutbound() int64 {
+	if m != nil && m.ConnsOutbound != nil {
+		return *m.ConnsOutbound
+	}
+	return 0
+}
+
+func (m *ResourceScopeUsage) GetConnsOutboundLimit() int64 {
+	if m != nil && m.ConnsOutboundLimit != nil {
+		return *m.ConnsOutboundLimit
+	}
+	return 0
+}
+
+func (m *ResourceScopeUsage) GetFd() int64 {
+	if m != nil && m.Fd != nil {
+		return *m.Fd
+	}
+	return 0
+}
+
+func (m *ResourceScopeUsage) GetFdLimit() int64 {
+	if m != nil && m.FdLimit != nil {
+		return *m.FdLimit
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("p2pd.pb.Reachability", Reachability_name, Reachability_value)
+	proto.RegisterEnum("p2pd.pb.Request_Type", Request_Type_name, Request_Type_value)
+	proto.RegisterEnum("p2pd.pb.Response_Type", Response_Type_name, Response_Type_value)
+	proto.RegisterEnum("p2pd.pb.ErrorResponse_Code", ErrorResponse_Code_name, ErrorResponse_Code_value)
+	proto.RegisterEnum("p2pd.pb.DHTRequest_Type", DHTRequest_Type_name, DHTRequest_Type_value)
+	proto.RegisterEnum("p2pd.pb.DHTResponse_Type", DHTResponse_Type_name, DHTResponse_Type_value)
+	proto.RegisterEnum("p2pd.pb.ConnManagerRequest_Type", ConnManagerRequest_Type_name, ConnManagerRequest_Type_value)
+	proto.RegisterEnum("p2pd.pb.ConnGaterRequest_Type", ConnGaterRequest_Type_name, ConnGaterRequest_Type_value)
+	proto.RegisterEnum("p2pd.pb.Event_Type", Event_Type_name, Event_Type_value)
+	proto.RegisterEnum("p2pd.pb.HolePunchEvent_Type", HolePunchEvent_Type_name, HolePunchEvent_Type_value)
+	proto.RegisterEnum("p2pd.pb.PSRequest_Type", PSRequest_Type_name, PSRequest_Type_value)
+	proto.RegisterType((*Request)(nil), "p2pd.pb.Request")
+	proto.RegisterType((*Response)(nil), "p2pd.pb.Response")
+	proto.RegisterType((*IdentifyResponse)(nil), "p2pd.pb.IdentifyResponse")
+	proto.RegisterType((*ConnectRequest)(nil), "p2pd.pb.ConnectRequest")
+	proto.RegisterType((*StreamOpenRequest)(nil), "p2pd.pb.StreamOpenRequest")
+	proto.RegisterType((*StreamHandlerRequest)(nil), "p2pd.pb.StreamHandlerRequest")
+	proto.RegisterType((*CancelRequest)(nil), "p2pd.pb.CancelRequest")
+	proto.RegisterType((*ErrorResponse)(nil), "p2pd.pb.ErrorResponse")
+	proto.RegisterType((*StreamInfo)(nil), "p2pd.pb.StreamInfo")
+	proto.RegisterType((*DHTRequest)(nil), "p2pd.pb.DHTRequest")
+	proto.RegisterType((*DHTResponse)(nil), "p2pd.pb.DHTResponse")
+	proto.RegisterType((*DHTStorageStats)(nil), "p2pd.pb.DHTStorageStats")
+	proto.RegisterType((*PeerInfo)(nil), "p2pd.pb.PeerInfo")
+	proto.RegisterType((*ConnManagerRequest)(nil), "p2pd.pb.ConnManagerRequest")
+	proto.RegisterType((*ConnGaterRequest)(nil), "p2pd.pb.ConnGaterRequest")
+	proto.RegisterType((*ConnGaterResponse)(nil), "p2pd.pb.ConnGaterResponse")
+	proto.RegisterType((*SignRequest)(nil), "p2pd.pb.SignRequest")
+	proto.RegisterType((*SignResponse)(nil), "p2pd.pb.SignResponse")
+	proto.RegisterType((*VerifyRequest)(nil), "p2pd.pb.VerifyRequest")
+	proto.RegisterType((*VerifyResponse)(nil), "p2pd.pb.VerifyResponse")
+	proto.RegisterType((*SealEnvelopeRequest)(nil), "p2pd.pb.SealEnvelopeRequest")
+	proto.RegisterType((*SealEnvelopeResponse)(nil), "p2pd.pb.SealEnvelopeResponse")
+	proto.RegisterType((*OpenEnvelopeRequest)(nil), "p2pd.pb.OpenEnvelopeRequest")
+	proto.RegisterType((*OpenEnvelopeResponse)(nil), "p2pd.pb.OpenEnvelopeResponse")
+	proto.RegisterType((*PeerRecordResponse)(nil), "p2pd.pb.PeerRecordResponse")
+	proto.RegisterType((*AddPeerRecordRequest)(nil), "p2pd.pb.AddPeerRecordRequest")
+	proto.RegisterType((*AddPeerRecordResponse)(nil), "p2pd.pb.AddPeerRecordResponse")
+	proto.RegisterType((*EventsRequest)(nil), "p2pd.pb.EventsRequest")
+	proto.RegisterType((*Event)(nil), "p2pd.pb.Event")
+	proto.RegisterType((*HolePunchEvent)(nil), "p2pd.pb.HolePunchEvent")
+	proto.RegisterType((*DirectConnectRequest)(nil), "p2pd.pb.DirectConnectRequest")
+	proto.RegisterType((*RelayReserveRequest)(nil), "p2pd.pb.RelayReserveRequest")
+	proto.RegisterType((*RelayCancelRequest)(nil), "p2pd.pb.RelayCancelRequest")
+	proto.RegisterType((*RelayReservation)(nil), "p2pd.pb.RelayReservation")
+	proto.RegisterType((*ReachabilityResponse)(nil), "p2pd.pb.ReachabilityResponse")
+	proto.RegisterType((*DisconnectRequest)(nil), "p2pd.pb.DisconnectRequest")
+	proto.RegisterType((*PSRequest)(nil), "p2pd.pb.PSRequest")
+	proto.RegisterType((*PSMessage)(nil), "p2pd.pb.PSMessage")
+	proto.RegisterType((*PSResponse)(nil), "p2pd.pb.PSResponse")
+	proto.RegisterType((*DiagnosticsResponse)(nil), "p2pd.pb.DiagnosticsResponse")
+	proto.RegisterType((*DiagnosticsBucket)(nil), "p2pd.pb.DiagnosticsBucket")
+	proto.RegisterType((*DiagnosticsConn)(nil), "p2pd.pb.DiagnosticsConn")
+	proto.RegisterType((*DiagnosticsStream)(nil), "p2pd.pb.DiagnosticsStream")
+	proto.RegisterType((*DiagnosticsTopic)(nil), "p2pd.pb.DiagnosticsTopic")
+	proto.RegisterType((*DiagnosticsHandler)(nil), "p2pd.pb.DiagnosticsHandler")
+	proto.RegisterType((*ResourceUsageResponse)(nil), "p2pd.pb.ResourceUsageResponse")
+	proto.RegisterType((*ResourceScopeUsage)(nil), "p2pd.pb.ResourceScopeUsage")
+}
+
+func init() { proto.RegisterFile("p2pd.proto", fileDescriptor_7333f0e9b622f7df) }
+
+var fileDescriptor_7333f0e9b622f7df = []byte{
+	// 3241 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x39, 0x4d, 0x73, 0xe3, 0xc6,
+	0xb1, 0x4b, 0x82, 0xa4, 0xc8, 0x26, 0x29, 0x41, 0x23, 0x69, 0x17, 0xb6, 0xf7, 0xed, 0x53, 0xa1,
+	0xfc, 0x21, 0x7f, 0x3c, 0x79, 0x2d, 0xaf, 0xcb, 0xcf, 0x76, 0xd9, 0x15, 0x8a, 0x84, 0x25, 0x66,
+	0x29, 0x52, 0x35, 0x80, 0x94, 0x6c, 0x55, 0x52, 0x0a, 0x44, 0xce, 0x4a, 0xc8, 0x52, 0x04, 0x0d,
+	0x80, 0x72, 0xe4, 0x4b, 0x0e, 0xc9, 0x21, 0x55, 0xa9, 0x54, 0x4e, 0x39, 0xe7, 0xe6, 0x54, 0xe5,
+	0x90, 0x4b, 0x72, 0xc8, 0x2f, 0x48, 0xe5, 0x98, 0x43, 0x7e, 0x40, 0xca, 0xe7, 0xfc, 0x81, 0xdc,
+	0x52, 0x3d, 0x33, 0x00, 0x06, 0x20, 0x56, 0xeb, 0x5c, 0x72, 0x43, 0xf7, 0x74, 0xf7, 0xf4, 0xf4,
+	0xf4, 0xf4, 0x17, 0x00, 0xe6, 0x7b, 0xf3, 0xc9, 0xee, 0x3c, 0xf0, 0x23, 0x9f, 0xac, 0x88, 0xef,
+	0x73, 0xf3, 0x8f, 0x2d, 0x58, 0xa1, 0xec, 0x8b, 0x05, 0x0b, 0x23, 0xf2, 0x26, 0x54, 0xa2, 0x9b,
+	0x39, 0x33, 0x4a, 0xdb, 0xe5, 0x9d, 0xd5, 0xbd, 0xad, 0x5d, 0x49, 0xb3, 0x2b, 0xd7, 0x77, 0x9d,
+	0x9b, 0x39, 0xa3, 0x9c, 0x84, 0xbc, 0x07, 0x2b, 0x63, 0x7f, 0x36, 0x63, 0xe3, 0xc8, 0x28, 0x6f,
+	0x97, 0x76, 0x9a, 0x7b, 0xf7, 0x12, 0xea, 0xae, 0xc0, 0x4b, 0x26, 0x1a, 0xd3, 0x91, 0x8f, 0x01,
+	0xc2, 0x28, 0x60, 0xee, 0xd5, 0x68, 0xce, 0x66, 0x86, 0xc6, 0xb9, 0x5e, 0x4e, 0xb8, 0xec, 0x64,
+	0x29, 0x66, 0x54, 0xa8, 0x49, 0x17, 0xda, 0x02, 0x3a, 0x74, 0x67, 0x93, 0x29, 0x0b, 0x8c, 0x0a,
+	0x67, 0xff, 0x9f, 0x1c, 0xbb, 0x5c, 0x8d, 0x25, 0x64, 0x79, 0xc8, 0x6b, 0xa0, 0x4d, 0x2e, 0x23,
+	0xa3, 0xca, 0x59, 0x37, 0x12, 0xd6, 0xde, 0xa1, 0x13, 0x33, 0xe0, 0x3a, 0xf9, 0x14, 0x9a, 0xa8,
+	0xf2, 0x91, 0x3b, 0x73, 0x2f, 0x58, 0x60, 0xd4, 0x38, 0xf9, 0x2b, 0x99, 0xe3, 0xc9, 0xb5, 0x98,
+	0x4d, 0xa5, 0xc7, 0x63, 0x4e, 0xbc, 0x30, 0x36, 0xce, 0x4a, 0xee, 0x98, 0xbd, 0x64, 0x29, 0x39,
+	0x66, 0x4a, 0x4d, 0xde, 0x82, 0xda, 0x7c, 0x71, 0x1e, 0x2e, 0xce, 0x8d, 0x3a, 0xe7, 0x23, 0x09,
+	0xdf, 0xb1, 0x1d, 0xd3, 0x4b, 0x0a, 0xb2, 0x0d, 0xcd, 0x28, 0x70, 0xc7, 0x6c, 0xee, 0x06, 0x6c,
+	0x16, 0x19, 0x8d, 0xed, 0xd2, 0x4e, 0x83, 0xaa, 0x28, 0xf2, 0x00, 0x80, 0x83, 0x61, 0xe4, 0x46,
+	0xcc, 0x00, 0x4e, 0xa0, 0x60, 0xc8, 0x2a, 0x94, 0xbd, 0x89, 0xd1, 0xdc, 0x2e, 0xed, 0x54, 0x68,
+	0xd9, 0x9b, 0x90, 0x5d, 0xa8, 0x8d, 0xdd, 0xd9, 0x98, 0x4d, 0x8d, 0x16, 0xdf, 0xfd, 0x6e, 0x7a,
+	0x66, 0x8e, 0x4e, 0x34, 0x10, 0x54, 0xe4, 0x43, 0x68, 0xa0, 0xe2, 0x07, 0x6e, 0xc4, 0x02, 0xa3,
+	0xcd, 0x59, 0x5e, 0xca, 0x98, 0x89, 0xaf, 0xc4, 0x5c, 0x29, 0x2d, 0xd9, 0x81, 0x4a, 0xe8, 0x5d,
+	0xcc, 0x8c, 0x55, 0xce, 0xb3, 0x99, 0x5e, 0xa2, 0x77, 0x91, 0xdc, 0x3e, 0xa7, 0x40, 0x95, 0xae,
+	0x59, 0xe0, 0x3d, 0xbd, 0x31, 0xd6, 0x72, 0x2a, 0x9d, 0x72, 0x74, 0xa2, 0x92, 0xa0, 0x22, 0xdf,
+	0x81, 0x56, 0xc8, 0xdc, 0xa9, 0x35, 0xbb, 0x66, 0x53, 0x7f, 0xce, 0x0c, 0x9d, 0x73, 0xdd, 0x4f,
+	0x77, 0x50, 0x16, 0x63, 0xde, 0x0c, 0x07, 0x4a, 0xf0, 0xe7, 0x6c, 0x96, 0x48, 0x58, 0xcf, 0x49,
+	0x18, 0x29, 0x8b, 0x89, 0x04, 0x95, 0x03, 0x7d, 0xd5, 0x9d, 0x4c, 0x8e, 0x19, 0x1e, 0x7d, 0xec,
+	0x07, 0x13, 0x83, 0xe4, 0x7c, 0xb5, 0xa3, 0xae, 0x26, 0xbe, 0x9a, 0xe1, 0xc1, 0x83, 0xb3, 0x6b,
+	0x36, 0x8b, 0x42, 0x63, 0x23, 0x77, 0x70, 0x8b, 0xa3, 0x93, 0x83, 0x0b, 0x2a, 0xdc, 0x74, 0xe2,
+	0x05, 0x6c, 0x1c, 0xc9, 0xd7, 0x67, 0x6c, 0xe6, 0x36, 0xed, 0xa9, 0xab, 0xc9, 0xa6, 0x19, 0x1e,
+	0x3c, 0x7b, 0xc0, 0xa6, 0xee, 0x0d, 0x65, 0x21, 0x0b, 0xae, 0x99, 0xb1, 0x95, 0x3b, 0x3b, 0x55,
+	0x16, 0x93, 0xb3, 0xab, 0x1c, 0xf8, 0x76, 0x38, 0x2c, 0x1c, 0xc6, 0xb8, 0x9b, 0x7b, 0x3b, 0x34,
+	0x5d, 0x4b, 0xde, 0x8e, 0x42, 0x6f, 0x7e, 0xad, 0x41, 0x05, 0x83, 0x0c, 0x69, 0x41, 0xbd, 0xdf,
+	0xb3, 0x86, 0x4e, 0xff, 0xf3, 0x27, 0xfa, 0x1d, 0xd2, 0x84, 0x95, 0xee, 0x68, 0x38, 0xb4, 0xba,
+	0x8e, 0x5e, 0x22, 0x6b, 0xd0, 0xb4, 0x1d, 0x6a, 0x75, 0x8e, 0xce, 0x46, 0xc7, 0xd6, 0x50, 0x2f,
+	0x13, 0x02, 0xab, 0x12, 0x71, 0xd8, 0x19, 0xf6, 0x06, 0x16, 0xd5, 0x35, 0xb2, 0x02, 0x5a, 0xef,
+	0xd0, 0xd1, 0x2b, 0x64, 0x15, 0x60, 0xd0, 0xb7, 0x9d, 0xb3, 0x63, 0xcb, 0xa2, 0xb6, 0x5e, 0x45,
+	0x6e, 0x14, 0x75, 0xd4, 0x19, 0x76, 0x0e, 0x2c, 0xaa, 0xd7, 0x90, 0xa0, 0xd7, 0xb7, 0x63, 0xf1,
+	0x2b, 0x04, 0xa0, 0x76, 0x7c, 0xb2, 0x6f, 0x9f, 0xec, 0xeb, 0x75, 0x24, 0xee, 0xf5, 0x3b, 0x07,
+	0xc3, 0x91, 0xed, 0xf4, 0xbb, 0xb6, 0xde, 0xc0, 0xad, 0xa8, 0x65, 0x8f, 0x4e, 0x68, 0xd7, 0x3a,
+	0x3b, 0xb1, 0x3b, 0x07, 0x96, 0x0e, 0xc8, 0xd0, 0xed, 0x0c, 0xbb, 0xd6, 0x40, 0x6f, 0x92, 0x36,
+	0x34, 0x50, 0xd2, 0x41, 0xc7, 0xb1, 0xa8, 0xde, 0x22, 0x75, 0xa8, 0xd8, 0xfd, 0x83, 0xa1, 0xde,
+	0x46, 0xa2, 0x53, 0x8b, 0xe2, 0x69, 0x56, 0xc9, 0x3a, 0xb4, 0x6d, 0xab, 0x33, 0x38, 0xb3, 0x86,
+	0xa7, 0xd6, 0x60, 0x74, 0x6c, 0xe9, 0x6b, 0x88, 0xc2, 0xc3, 0xa4, 0x28, 0x1d, 0xf7, 0x46, 0x9d,
+	0xcf, 0xa8, 0xd5, 0x1d, 0xd1, 0x9e, 0xbe, 0x4e, 0x36, 0x60, 0xad, 0xd3, 0xeb, 0x9d, 0xa9, 0x48,
+	0x82, 0x72, 0xad, 0x53, 0x6b, 0xe8, 0xd8, 0xfa, 0x06, 0x2a, 0xd7, 0xeb, 0x53, 0xab, 0xeb, 0x9c,
+	0xc5, 0xa7, 0xd9, 0x44, 0xc1, 0xd4, 0x1a, 0x74, 0x9e, 0x9c, 0x51, 0xcb, 0xb6, 0xe8, 0xa9, 0xa5,
+	0x6f, 0x91, 0x57, 0xe0, 0x9e, 0x40, 0x71, 0xbb, 0x08, 0x7c, 0xc7, 0xe9, 0x8f, 0x86, 0xb6, 0x7e,
+	0x97, 0xe8, 0xd0, 0x12, 0x8b, 0xf2, 0x48, 0xf7, 0xc8, 0x26, 0xe8, 0x07, 0x16, 0xd2, 0x75, 0xba,
+	0x87, 0x9d, 0xfd, 0xfe, 0xa0, 0xef, 0x3c, 0xd1, 0x0d, 0xf3, 0xf7, 0x75, 0xa8, 0x53, 0x16, 0xce,
+	0xfd, 0x59, 0xc8, 0xc8, 0x5b, 0x99, 0xb4, 0x71, 0x57, 0xb9, 0x6d, 0x41, 0xa0, 0xe6, 0x8d, 0x77,
+	0xa0, 0xca, 0x82, 0xc0, 0x0f, 0x64, 0xd6, 0x50, 0xdc, 0x1a, 0xb1, 0x31, 0x07, 0x15, 0x44, 0xe4,
+	0xfd, 0x38, 0x65, 0xf4, 0x67, 0x4f, 0x7d, 0x43, 0xcb, 0x05, 0x6e, 0x3b, 0x59, 0xa2, 0x0a, 0x19,
+	0xf9, 0x00, 0xea, 0xde, 0x84, 0xcd, 0x22, 0x8c, 0x1a, 0x95, 0x5c, 0x54, 0xea, 0xcb, 0x85, 0x64,
+	0xa3, 0x84, 0x94, 0xbc, 0xae, 0x66, 0x87, 0xcd, 0x6c, 0x76, 0x90, 0xc4, 0x48, 0x40, 0xde, 0x80,
+	0xea, 0x9c, 0xb1, 0x20, 0x34, 0x6a, 0xdb, 0xda, 0x4e, 0x73, 0x6f, 0x3d, 0x0d, 0xd1, 0x8c, 0x05,
+	0x5c, 0x19, 0xb1, 0x4e, 0xde, 0x4e, 0x82, 0xf9, 0x4a, 0x4e, 0xf1, 0x63, 0x3b, 0x11, 0x29, 0x49,
+	0xc8, 0x67, 0xd0, 0x9c, 0x78, 0xee, 0xc5, 0xcc, 0x0f, 0x23, 0x6f, 0x1c, 0x1a, 0xf5, 0xdc, 0xcb,
+	0xeb, 0xa5, 0x6b, 0x09, 0xab, 0xca, 0x40, 0x7a, 0xd0, 0x0e, 0x58, 0xe8, 0x2f, 0x82, 0x31, 0x3b,
+	0x09, 0xdd, 0x0b, 0xc6, 0xf3, 0x41, 0x73, 0xef, 0x81, 0x7a, 0x19, 0xe9, 0x6a, 0x22, 0x23, 0xcb,
+	0x24, 0x33, 0x02, 0x24, 0x19, 0xe1, 0xff, 0xd5, 0x08, 0xdf, 0xcc, 0xa5, 0x32, 0x25, 0xc2, 0x4b,
+	0x69, 0x29, 0x31, 0x96, 0x12, 0x3c, 0xc4, 0x8b, 0x4c, 0xb2, 0x95, 0x0b, 0xf1, 0x92, 0x9e, 0x93,
+	0x90, 0x77, 0x93, 0x18, 0xdf, 0xce, 0x55, 0x12, 0x71, 0x8c, 0x8f, 0x6d, 0x25, 0xc8, 0x48, 0x27,
+	0x17, 0xe4, 0x57, 0xf3, 0xb5, 0x40, 0x26, 0xc8, 0x4b, 0xe6, 0x0c, 0x0b, 0x8a, 0xc8, 0x44, 0xf9,
+	0xb5, 0x9c, 0x88, 0x6c, 0x94, 0x8f, 0x45, 0xa8, 0x2c, 0xe4, 0x13, 0x80, 0x79, 0x1a, 0xe3, 0xf5,
+	0x5c, 0xa4, 0x53, 0x03, 0xbc, 0x64, 0x57, 0xc8, 0xf1, 0xba, 0xb2, 0x39, 0x62, 0x3d, 0x77, 0x5d,
+	0xb9, 0x1c, 0x11, 0x5f, 0x57, 0x86, 0x89, 0x1c, 0xc0, 0xba, 0x12, 0x7d, 0xdd, 0xc8, 0xf3, 0x67,
+	0xa1, 0x41, 0xb6, 0xb5, 0x8c, 0xcb, 0xd3, 0x1c, 0x05, 0x5d, 0xe6, 0x41, 0x73, 0x04, 0xcc, 0x1d,
+	0x5f, 0xba, 0xe7, 0xde, 0xd4, 0x8b, 0x6e, 0x8c, 0x8d, 0x9c, 0x39, 0xa8, 0xb2, 0x98, 0x9a, 0x43,
+	0x65, 0x31, 0x5f, 0x92, 0x91, 0xbb, 0x06, 0xe5, 0xd1, 0x63, 0xfd, 0x0e, 0x69, 0x40, 0xd5, 0xa2,
+	0x74, 0x44, 0xf5, 0x92, 0xf9, 0x7d, 0xd0, 0xf3, 0xef, 0x4e, 0x7a, 0x1a, 0x46, 0x8c, 0x16, 0xf7,
+	0xb4, 0x4d, 0xa8, 0xba, 0x93, 0x49, 0x10, 0x1a, 0xe5, 0x6d, 0x6d, 0xa7, 0x45, 0x05, 0x80, 0x15,
+	0x8c, 0x62, 0x63, 0x7c, 0xff, 0x2d, 0xd5, 0x8c, 0xe6, 0x57, 0xb0, 0x9a, 0xcd, 0x68, 0x84, 0x40,
+	0x05, 0xd7, 0xa5, 0x64, 0xfe, 0xfd, 0x1c, 0xd9, 0x06, 0xac, 0x44, 0xde, 0x15, 0xf3, 0x17, 0x11,
+	0x17, 0xac, 0xd1, 0x18, 0x24, 0xaf, 0x42, 0x5b, 0x7e, 0x1e, 0x79, 0xd3, 0xa9, 0x17, 0xf2, 0x28,
+	0xa2, 0xd1, 0x2c, 0xd2, 0xfc, 0x75, 0x09, 0xd6, 0x97, 0x8a, 0xd6, 0xe7, 0xed, 0xcf, 0x8b, 0x6e,
+	0xbe, 0x7f, 0x83, 0x0a, 0xe0, 0x96, 0xfd, 0x75, 0xd0, 0xc2, 0xcb, 0x2b, 0xbe, 0x6b, 0x9d, 0xe2,
+	0xe7, 0xb2, 0x46, 0xd5, 0x22, 0x8d, 0x7e, 0x0c, 0x9b, 0x45, 0x65, 0x30, 0xea, 0x84, 0x47, 0x36,
+	0x4a, 0xdc, 0x7e, 0xfc, 0xfb, 0x39, 0x3a, 0xc9, 0x9d, 0xb5, 0x74, 0xe7, 0xbb, 0x50, 0x9b, 0xbb,
+	0x61, 0xf8, 0xf9, 0x44, 0xaa, 0x23, 0x21, 0xf3, 0x7f, 0xa1, 0x9d, 0xc9, 0xe3, 0xca, 0x85, 0xf2,
+	0xd0, 0x61, 0xfe, 0xa2, 0x04, 0xed, 0x4c, 0x4c, 0x47, 0xe1, 0x57, 0xe1, 0x05, 0x27, 0x69, 0x50,
+	0xfc, 0x24, 0xef, 0x42, 0x65, 0xec, 0x4f, 0x18, 0xcf, 0x05, 0xab, 0xca, 0xe3, 0xc9, 0xf0, 0xed,
+	0x76, 0xfd, 0x09, 0xa3, 0x9c, 0xd0, 0x7c, 0x04, 0x15, 0x84, 0xb0, 0x20, 0x38, 0x19, 0x3e, 0x1e,
+	0x8e, 0xbe, 0x37, 0xd4, 0xef, 0xf0, 0x9c, 0xd5, 0x71, 0xac, 0xb3, 0x41, 0xff, 0xa8, 0xef, 0x58,
+	0x3d, 0xbd, 0xc4, 0xd3, 0x30, 0xcf, 0x5f, 0x03, 0xab, 0xa7, 0x97, 0xcd, 0x09, 0x40, 0x9a, 0x2a,
+	0x0a, 0x6f, 0x28, 0xb6, 0x50, 0x59, 0xe0, 0xb2, 0x16, 0xd2, 0xb8, 0xc2, 0xe9, 0xad, 0x85, 0x97,
+	0x57, 0xb6, 0xf7, 0x15, 0xe3, 0x06, 0xa9, 0xd0, 0x18, 0x34, 0x7f, 0xa7, 0x01, 0xa4, 0xad, 0x04,
+	0x79, 0x27, 0x93, 0x14, 0x8d, 0x82, 0x6e, 0x43, 0x4d, 0x8b, 0xb1, 0x52, 0x65, 0x71, 0x45, 0x5c,
+	0x29, 0x1d, 0xb4, 0xb1, 0x17, 0x7b, 0x3d, 0x7e, 0x22, 0xe6, 0x19, 0x13, 0x49, 0xad, 0x45, 0xf1,
+	0x13, 0x95, 0xbc, 0x76, 0xa7, 0x0b, 0xc6, 0x1d, 0xa2, 0x45, 0x05, 0x80, 0xd8, 0xb1, 0xbf, 0x98,
+	0x45, 0xbc, 0x77, 0xa9, 0x52, 0x01, 0xa8, 0x0e, 0xb7, 0xf2, 0x02, 0x87, 0xaf, 0x17, 0xb9, 0xd7,
+	0x5f, 0x4a, 0xf2, 0x89, 0xb7, 0xa1, 0xf1, 0x79, 0x7f, 0x28, 0x4a, 0x11, 0xfd, 0x0e, 0xd9, 0x86,
+	0xfb, 0x09, 0x68, 0xc7, 0xb5, 0x87, 0xd5, 0x3b, 0x73, 0x46, 0x82, 0xa2, 0x84, 0x95, 0x89, 0xa0,
+	0xa0, 0xa3, 0xd3, 0x7e, 0x0f, 0x0b, 0xb1, 0x32, 0xd9, 0x82, 0x75, 0xac, 0x2b, 0xba, 0x83, 0x91,
+	0x6d, 0x25, 0xf5, 0x99, 0x86, 0xa4, 0x88, 0x3e, 0x3e, 0xd9, 0x1f, 0xf4, 0xbb, 0x67, 0x8f, 0xad,
+	0x27, 0x7a, 0x05, 0xf7, 0x43, 0xdc, 0x69, 0x67, 0x70, 0x62, 0xe9, 0x55, 0xbc, 0x6f, 0xdb, 0xea,
+	0xd0, 0xee, 0xa1, 0xc4, 0xd4, 0x90, 0xe0, 0xf8, 0x24, 0x26, 0x58, 0x41, 0xef, 0x90, 0x3b, 0xe9,
+	0x75, 0x5e, 0x6d, 0x39, 0x23, 0xda, 0x39, 0xb0, 0xce, 0x6c, 0xa7, 0xe3, 0xd8, 0x7a, 0xc3, 0xfc,
+	0x7b, 0x09, 0x9a, 0x4a, 0x5a, 0x27, 0xff, 0x97, 0xb9, 0xaa, 0x97, 0x8a, 0x52, 0xbf, 0x7a, 0x57,
+	0xaf, 0x29, 0x77, 0x55, 0x98, 0xff, 0x93, 0x57, 0x2f, 0xae, 0x46, 0x53, 0xaf, 0x66, 0x0f, 0x56,
+	0xc2, 0xc8, 0x0f, 0x30, 0x43, 0x8b, 0xda, 0x24, 0xe3, 0x19, 0xb6, 0x58, 0xb2, 0x23, 0x37, 0x0a,
+	0x69, 0x4c, 0x68, 0xbe, 0x26, 0xed, 0xde, 0x80, 0xea, 0xbe, 0x75, 0xd0, 0x1f, 0x8a, 0xe8, 0x2a,
+	0x4e, 0x5b, 0xc2, 0x52, 0xd7, 0x1a, 0xa2, 0x9b, 0x5f, 0xc1, 0x5a, 0x4e, 0x04, 0x5e, 0x79, 0xc0,
+	0x23, 0x65, 0xc8, 0x0f, 0xa7, 0xd1, 0x18, 0x24, 0xf7, 0xa1, 0x31, 0x0f, 0xfc, 0x6b, 0x6f, 0xc2,
+	0x78, 0x5c, 0xc4, 0xb5, 0x14, 0x41, 0x4c, 0x68, 0x49, 0x60, 0xf2, 0x98, 0xdd, 0x84, 0xfc, 0x09,
+	0x68, 0x34, 0x83, 0x33, 0x1f, 0x42, 0x3d, 0x3e, 0xf1, 0xb7, 0x8b, 0xe6, 0xe6, 0x9f, 0x4b, 0x40,
+	0x96, 0xbb, 0x67, 0xf2, 0x28, 0x63, 0xfe, 0xed, 0x5b, 0x1a, 0xed, 0x6f, 0xf1, 0x62, 0x22, 0xf7,
+	0x82, 0x1b, 0xbc, 0x41, 0xf1, 0x13, 0xc3, 0xd7, 0x97, 0xcc, 0xbb, 0xb8, 0x8c, 0x64, 0x0c, 0x97,
+	0x90, 0xb9, 0x9b, 0xf6, 0x19, 0x4e, 0xe7, 0x20, 0xf6, 0xe4, 0x55, 0x80, 0x93, 0x61, 0x02, 0x97,
+	0xb0, 0x7e, 0x77, 0x68, 0xff, 0x48, 0x2f, 0xa3, 0xcb, 0xe8, 0xf9, 0x8e, 0x96, 0xec, 0x65, 0x14,
+	0x7f, 0xf0, 0xdc, 0xd6, 0xf7, 0x45, 0x6a, 0xdf, 0x85, 0x5a, 0xb8, 0x38, 0x9f, 0xb1, 0x48, 0x6a,
+	0x2e, 0x21, 0xf3, 0x47, 0x52, 0xc9, 0x55, 0x80, 0xfd, 0xc1, 0xa8, 0xfb, 0x38, 0x56, 0x53, 0x87,
+	0xd6, 0xc9, 0x50, 0xc1, 0x94, 0x10, 0x23, 0x60, 0xfb, 0x64, 0x7f, 0x68, 0x39, 0xa2, 0x29, 0x3a,
+	0x19, 0x66, 0x70, 0x1a, 0x52, 0xf1, 0x9a, 0x9f, 0xa3, 0xad, 0x9e, 0x5e, 0x31, 0x7f, 0x56, 0x82,
+	0xf5, 0xa5, 0x32, 0x0e, 0x6f, 0xff, 0x7c, 0xea, 0x8f, 0x9f, 0x31, 0x5e, 0x6b, 0xa0, 0xeb, 0xe0,
+	0x25, 0x66, 0x70, 0xe4, 0x75, 0x58, 0x95, 0xb0, 0xcd, 0x95, 0x0d, 0x65, 0x22, 0xc9, 0x61, 0x51,
+	0x96, 0x3b, 0x9d, 0xfa, 0x5f, 0xc6, 0xb2, 0x34, 0x21, 0x4b, 0xc5, 0x99, 0x1f, 0x41, 0x53, 0xe9,
+	0xfc, 0xd1, 0x44, 0x13, 0x37, 0x72, 0xe3, 0x00, 0x8d, 0xdf, 0x68, 0xa2, 0x89, 0x7f, 0xe5, 0x7a,
+	0x33, 0x6e, 0xb8, 0x06, 0x95, 0x90, 0xf9, 0x5d, 0x68, 0xa9, 0x15, 0x25, 0xba, 0x35, 0xd6, 0x94,
+	0x6e, 0xb4, 0x08, 0x98, 0x14, 0x90, 0x22, 0x70, 0x75, 0xbe, 0x38, 0x9f, 0x7a, 0xe3, 0xc7, 0xec,
+	0x46, 0xc6, 0xfa, 0x14, 0x61, 0xfe, 0xb2, 0x04, 0xed, 0xcc, 0x54, 0xa1, 0x50, 0x93, 0xcc, 0x0e,
+	0xe5, 0xfc, 0x0e, 0xa9, 0x9e, 0x9a, 0xaa, 0x67, 0x72, 0xed, 0x15, 0xe5, 0xda, 0x33, 0xda, 0x88,
+	0xf8, 0xad, 0x68, 0xf3, 0x3a, 0xac, 0x66, 0xcb, 0x5f, 0x19, 0x50, 0xe4, 0x3b, 0xab, 0x53, 0x01,
+	0x98, 0x1e, 0x6c, 0x14, 0x0c, 0x35, 0x14, 0x45, 0x44, 0xbe, 0x8d, 0x15, 0xd9, 0x86, 0xe6, 0xdc,
+	0xbd, 0x99, 0xfa, 0xee, 0x04, 0x5d, 0x4b, 0x1e, 0x40, 0x45, 0x61, 0xcc, 0x90, 0x20, 0x7f, 0xf6,
+	0x2d, 0x1a, 0x83, 0xe6, 0x1e, 0x6c, 0x16, 0x95, 0xd6, 0xe4, 0x65, 0xa8, 0x33, 0x89, 0x93, 0xa6,
+	0x4a, 0x60, 0xb3, 0x0f, 0x1b, 0x05, 0x13, 0x93, 0xdb, 0x58, 0x32, 0x77, 0xad, 0xa8, 0x6e, 0xfe,
+	0xbc, 0x04, 0x9b, 0x45, 0x75, 0x79, 0x61, 0x46, 0xbf, 0xf5, 0xaa, 0xf3, 0x56, 0xd0, 0x6e, 0xb5,
+	0x42, 0x25, 0x6b, 0x85, 0x87, 0x40, 0x96, 0x2b, 0xf3, 0x5b, 0x6d, 0xd0, 0x83, 0xcd, 0xa2, 0x91,
+	0xcf, 0xad, 0x46, 0xc0, 0x50, 0x16, 0x4d, 0xb9, 0xb7, 0x6b, 0x14, 0x3f, 0xcd, 0x1f, 0xc2, 0x56,
+	0x61, 0x53, 0xf0, 0x1f, 0x94, 0xbc, 0x2f, 0x43, 0xdd, 0x1d, 0x8f, 0xd9, 0x3c, 0x62, 0xe2, 0x6e,
+	0xeb, 0x34, 0x81, 0xcd, 0x8f, 0xa1, 0x9d, 0x99, 0x2c, 0x91, 0x37, 0xa1, 0x8a, 0x11, 0x4b, 0x3c,
+	0xff, 0x55, 0xa5, 0x7b, 0xe5, 0x64, 0x22, 0xa6, 0x09, 0x0a, 0xf3, 0xb7, 0x65, 0xa8, 0x72, 0x2c,
+	0x79, 0x23, 0x13, 0x12, 0x0b, 0x79, 0x92, 0x38, 0x88, 0xd5, 0x85, 0x4c, 0x3d, 0xfc, 0x3b, 0x39,
+	0x88, 0xa6, 0x3c, 0x92, 0x0f, 0xa0, 0x71, 0xe9, 0x4f, 0xd9, 0xf1, 0x62, 0x36, 0xbe, 0x34, 0x2a,
+	0xb9, 0xfe, 0xf0, 0x30, 0x5e, 0xe1, 0xe2, 0x69, 0x4a, 0x49, 0x3e, 0xca, 0x35, 0x34, 0x55, 0x5e,
+	0x61, 0x6e, 0x15, 0x37, 0x34, 0x19, 0xd2, 0xd4, 0x74, 0x35, 0x35, 0x77, 0x7d, 0x9a, 0xc6, 0xe2,
+	0xc3, 0xd1, 0xc0, 0x3a, 0x3b, 0x3e, 0x19, 0x76, 0x0f, 0x65, 0xf1, 0xa9, 0x8e, 0x46, 0x4a, 0x38,
+	0xa7, 0xe1, 0x33, 0x1a, 0x3e, 0x55, 0x3a, 0xb5, 0x28, 0x2f, 0x41, 0xff, 0x54, 0x86, 0xd5, 0xac,
+	0xb6, 0xe4, 0x61, 0xc6, 0x54, 0xf7, 0x9f, 0x73, 0x28, 0xd5, 0x66, 0x58, 0x7b, 0x2e, 0xc6, 0x63,
+	0x16, 0x86, 0xdc, 0x2f, 0xea, 0x34, 0x06, 0x51, 0x67, 0x31, 0x55, 0x11, 0x51, 0x47, 0x00, 0x58,
+	0xd6, 0xb1, 0xa9, 0x3b, 0x0f, 0xd9, 0x24, 0xdb, 0xc7, 0x64, 0x90, 0xf8, 0x52, 0x82, 0x28, 0xdb,
+	0x57, 0xa4, 0x88, 0x62, 0x6b, 0xa0, 0x26, 0x6e, 0x14, 0xb1, 0xab, 0xb9, 0x28, 0x25, 0xab, 0x34,
+	0x06, 0xcd, 0x81, 0xb4, 0x13, 0x1f, 0x9d, 0xf1, 0x61, 0x54, 0xaf, 0xdf, 0x19, 0xe8, 0x77, 0x30,
+	0x21, 0x1d, 0xd3, 0x91, 0x33, 0xea, 0x8e, 0x06, 0x67, 0xb2, 0x31, 0xc4, 0x2a, 0xc6, 0x76, 0x3a,
+	0x14, 0xf3, 0x55, 0x13, 0x56, 0x3a, 0x8e, 0x63, 0x1d, 0x1d, 0x3b, 0xba, 0x16, 0x97, 0x34, 0x15,
+	0xec, 0x68, 0x8a, 0xe6, 0x96, 0x85, 0x2e, 0xaf, 0x94, 0xb7, 0xe5, 0x17, 0x94, 0xb7, 0x5a, 0x51,
+	0x79, 0xfb, 0x53, 0xd8, 0x28, 0x98, 0x6f, 0xfe, 0x17, 0x1b, 0xca, 0x1d, 0x20, 0xcb, 0xf3, 0xd1,
+	0xa2, 0xfd, 0xb1, 0x90, 0xd2, 0xf3, 0x6d, 0x3d, 0x2a, 0xc5, 0x1b, 0x7b, 0x49, 0x29, 0x00, 0xec,
+	0xa0, 0xd9, 0x4f, 0xe6, 0x5e, 0xc0, 0x69, 0xe4, 0x6b, 0x53, 0x30, 0xe9, 0x51, 0x34, 0xf5, 0x28,
+	0xaf, 0x42, 0x7b, 0xea, 0x5d, 0x79, 0x51, 0x6f, 0x21, 0x19, 0xa5, 0xc2, 0x19, 0x24, 0x7a, 0x8e,
+	0x40, 0x60, 0x8e, 0xac, 0xf2, 0x6e, 0x28, 0x45, 0xa4, 0x3e, 0x59, 0x53, 0x7c, 0xd2, 0xf4, 0x60,
+	0xb3, 0x68, 0x98, 0xb0, 0xf4, 0x60, 0x97, 0x7f, 0x41, 0x3d, 0xf7, 0xc1, 0xde, 0x85, 0xda, 0x53,
+	0x3f, 0x18, 0xb3, 0x89, 0x7c, 0x15, 0x12, 0x32, 0xdf, 0x80, 0xf5, 0xa5, 0xbf, 0x2d, 0x85, 0xe6,
+	0xfc, 0xba, 0x04, 0x8d, 0xe4, 0xff, 0x0a, 0x79, 0x3b, 0xf3, 0x2e, 0xef, 0x2d, 0xff, 0x81, 0x51,
+	0x9f, 0xe4, 0x26, 0x54, 0x23, 0x7f, 0xee, 0x8d, 0x65, 0x59, 0x22, 0x80, 0xa4, 0x6e, 0x90, 0x81,
+	0x0c, 0xbf, 0xcd, 0xfd, 0x34, 0x80, 0x60, 0x33, 0xe3, 0x8c, 0x8e, 0x71, 0xa4, 0x7c, 0x27, 0x37,
+	0xa0, 0x2e, 0xf1, 0xe6, 0x05, 0x9b, 0x1f, 0xfb, 0x50, 0x2f, 0x63, 0x63, 0x63, 0x9f, 0xec, 0xdb,
+	0x5d, 0xda, 0xdf, 0xb7, 0x74, 0xcd, 0xfc, 0x0d, 0x57, 0xf4, 0x88, 0x85, 0x7c, 0x58, 0x47, 0xa0,
+	0xf2, 0x34, 0xf0, 0xaf, 0xe2, 0xb6, 0x1e, 0xbf, 0x93, 0x9d, 0xcb, 0xe9, 0xce, 0xa8, 0x63, 0xc8,
+	0xbe, 0x98, 0xf9, 0x71, 0x23, 0xc2, 0x01, 0xcc, 0x05, 0x5c, 0xd9, 0x7e, 0x0f, 0xdd, 0x11, 0x4b,
+	0xb7, 0x04, 0xce, 0xd6, 0x38, 0xb2, 0x32, 0x49, 0x10, 0x71, 0x17, 0x5a, 0x4b, 0xba, 0x50, 0xf3,
+	0x33, 0x80, 0x74, 0xa4, 0x89, 0xf7, 0xc1, 0x25, 0x89, 0xcc, 0xd1, 0xa0, 0x12, 0xe2, 0x29, 0x15,
+	0x1b, 0x86, 0x5e, 0xfc, 0x6e, 0x62, 0xd0, 0xfc, 0x95, 0x06, 0x1b, 0x05, 0x13, 0x4e, 0xf2, 0x19,
+	0xb4, 0x02, 0x7f, 0x11, 0x79, 0xb3, 0x0b, 0xc7, 0x3d, 0x9f, 0x32, 0x2e, 0x2f, 0xfb, 0x33, 0x2d,
+	0xe1, 0xd9, 0x5f, 0x8c, 0x9f, 0xb1, 0x88, 0x66, 0xe8, 0xc9, 0x2e, 0xf6, 0xc1, 0xb3, 0x99, 0xd8,
+	0x2f, 0xd3, 0x6a, 0xa5, 0x8c, 0x18, 0x59, 0xa8, 0x20, 0x23, 0xef, 0x25, 0x9a, 0x6b, 0xb9, 0x21,
+	0x9a, 0xc2, 0xe0, 0x20, 0x45, 0x72, 0xa8, 0x0f, 0xa1, 0x7e, 0x29, 0xa6, 0x2d, 0xc2, 0x8c, 0xea,
+	0x0c, 0x50, 0x61, 0x8a, 0x27, 0x32, 0x09, 0x31, 0x3e, 0xcc, 0x0b, 0x5f, 0x68, 0xcb, 0xe2, 0xb8,
+	0xab, 0x60, 0xb0, 0xc0, 0x9e, 0x07, 0xde, 0xb5, 0x1b, 0xb1, 0x21, 0x8b, 0xbe, 0xf4, 0x83, 0x67,
+	0xdc, 0xe0, 0x75, 0x9a, 0xc3, 0x62, 0x81, 0x9d, 0xa4, 0x3d, 0x6f, 0x76, 0xc1, 0xe3, 0x71, 0x9d,
+	0x66, 0x70, 0x64, 0x17, 0x88, 0x0a, 0x77, 0xc6, 0x91, 0x77, 0xcd, 0x78, 0x93, 0x5f, 0xa7, 0x05,
+	0x2b, 0xe6, 0x27, 0xb0, 0xae, 0xe8, 0x2e, 0x4c, 0xcb, 0xc7, 0x11, 0xf3, 0x29, 0x7f, 0x16, 0x6d,
+	0x8a, 0x9f, 0x7c, 0x42, 0xc2, 0x58, 0x1a, 0x06, 0x39, 0x60, 0xfe, 0xab, 0x04, 0x6b, 0x39, 0xfb,
+	0x7e, 0xeb, 0x99, 0xcb, 0x7d, 0x68, 0x88, 0x3f, 0x52, 0x18, 0x73, 0x44, 0x2e, 0x4b, 0x11, 0xbc,
+	0x97, 0xb8, 0x60, 0xb3, 0xe8, 0x94, 0x05, 0x61, 0x1c, 0x94, 0x1a, 0x34, 0x83, 0x23, 0x3b, 0xb0,
+	0xc6, 0x07, 0x35, 0x63, 0x7f, 0x1a, 0x93, 0x55, 0x39, 0x59, 0x1e, 0x2d, 0x3b, 0x60, 0x8e, 0x12,
+	0xd9, 0xad, 0x41, 0x53, 0x04, 0x79, 0x84, 0x7d, 0x3a, 0xce, 0x8c, 0x42, 0x63, 0xe5, 0xf9, 0x5e,
+	0x27, 0xc6, 0x4a, 0x34, 0x26, 0x35, 0x0f, 0x60, 0x7d, 0x69, 0x35, 0x1d, 0x24, 0x95, 0x44, 0x8c,
+	0xe0, 0x40, 0xf6, 0xa8, 0xe5, 0xdc, 0x51, 0xcd, 0x1f, 0x80, 0x9e, 0x77, 0xb9, 0x34, 0xd6, 0x88,
+	0x8a, 0xbe, 0x1a, 0xc5, 0xd8, 0xe5, 0x4b, 0x40, 0xe9, 0x57, 0x2c, 0xbc, 0x54, 0x7b, 0xae, 0x14,
+	0x61, 0x5e, 0x02, 0x59, 0xf6, 0x4d, 0x55, 0x4f, 0x65, 0xe0, 0x95, 0x5e, 0x53, 0x3a, 0x3c, 0x4c,
+	0x87, 0x82, 0x9a, 0x3a, 0x14, 0x5c, 0x1e, 0x5c, 0x9a, 0x7f, 0x28, 0xc3, 0x56, 0xe1, 0x9f, 0x07,
+	0xf2, 0x3e, 0xd4, 0xc2, 0x9b, 0x30, 0x62, 0x57, 0x7c, 0xbb, 0xec, 0x4f, 0x42, 0x41, 0x6f, 0x8f,
+	0xfd, 0xb9, 0x64, 0x92, 0xa4, 0xe4, 0x23, 0x68, 0x44, 0x81, 0x3b, 0x0b, 0x3d, 0xfc, 0xe3, 0x5d,
+	0x7e, 0x31, 0x5f, 0x4a, 0x8d, 0x0f, 0x15, 0x73, 0xa5, 0x37, 0x66, 0xf1, 0xeb, 0xbe, 0x95, 0x33,
+	0x21, 0xc6, 0x3d, 0x53, 0x3f, 0xa9, 0xbc, 0x98, 0x33, 0xa5, 0x26, 0xef, 0xc5, 0x77, 0x53, 0x7d,
+	0x31, 0x9b, 0x7c, 0x3d, 0xff, 0xd4, 0x80, 0x2c, 0xaf, 0xe2, 0x2d, 0xcc, 0xdc, 0x2b, 0x26, 0x5d,
+	0x88, 0x7f, 0xe3, 0x2d, 0x5c, 0xb1, 0x2b, 0x3f, 0xb8, 0x91, 0xf5, 0x8e, 0x84, 0xb0, 0xb9, 0x11,
+	0x5f, 0x03, 0xcc, 0xc5, 0xb2, 0x16, 0x51, 0x51, 0x18, 0x5b, 0xa4, 0xc7, 0xf6, 0x67, 0xe7, 0xfe,
+	0x62, 0x36, 0x91, 0xf9, 0x3d, 0x87, 0x25, 0x0f, 0x61, 0x23, 0x8b, 0x11, 0x12, 0x45, 0xb0, 0x2a,
+	0x5a, 0xc2, 0xe7, 0x27, 0xd1, 0xa3, 0x45, 0x24, 0x44, 0xd7, 0x38, 0x75, 0x1e, 0x4d, 0xf6, 0x60,
+	0x33, 0x87, 0x12, 0xc2, 0xc5, 0x68, 0xb2, 0x70, 0x0d, 0x03, 0x00, 0x0f, 0xd4, 0xb1, 0xd6, 0x62,
+	0x4c, 0x99, 0xc1, 0x91, 0x77, 0x60, 0x5d, 0x85, 0x85, 0xd0, 0x06, 0x27, 0x5c, 0x5e, 0xc0, 0x42,
+	0x87, 0x23, 0x13, 0x6d, 0x41, 0x14, 0x3a, 0x19, 0x24, 0xc6, 0xcf, 0x0c, 0x42, 0x08, 0x6d, 0x72,
+	0xd2, 0x82, 0x15, 0x1c, 0x87, 0x3d, 0x9d, 0xf0, 0x5f, 0x5f, 0x1a, 0x2d, 0x3f, 0x9d, 0x60, 0xe6,
+	0x7b, 0x2a, 0x99, 0xda, 0x1c, 0x19, 0x83, 0x6f, 0x3d, 0x82, 0x96, 0x5a, 0xd9, 0x64, 0x07, 0xdb,
+	0xe2, 0x57, 0xf4, 0xa0, 0xdf, 0x95, 0x65, 0x01, 0xed, 0x9f, 0x76, 0x1c, 0x4b, 0x2f, 0xef, 0xb7,
+	0xfe, 0xfa, 0xcd, 0x83, 0xd2, 0xdf, 0xbe, 0x79, 0x50, 0xfa, 0xc7, 0x37, 0x0f, 0x4a, 0xff, 0x1e,
+	0x00, 0xac, 0xe3, 0x2d, 0x9f, 0xd7, 0x23, 0x00, 0x00,
+}
+
+func (m *Request) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *Request) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Request) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Topics) > 0 {
-		for _, s := range m.Topics {
-			l = len(s)
-			n += 1 + l + sovP2Pd(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.RelayCancel != nil {
+		{
+			size, err := m.RelayCancel.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb2
+	}
+	if m.RelayReserve != nil {
+		{
+			size, err := m.RelayReserve.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xaa
+	}
+	if m.DirectConnect != nil {
+		{
+			size, err := m.DirectConnect.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
+	if m.Events != nil {
+		{
+			size, err := m.Events.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	if m.AddPeerRecord != nil {
+		{
+			size, err := m.AddPeerRecord.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	if m.OpenEnvelope != nil {
+		{
+			size, err := m.OpenEnvelope.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if m.SealEnvelope != nil {
+		{
+			size, err := m.SealEnvelope.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if m.Verify != nil {
+		{
+			size, err := m.Verify.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	if m.Sign != nil {
+		{
+			size, err := m.Sign.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	if m.ConnGater != nil {
+		{
+			size, err := m.ConnGater.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.Cancel != nil {
+		{
+			size, err := m.Cancel.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	if m.Id != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Id))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.Tracestate != nil {
+		i -= len(*m.Tracestate)
+		copy(dAtA[i:], *m.Tracestate)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Tracestate)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if m.Traceparent != nil {
+		i -= len(*m.Traceparent)
+		copy(dAtA[i:], *m.Traceparent)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Traceparent)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.Pubsub != nil {
+		{
+			size, err := m.Pubsub.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Disconnect != nil {
+		{
+			size, err := m.Disconnect.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.ConnManager != nil {
+		{
+			size, err := m.ConnManager.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Dht != nil {
+		{
+			size, err := m.Dht.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.StreamHandler != nil {
+		{
+			size, err := m.StreamHandler.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.StreamOpen != nil {
+		{
+			size, err := m.StreamOpen.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Connect != nil {
+		{
+			size, err := m.Connect.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Response) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Response) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Response) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Reachability != nil {
+		{
+			size, err := m.Reachability.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	if len(m.RelayReservations) > 0 {
+		for iNdEx := len(m.RelayReservations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.RelayReservations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x92
+		}
+	}
+	if m.AddPeerRecord != nil {
+		{
+			size, err := m.AddPeerRecord.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if m.PeerRecord != nil {
+		{
+			size, err := m.PeerRecord.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if m.OpenEnvelope != nil {
+		{
+			size, err := m.OpenEnvelope.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	if m.SealEnvelope != nil {
+		{
+			size, err := m.SealEnvelope.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	if m.Verify != nil {
+		{
+			size, err := m.Verify.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.Sign != nil {
+		{
+			size, err := m.Sign.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	if m.ConnGater != nil {
+		{
+			size, err := m.ConnGater.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	if m.Id != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Id))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.ResourceUsage != nil {
+		{
+			size, err := m.ResourceUsage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.Diagnostics != nil {
+		{
+			size, err := m.Diagnostics.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Pubsub != nil {
+		{
+			size, err := m.Pubsub.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.Dht != nil {
+		{
+			size, err := m.Dht.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Identify != nil {
+		{
+			size, err := m.Identify.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.StreamInfo != nil {
+		{
+			size, err := m.StreamInfo.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Error != nil {
+		{
+			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *IdentifyResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IdentifyResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *IdentifyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.PeerRecord != nil {
+		i -= len(m.PeerRecord)
+		copy(dAtA[i:], m.PeerRecord)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.PeerRecord)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Id == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	} else {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnectRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.TimeoutMillis != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.TimeoutMillis))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Timeout != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamOpenRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamOpenRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamOpenRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.TimeoutMillis != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.TimeoutMillis))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Shm != nil {
+		i--
+		if *m.Shm {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Timeout != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Proto) > 0 {
+		for iNdEx := len(m.Proto) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Proto[iNdEx])
+			copy(dAtA[i:], m.Proto[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Proto[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamHandlerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamHandlerRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamHandlerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.PassFd != nil {
+		i--
+		if *m.PassFd {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Shm != nil {
+		i--
+		if *m.Shm {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Proto) > 0 {
+		for iNdEx := len(m.Proto) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Proto[iNdEx])
+			copy(dAtA[i:], m.Proto[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Proto[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Addr != nil {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CancelRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CancelRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CancelRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Id == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Id))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ErrorResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ErrorResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ErrorResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Code != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Code))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Msg == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("msg")
+	} else {
+		i -= len(*m.Msg)
+		copy(dAtA[i:], *m.Msg)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Msg)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ShmSize != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.ShmSize))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Proto == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	} else {
+		i -= len(*m.Proto)
+		copy(dAtA[i:], *m.Proto)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Proto)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Addr == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
+	} else {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DHTRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DHTRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DHTRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.TimeoutMillis != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.TimeoutMillis))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Timeout != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Count != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Count))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Value != nil {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Key != nil {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Cid != nil {
+		i -= len(m.Cid)
+		copy(dAtA[i:], m.Cid)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Cid)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Peer != nil {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DHTResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DHTResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DHTResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Storage != nil {
+		{
+			size, err := m.Storage.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Value != nil {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Peer != nil {
+		{
+			size, err := m.Peer.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DHTStorageStats) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DHTStorageStats) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DHTStorageStats) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ProvidedKeys == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("providedKeys")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.ProvidedKeys))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Providers == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("providers")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Providers))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Records == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("records")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Records))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Id == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	} else {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnManagerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnManagerRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnManagerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Weight != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Weight))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Tag != nil {
+		i -= len(*m.Tag)
+		copy(dAtA[i:], *m.Tag)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Tag)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Peer != nil {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnGaterRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnGaterRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnGaterRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Subnet != nil {
+		i -= len(*m.Subnet)
+		copy(dAtA[i:], *m.Subnet)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Subnet)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Peer != nil {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnGaterResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnGaterResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnGaterResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.AllowedPeers) > 0 {
+		for iNdEx := len(m.AllowedPeers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.AllowedPeers[iNdEx])
+			copy(dAtA[i:], m.AllowedPeers[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.AllowedPeers[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.BlockedSubnets) > 0 {
+		for iNdEx := len(m.BlockedSubnets) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.BlockedSubnets[iNdEx])
+			copy(dAtA[i:], m.BlockedSubnets[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.BlockedSubnets[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.BlockedPeers) > 0 {
+		for iNdEx := len(m.BlockedPeers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.BlockedPeers[iNdEx])
+			copy(dAtA[i:], m.BlockedPeers[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.BlockedPeers[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SignRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Domain != nil {
+		i -= len(*m.Domain)
+		copy(dAtA[i:], *m.Domain)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Domain)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Data == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("data")
+	} else {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SignResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.PublicKey == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("publicKey")
+	} else {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Signature == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("signature")
+	} else {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VerifyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VerifyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.PublicKey != nil {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Peer != nil {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Domain != nil {
+		i -= len(*m.Domain)
+		copy(dAtA[i:], *m.Domain)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Domain)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Signature == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("signature")
+	} else {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Data == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("data")
+	} else {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VerifyResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VerifyResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VerifyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Valid == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("valid")
+	} else {
+		i--
+		if *m.Valid {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SealEnvelopeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SealEnvelopeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SealEnvelopeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Payload == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("payload")
+	} else {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.PayloadType == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("payloadType")
+	} else {
+		i -= len(m.PayloadType)
+		copy(dAtA[i:], m.PayloadType)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.PayloadType)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Domain == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("domain")
+	} else {
+		i -= len(*m.Domain)
+		copy(dAtA[i:], *m.Domain)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Domain)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SealEnvelopeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SealEnvelopeResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SealEnvelopeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Envelope == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("envelope")
+	} else {
+		i -= len(m.Envelope)
+		copy(dAtA[i:], m.Envelope)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Envelope)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *OpenEnvelopeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OpenEnvelopeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OpenEnvelopeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Domain == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("domain")
+	} else {
+		i -= len(*m.Domain)
+		copy(dAtA[i:], *m.Domain)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Domain)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Envelope == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("envelope")
+	} else {
+		i -= len(m.Envelope)
+		copy(dAtA[i:], m.Envelope)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Envelope)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *OpenEnvelopeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *OpenEnvelopeResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *OpenEnvelopeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Payload == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("payload")
+	} else {
+		i -= len(m.Payload)
+		copy(dAtA[i:], m.Payload)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Payload)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.PayloadType == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("payloadType")
+	} else {
+		i -= len(m.PayloadType)
+		copy(dAtA[i:], m.PayloadType)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.PayloadType)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.PublicKey == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("publicKey")
+	} else {
+		i -= len(m.PublicKey)
+		copy(dAtA[i:], m.PublicKey)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.PublicKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PeerRecordResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PeerRecordResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PeerRecordResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Envelope == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("envelope")
+	} else {
+		i -= len(m.Envelope)
+		copy(dAtA[i:], m.Envelope)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Envelope)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AddPeerRecordRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddPeerRecordRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddPeerRecordRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Ttl != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Ttl))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Envelope == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("envelope")
+	} else {
+		i -= len(m.Envelope)
+		copy(dAtA[i:], m.Envelope)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Envelope)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AddPeerRecordResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddPeerRecordResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddPeerRecordResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Accepted == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("accepted")
+	} else {
+		i--
+		if *m.Accepted {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EventsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Types) > 0 {
+		for iNdEx := len(m.Types) - 1; iNdEx >= 0; iNdEx-- {
+			i = encodeVarintP2Pd(dAtA, i, uint64(m.Types[iNdEx]))
+			i--
+			dAtA[i] = 0x8
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Event) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Event) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.Reachability != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Reachability))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.HolePunch != nil {
+		{
+			size, err := m.HolePunch.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Peer != nil {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Time == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("time")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Time))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HolePunchEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HolePunchEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HolePunchEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Attempt != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Attempt))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.RttMillis != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.RttMillis))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.ElapsedMillis != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.ElapsedMillis))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Error != nil {
+		i -= len(*m.Error)
+		copy(dAtA[i:], *m.Error)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Error)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Success != nil {
+		i--
+		if *m.Success {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DirectConnectRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DirectConnectRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DirectConnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.TimeoutMillis != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.TimeoutMillis))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Timeout != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RelayReserveRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RelayReserveRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RelayReserveRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.TimeoutMillis != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.TimeoutMillis))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Timeout != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Timeout))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RelayCancelRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RelayCancelRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RelayCancelRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RelayReservation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RelayReservation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RelayReservation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Error != nil {
+		i -= len(*m.Error)
+		copy(dAtA[i:], *m.Error)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Error)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.LimitData != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.LimitData))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.LimitDuration != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.LimitDuration))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Addrs) > 0 {
+		for iNdEx := len(m.Addrs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addrs[iNdEx])
+			copy(dAtA[i:], m.Addrs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addrs[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Expiration == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("expiration")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Expiration))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Relay == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("relay")
+	} else {
+		i -= len(m.Relay)
+		copy(dAtA[i:], m.Relay)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Relay)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReachabilityResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReachabilityResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReachabilityResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Forced != nil {
+		i--
+		if *m.Forced {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Reachability == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("reachability")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Reachability))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DisconnectRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DisconnectRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DisconnectRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PSRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PSRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PSRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Data != nil {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Topic != nil {
+		i -= len(*m.Topic)
+		copy(dAtA[i:], *m.Topic)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Topic)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PSMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PSMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PSMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Key != nil {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Signature != nil {
+		i -= len(m.Signature)
+		copy(dAtA[i:], m.Signature)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Signature)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.TopicIDs) > 0 {
+		for iNdEx := len(m.TopicIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.TopicIDs[iNdEx])
+			copy(dAtA[i:], m.TopicIDs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.TopicIDs[iNdEx])))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.Seqno != nil {
+		i -= len(m.Seqno)
+		copy(dAtA[i:], m.Seqno)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Seqno)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Data != nil {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.From != nil {
+		i -= len(m.From)
+		copy(dAtA[i:], m.From)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.From)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PSResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PSResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PSResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.PeerIDs) > 0 {
+		for iNdEx := len(m.PeerIDs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.PeerIDs[iNdEx])
+			copy(dAtA[i:], m.PeerIDs[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.PeerIDs[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Topics) > 0 {
+		for iNdEx := len(m.Topics) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Topics[iNdEx])
+			copy(dAtA[i:], m.Topics[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Topics[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DiagnosticsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DiagnosticsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DiagnosticsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.HolePunchingActive != nil {
+		i--
+		if *m.HolePunchingActive {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.HolePunching != nil {
+		i--
+		if *m.HolePunching {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.PrivateNetwork != nil {
+		i--
+		if *m.PrivateNetwork {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Goroutines != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Goroutines))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Handlers) > 0 {
+		for iNdEx := len(m.Handlers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Handlers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Topics) > 0 {
+		for iNdEx := len(m.Topics) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Topics[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Conns) > 0 {
+		for iNdEx := len(m.Conns) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Conns[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.RoutingTable) > 0 {
+		for iNdEx := len(m.RoutingTable) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.RoutingTable[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DiagnosticsBucket) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DiagnosticsBucket) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DiagnosticsBucket) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Peers[iNdEx])
+			copy(dAtA[i:], m.Peers[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peers[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Cpl == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("cpl")
+	} else {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Cpl))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DiagnosticsConn) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DiagnosticsConn) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DiagnosticsConn) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Streams) > 0 {
+		for iNdEx := len(m.Streams) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Streams[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.Protocols) > 0 {
+		for iNdEx := len(m.Protocols) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Protocols[iNdEx])
+			copy(dAtA[i:], m.Protocols[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Protocols[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if m.ProtocolVersion != nil {
+		i -= len(*m.ProtocolVersion)
+		copy(dAtA[i:], *m.ProtocolVersion)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.ProtocolVersion)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.AgentVersion != nil {
+		i -= len(*m.AgentVersion)
+		copy(dAtA[i:], *m.AgentVersion)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.AgentVersion)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Direction != nil {
+		i -= len(*m.Direction)
+		copy(dAtA[i:], *m.Direction)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Direction)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Addr == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
+	} else {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Peer == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	} else {
+		i -= len(m.Peer)
+		copy(dAtA[i:], m.Peer)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peer)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DiagnosticsStream) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DiagnosticsStream) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DiagnosticsStream) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Direction != nil {
+		i -= len(*m.Direction)
+		copy(dAtA[i:], *m.Direction)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Direction)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Proto != nil {
+		i -= len(*m.Proto)
+		copy(dAtA[i:], *m.Proto)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Proto)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DiagnosticsTopic) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DiagnosticsTopic) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DiagnosticsTopic) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.MeshPeers) > 0 {
+		for iNdEx := len(m.MeshPeers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.MeshPeers[iNdEx])
+			copy(dAtA[i:], m.MeshPeers[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.MeshPeers[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Peers[iNdEx])
+			copy(dAtA[i:], m.Peers[iNdEx])
+			i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Peers[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Topic == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("topic")
+	} else {
+		i -= len(*m.Topic)
+		copy(dAtA[i:], *m.Topic)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Topic)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DiagnosticsHandler) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DiagnosticsHandler) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DiagnosticsHandler) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Shm != nil {
+		i--
+		if *m.Shm {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.PassFd != nil {
+		i--
+		if *m.PassFd {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Addr != nil {
+		i -= len(m.Addr)
+		copy(dAtA[i:], m.Addr)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(m.Addr)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Proto == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	} else {
+		i -= len(*m.Proto)
+		copy(dAtA[i:], *m.Proto)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Proto)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResourceUsageResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResourceUsageResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResourceUsageResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Peers) > 0 {
+		for iNdEx := len(m.Peers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Peers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Protocols) > 0 {
+		for iNdEx := len(m.Protocols) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Protocols[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Services) > 0 {
+		for iNdEx := len(m.Services) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Services[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintP2Pd(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Transient == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("transient")
+	} else {
+		{
+			size, err := m.Transient.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.System == nil {
+		return 0, github_com_gogo_protobuf_proto.NewRequiredNotSetError("system")
+	} else {
+		{
+			size, err := m.System.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintP2Pd(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResourceScopeUsage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResourceScopeUsage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResourceScopeUsage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.FdLimit != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.FdLimit))
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.Fd != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Fd))
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.ConnsOutboundLimit != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.ConnsOutboundLimit))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.ConnsOutbound != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.ConnsOutbound))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.ConnsInboundLimit != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.ConnsInboundLimit))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.ConnsInbound != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.ConnsInbound))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.StreamsOutboundLimit != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.StreamsOutboundLimit))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.StreamsOutbound != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.StreamsOutbound))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.StreamsInboundLimit != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.StreamsInboundLimit))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.StreamsInbound != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.StreamsInbound))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MemoryLimit != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.MemoryLimit))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Memory != nil {
+		i = encodeVarintP2Pd(dAtA, i, uint64(*m.Memory))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Name != nil {
+		i -= len(*m.Name)
+		copy(dAtA[i:], *m.Name)
+		i = encodeVarintP2Pd(dAtA, i, uint64(len(*m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintP2Pd(dAtA []byte, offset int, v uint64) int {
+	offset -= sovP2Pd(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Request) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Connect != nil {
+		l = m.Connect.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.StreamOpen != nil {
+		l = m.StreamOpen.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.StreamHandler != nil {
+		l = m.StreamHandler.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Dht != nil {
+		l = m.Dht.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.ConnManager != nil {
+		l = m.ConnManager.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Disconnect != nil {
+		l = m.Disconnect.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Pubsub != nil {
+		l = m.Pubsub.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Traceparent != nil {
+		l = len(*m.Traceparent)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Tracestate != nil {
+		l = len(*m.Tracestate)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Id != nil {
+		n += 1 + sovP2Pd(uint64(*m.Id))
+	}
+	if m.Cancel != nil {
+		l = m.Cancel.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.ConnGater != nil {
+		l = m.ConnGater.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Sign != nil {
+		l = m.Sign.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Verify != nil {
+		l = m.Verify.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.SealEnvelope != nil {
+		l = m.SealEnvelope.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.OpenEnvelope != nil {
+		l = m.OpenEnvelope.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.AddPeerRecord != nil {
+		l = m.AddPeerRecord.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.Events != nil {
+		l = m.Events.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.DirectConnect != nil {
+		l = m.DirectConnect.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.RelayReserve != nil {
+		l = m.RelayReserve.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.RelayCancel != nil {
+		l = m.RelayCancel.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Response) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.StreamInfo != nil {
+		l = m.StreamInfo.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Identify != nil {
+		l = m.Identify.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Dht != nil {
+		l = m.Dht.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Peers) > 0 {
+		for _, e := range m.Peers {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Pubsub != nil {
+		l = m.Pubsub.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Diagnostics != nil {
+		l = m.Diagnostics.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.ResourceUsage != nil {
+		l = m.ResourceUsage.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Id != nil {
+		n += 1 + sovP2Pd(uint64(*m.Id))
+	}
+	if m.ConnGater != nil {
+		l = m.ConnGater.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Sign != nil {
+		l = m.Sign.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Verify != nil {
+		l = m.Verify.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.SealEnvelope != nil {
+		l = m.SealEnvelope.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.OpenEnvelope != nil {
+		l = m.OpenEnvelope.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.PeerRecord != nil {
+		l = m.PeerRecord.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.AddPeerRecord != nil {
+		l = m.AddPeerRecord.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.RelayReservations) > 0 {
+		for _, e := range m.RelayReservations {
+			l = e.Size()
+			n += 2 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Reachability != nil {
+		l = m.Reachability.Size()
+		n += 2 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *IdentifyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = len(m.Id)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.PeerRecord != nil {
+		l = len(m.PeerRecord)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConnectRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Timeout != nil {
+		n += 1 + sovP2Pd(uint64(*m.Timeout))
+	}
+	if m.TimeoutMillis != nil {
+		n += 1 + sovP2Pd(uint64(*m.TimeoutMillis))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StreamOpenRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Proto) > 0 {
+		for _, s := range m.Proto {
+			l = len(s)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Timeout != nil {
+		n += 1 + sovP2Pd(uint64(*m.Timeout))
+	}
+	if m.Shm != nil {
+		n += 2
+	}
+	if m.TimeoutMillis != nil {
+		n += 1 + sovP2Pd(uint64(*m.TimeoutMillis))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StreamHandlerRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Addr != nil {
+		l = len(m.Addr)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Proto) > 0 {
+		for _, s := range m.Proto {
+			l = len(s)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Shm != nil {
+		n += 2
+	}
+	if m.PassFd != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CancelRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		n += 1 + sovP2Pd(uint64(*m.Id))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ErrorResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Msg != nil {
+		l = len(*m.Msg)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Code != nil {
+		n += 1 + sovP2Pd(uint64(*m.Code))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StreamInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Addr != nil {
+		l = len(m.Addr)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Proto != nil {
+		l = len(*m.Proto)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.ShmSize != nil {
+		n += 1 + sovP2Pd(uint64(*m.ShmSize))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DHTRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Cid != nil {
+		l = len(m.Cid)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Key != nil {
+		l = len(m.Key)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Value != nil {
+		l = len(m.Value)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Count != nil {
+		n += 1 + sovP2Pd(uint64(*m.Count))
+	}
+	if m.Timeout != nil {
+		n += 1 + sovP2Pd(uint64(*m.Timeout))
+	}
+	if m.TimeoutMillis != nil {
+		n += 1 + sovP2Pd(uint64(*m.TimeoutMillis))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DHTResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Peer != nil {
+		l = m.Peer.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Value != nil {
+		l = len(m.Value)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Storage != nil {
+		l = m.Storage.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DHTStorageStats) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Records != nil {
+		n += 1 + sovP2Pd(uint64(*m.Records))
+	}
+	if m.Providers != nil {
+		n += 1 + sovP2Pd(uint64(*m.Providers))
+	}
+	if m.ProvidedKeys != nil {
+		n += 1 + sovP2Pd(uint64(*m.ProvidedKeys))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = len(m.Id)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConnManagerRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Tag != nil {
+		l = len(*m.Tag)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Weight != nil {
+		n += 1 + sovP2Pd(uint64(*m.Weight))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConnGaterRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Subnet != nil {
+		l = len(*m.Subnet)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConnGaterResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.BlockedPeers) > 0 {
+		for _, b := range m.BlockedPeers {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.BlockedSubnets) > 0 {
+		for _, s := range m.BlockedSubnets {
+			l = len(s)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.AllowedPeers) > 0 {
+		for _, b := range m.AllowedPeers {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SignRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Data != nil {
+		l = len(m.Data)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Domain != nil {
+		l = len(*m.Domain)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SignResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Signature != nil {
+		l = len(m.Signature)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.PublicKey != nil {
+		l = len(m.PublicKey)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *VerifyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Data != nil {
+		l = len(m.Data)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Signature != nil {
+		l = len(m.Signature)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Domain != nil {
+		l = len(*m.Domain)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.PublicKey != nil {
+		l = len(m.PublicKey)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *VerifyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Valid != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SealEnvelopeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Domain != nil {
+		l = len(*m.Domain)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.PayloadType != nil {
+		l = len(m.PayloadType)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Payload != nil {
+		l = len(m.Payload)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SealEnvelopeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Envelope != nil {
+		l = len(m.Envelope)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *OpenEnvelopeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Envelope != nil {
+		l = len(m.Envelope)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Domain != nil {
+		l = len(*m.Domain)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *OpenEnvelopeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.PublicKey != nil {
+		l = len(m.PublicKey)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.PayloadType != nil {
+		l = len(m.PayloadType)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Payload != nil {
+		l = len(m.Payload)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PeerRecordResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Envelope != nil {
+		l = len(m.Envelope)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AddPeerRecordRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Envelope != nil {
+		l = len(m.Envelope)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Ttl != nil {
+		n += 1 + sovP2Pd(uint64(*m.Ttl))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *AddPeerRecordResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Accepted != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *EventsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Types) > 0 {
+		for _, e := range m.Types {
+			n += 1 + sovP2Pd(uint64(e))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Event) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Time != nil {
+		n += 1 + sovP2Pd(uint64(*m.Time))
+	}
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.HolePunch != nil {
+		l = m.HolePunch.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Reachability != nil {
+		n += 1 + sovP2Pd(uint64(*m.Reachability))
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *HolePunchEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Success != nil {
+		n += 2
+	}
+	if m.Error != nil {
+		l = len(*m.Error)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.ElapsedMillis != nil {
+		n += 1 + sovP2Pd(uint64(*m.ElapsedMillis))
+	}
+	if m.RttMillis != nil {
+		n += 1 + sovP2Pd(uint64(*m.RttMillis))
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Attempt != nil {
+		n += 1 + sovP2Pd(uint64(*m.Attempt))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DirectConnectRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Timeout != nil {
+		n += 1 + sovP2Pd(uint64(*m.Timeout))
+	}
+	if m.TimeoutMillis != nil {
+		n += 1 + sovP2Pd(uint64(*m.TimeoutMillis))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RelayReserveRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Timeout != nil {
+		n += 1 + sovP2Pd(uint64(*m.Timeout))
+	}
+	if m.TimeoutMillis != nil {
+		n += 1 + sovP2Pd(uint64(*m.TimeoutMillis))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RelayCancelRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RelayReservation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Relay != nil {
+		l = len(m.Relay)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Expiration != nil {
+		n += 1 + sovP2Pd(uint64(*m.Expiration))
+	}
+	if len(m.Addrs) > 0 {
+		for _, b := range m.Addrs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.LimitDuration != nil {
+		n += 1 + sovP2Pd(uint64(*m.LimitDuration))
+	}
+	if m.LimitData != nil {
+		n += 1 + sovP2Pd(uint64(*m.LimitData))
+	}
+	if m.Error != nil {
+		l = len(*m.Error)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReachabilityResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Reachability != nil {
+		n += 1 + sovP2Pd(uint64(*m.Reachability))
+	}
+	if m.Forced != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DisconnectRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PSRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != nil {
+		n += 1 + sovP2Pd(uint64(*m.Type))
+	}
+	if m.Topic != nil {
+		l = len(*m.Topic)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Data != nil {
+		l = len(m.Data)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PSMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.From != nil {
+		l = len(m.From)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Data != nil {
+		l = len(m.Data)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Seqno != nil {
+		l = len(m.Seqno)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.TopicIDs) > 0 {
+		for _, s := range m.TopicIDs {
+			l = len(s)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Signature != nil {
+		l = len(m.Signature)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Key != nil {
+		l = len(m.Key)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PSResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Topics) > 0 {
+		for _, s := range m.Topics {
+			l = len(s)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.PeerIDs) > 0 {
+		for _, b := range m.PeerIDs {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DiagnosticsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.RoutingTable) > 0 {
+		for _, e := range m.RoutingTable {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.Conns) > 0 {
+		for _, e := range m.Conns {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.Topics) > 0 {
+		for _, e := range m.Topics {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.Handlers) > 0 {
+		for _, e := range m.Handlers {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.Goroutines != nil {
+		n += 1 + sovP2Pd(uint64(*m.Goroutines))
+	}
+	if m.PrivateNetwork != nil {
+		n += 2
+	}
+	if m.HolePunching != nil {
+		n += 2
+	}
+	if m.HolePunchingActive != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DiagnosticsBucket) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Cpl != nil {
+		n += 1 + sovP2Pd(uint64(*m.Cpl))
+	}
+	if len(m.Peers) > 0 {
+		for _, b := range m.Peers {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DiagnosticsConn) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Peer != nil {
+		l = len(m.Peer)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Addr != nil {
+		l = len(m.Addr)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Direction != nil {
+		l = len(*m.Direction)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.AgentVersion != nil {
+		l = len(*m.AgentVersion)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.ProtocolVersion != nil {
+		l = len(*m.ProtocolVersion)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Protocols) > 0 {
+		for _, s := range m.Protocols {
+			l = len(s)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.Streams) > 0 {
+		for _, e := range m.Streams {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DiagnosticsStream) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Proto != nil {
+		l = len(*m.Proto)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Direction != nil {
+		l = len(*m.Direction)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DiagnosticsTopic) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Topic != nil {
+		l = len(*m.Topic)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Peers) > 0 {
+		for _, b := range m.Peers {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.MeshPeers) > 0 {
+		for _, b := range m.MeshPeers {
+			l = len(b)
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DiagnosticsHandler) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Proto != nil {
+		l = len(*m.Proto)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Addr != nil {
+		l = len(m.Addr)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.PassFd != nil {
+		n += 2
+	}
+	if m.Shm != nil {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResourceUsageResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.System != nil {
+		l = m.System.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Transient != nil {
+		l = m.Transient.Size()
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if len(m.Services) > 0 {
+		for _, e := range m.Services {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.Protocols) > 0 {
+		for _, e := range m.Protocols {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if len(m.Peers) > 0 {
+		for _, e := range m.Peers {
+			l = e.Size()
+			n += 1 + l + sovP2Pd(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResourceScopeUsage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Name != nil {
+		l = len(*m.Name)
+		n += 1 + l + sovP2Pd(uint64(l))
+	}
+	if m.Memory != nil {
+		n += 1 + sovP2Pd(uint64(*m.Memory))
+	}
+	if m.MemoryLimit != nil {
+		n += 1 + sovP2Pd(uint64(*m.MemoryLimit))
+	}
+	if m.StreamsInbound != nil {
+		n += 1 + sovP2Pd(uint64(*m.StreamsInbound))
+	}
+	if m.StreamsInboundLimit != nil {
+		n += 1 + sovP2Pd(uint64(*m.StreamsInboundLimit))
+	}
+	if m.StreamsOutbound != nil {
+		n += 1 + sovP2Pd(uint64(*m.StreamsOutbound))
+	}
+	if m.StreamsOutboundLimit != nil {
+		n += 1 + sovP2Pd(uint64(*m.StreamsOutboundLimit))
+	}
+	if m.ConnsInbound != nil {
+		n += 1 + sovP2Pd(uint64(*m.ConnsInbound))
+	}
+	if m.ConnsInboundLimit != nil {
+		n += 1 + sovP2Pd(uint64(*m.ConnsInboundLimit))
+	}
+	if m.ConnsOutbound != nil {
+		n += 1 + sovP2Pd(uint64(*m.ConnsOutbound))
+	}
+	if m.ConnsOutboundLimit != nil {
+		n += 1 + sovP2Pd(uint64(*m.ConnsOutboundLimit))
+	}
+	if m.Fd != nil {
+		n += 1 + sovP2Pd(uint64(*m.Fd))
+	}
+	if m.FdLimit != nil {
+		n += 1 + sovP2Pd(uint64(*m.FdLimit))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovP2Pd(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozP2Pd(x uint64) (n int) {
+	return sovP2Pd(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Request) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Request: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var v Request_Type
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= Request_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Type = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Connect", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Connect == nil {
+				m.Connect = &ConnectRequest{}
+			}
+			if err := m.Connect.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamOpen", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StreamOpen == nil {
+				m.StreamOpen = &StreamOpenRequest{}
+			}
+			if err := m.StreamOpen.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamHandler", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StreamHandler == nil {
+				m.StreamHandler = &StreamHandlerRequest{}
+			}
+			if err := m.StreamHandler.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dht", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Dht == nil {
+				m.Dht = &DHTRequest{}
+			}
+			if err := m.Dht.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnManager", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConnManager == nil {
+				m.ConnManager = &ConnManagerRequest{}
+			}
+			if err := m.ConnManager.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Disconnect", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Disconnect == nil {
+				m.Disconnect = &DisconnectRequest{}
+			}
+			if err := m.Disconnect.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pubsub", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pubsub == nil {
+				m.Pubsub = &PSRequest{}
+			}
+			if err := m.Pubsub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Traceparent", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Traceparent = &s
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tracestate", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Tracestate = &s
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Id = &v
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cancel", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Cancel == nil {
+				m.Cancel = &CancelRequest{}
+			}
+			if err := m.Cancel.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnGater", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConnGater == nil {
+				m.ConnGater = &ConnGaterRequest{}
+			}
+			if err := m.ConnGater.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sign", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Sign == nil {
+				m.Sign = &SignRequest{}
+			}
+			if err := m.Sign.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Verify", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Verify == nil {
+				m.Verify = &VerifyRequest{}
+			}
+			if err := m.Verify.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SealEnvelope", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SealEnvelope == nil {
+				m.SealEnvelope = &SealEnvelopeRequest{}
+			}
+			if err := m.SealEnvelope.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OpenEnvelope", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.OpenEnvelope == nil {
+				m.OpenEnvelope = &OpenEnvelopeRequest{}
+			}
+			if err := m.OpenEnvelope.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddPeerRecord", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AddPeerRecord == nil {
+				m.AddPeerRecord = &AddPeerRecordRequest{}
+			}
+			if err := m.AddPeerRecord.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Events == nil {
+				m.Events = &EventsRequest{}
+			}
+			if err := m.Events.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DirectConnect", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DirectConnect == nil {
+				m.DirectConnect = &DirectConnectRequest{}
+			}
+			if err := m.DirectConnect.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelayReserve", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RelayReserve == nil {
+				m.RelayReserve = &RelayReserveRequest{}
+			}
+			if err := m.RelayReserve.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelayCancel", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RelayCancel == nil {
+				m.RelayCancel = &RelayCancelRequest{}
+			}
+			if err := m.RelayCancel.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Response) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var v Response_Type
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= Response_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Type = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &ErrorResponse{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.StreamInfo == nil {
+				m.StreamInfo = &StreamInfo{}
+			}
+			if err := m.StreamInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Identify", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Identify == nil {
+				m.Identify = &IdentifyResponse{}
+			}
+			if err := m.Identify.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dht", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Dht == nil {
+				m.Dht = &DHTResponse{}
+			}
+			if err := m.Dht.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peers = append(m.Peers, &PeerInfo{})
+			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pubsub", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pubsub == nil {
+				m.Pubsub = &PSResponse{}
+			}
+			if err := m.Pubsub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Diagnostics", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Diagnostics == nil {
+				m.Diagnostics = &DiagnosticsResponse{}
+			}
+			if err := m.Diagnostics.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceUsage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ResourceUsage == nil {
+				m.ResourceUsage = &ResourceUsageResponse{}
+			}
+			if err := m.ResourceUsage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Id = &v
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnGater", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConnGater == nil {
+				m.ConnGater = &ConnGaterResponse{}
+			}
+			if err := m.ConnGater.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sign", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Sign == nil {
+				m.Sign = &SignResponse{}
+			}
+			if err := m.Sign.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Verify", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Verify == nil {
+				m.Verify = &VerifyResponse{}
+			}
+			if err := m.Verify.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SealEnvelope", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SealEnvelope == nil {
+				m.SealEnvelope = &SealEnvelopeResponse{}
+			}
+			if err := m.SealEnvelope.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OpenEnvelope", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.OpenEnvelope == nil {
+				m.OpenEnvelope = &OpenEnvelopeResponse{}
+			}
+			if err := m.OpenEnvelope.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerRecord", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PeerRecord == nil {
+				m.PeerRecord = &PeerRecordResponse{}
+			}
+			if err := m.PeerRecord.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AddPeerRecord", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AddPeerRecord == nil {
+				m.AddPeerRecord = &AddPeerRecordResponse{}
+			}
+			if err := m.AddPeerRecord.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelayReservations", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RelayReservations = append(m.RelayReservations, &RelayReservation{})
+			if err := m.RelayReservations[len(m.RelayReservations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reachability", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Reachability == nil {
+				m.Reachability = &ReachabilityResponse{}
+			}
+			if err := m.Reachability.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IdentifyResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IdentifyResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IdentifyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
+			if m.Id == nil {
+				m.Id = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerRecord", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PeerRecord = append(m.PeerRecord[:0], dAtA[iNdEx:postIndex]...)
+			if m.PeerRecord == nil {
+				m.PeerRecord = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConnectRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Timeout = &v
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutMillis", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TimeoutMillis = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamOpenRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamOpenRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamOpenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Proto = append(m.Proto, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Timeout = &v
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shm", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Shm = &b
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutMillis", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TimeoutMillis = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamHandlerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamHandlerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamHandlerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addr == nil {
+				m.Addr = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Proto = append(m.Proto, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shm", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Shm = &b
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PassFd", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.PassFd = &b
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CancelRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CancelRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CancelRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Id = &v
+			hasFields[0] |= uint64(0x00000001)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ErrorResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ErrorResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ErrorResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Msg = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			var v ErrorResponse_Code
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= ErrorResponse_Code(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Code = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("msg")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StreamInfo) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StreamInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StreamInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addr == nil {
+				m.Addr = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Proto = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000004)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShmSize", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ShmSize = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DHTRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DHTRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DHTRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var v DHTRequest_Type
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= DHTRequest_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Type = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cid", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cid = append(m.Cid[:0], dAtA[iNdEx:postIndex]...)
+			if m.Cid == nil {
+				m.Cid = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Count = &v
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Timeout = &v
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutMillis", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TimeoutMillis = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DHTResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DHTResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DHTResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var v DHTResponse_Type
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= DHTResponse_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Type = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Peer == nil {
+				m.Peer = &PeerInfo{}
+			}
+			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Storage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Storage == nil {
+				m.Storage = &DHTStorageStats{}
+			}
+			if err := m.Storage.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DHTStorageStats) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DHTStorageStats: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DHTStorageStats: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Records", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Records = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Providers", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Providers = &v
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProvidedKeys", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ProvidedKeys = &v
+			hasFields[0] |= uint64(0x00000004)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("records")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("providers")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("providedKeys")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerInfo) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
+			if m.Id == nil {
+				m.Id = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnManagerRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConnManagerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConnManagerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var v ConnManagerRequest_Type
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= ConnManagerRequest_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Type = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tag", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Tag = &s
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Weight", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Weight = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnGaterRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConnGaterRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConnGaterRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var v ConnGaterRequest_Type
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= ConnGaterRequest_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Type = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subnet", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Subnet = &s
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConnGaterResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConnGaterResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConnGaterResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockedPeers", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BlockedPeers = append(m.BlockedPeers, make([]byte, postIndex-iNdEx))
+			copy(m.BlockedPeers[len(m.BlockedPeers)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockedSubnets", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BlockedSubnets = append(m.BlockedSubnets, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowedPeers", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AllowedPeers = append(m.AllowedPeers, make([]byte, postIndex-iNdEx))
+			copy(m.AllowedPeers[len(m.AllowedPeers)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Domain = &s
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("data")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("signature")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("publicKey")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerifyRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerifyRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerifyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Domain = &s
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("data")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("signature")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VerifyResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VerifyResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VerifyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Valid", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Valid = &b
+			hasFields[0] |= uint64(0x00000001)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("valid")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SealEnvelopeRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SealEnvelopeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SealEnvelopeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Domain = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PayloadType", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PayloadType = append(m.PayloadType[:0], dAtA[iNdEx:postIndex]...)
+			if m.PayloadType == nil {
+				m.PayloadType = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000004)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("domain")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("payloadType")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("payload")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SealEnvelopeResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SealEnvelopeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SealEnvelopeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Envelope", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Envelope = append(m.Envelope[:0], dAtA[iNdEx:postIndex]...)
+			if m.Envelope == nil {
+				m.Envelope = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("envelope")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OpenEnvelopeRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OpenEnvelopeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OpenEnvelopeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Envelope", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Envelope = append(m.Envelope[:0], dAtA[iNdEx:postIndex]...)
+			if m.Envelope == nil {
+				m.Envelope = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Domain", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Domain = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("envelope")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("domain")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OpenEnvelopeResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OpenEnvelopeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OpenEnvelopeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PublicKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PublicKey = append(m.PublicKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.PublicKey == nil {
+				m.PublicKey = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PayloadType", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PayloadType = append(m.PayloadType[:0], dAtA[iNdEx:postIndex]...)
+			if m.PayloadType == nil {
+				m.PayloadType = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000004)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000008)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("publicKey")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("payloadType")
+	}
+	if hasFields[0]&uint64(0x00000008) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("payload")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PeerRecordResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PeerRecordResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PeerRecordResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Envelope", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Envelope = append(m.Envelope[:0], dAtA[iNdEx:postIndex]...)
+			if m.Envelope == nil {
+				m.Envelope = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("envelope")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddPeerRecordRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddPeerRecordRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddPeerRecordRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Envelope", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Envelope = append(m.Envelope[:0], dAtA[iNdEx:postIndex]...)
+			if m.Envelope == nil {
+				m.Envelope = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ttl", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Ttl = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("envelope")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddPeerRecordResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddPeerRecordResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddPeerRecordResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Accepted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Accepted = &b
+			hasFields[0] |= uint64(0x00000002)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("accepted")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EventsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v Event_Type
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowP2Pd
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= Event_Type(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Types = append(m.Types, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowP2Pd
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthP2Pd
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthP2Pd
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				if elementCount != 0 && len(m.Types) == 0 {
+					m.Types = make([]Event_Type, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v Event_Type
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowP2Pd
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= Event_Type(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Types = append(m.Types, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Types", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Event) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Event: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Event: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var v Event_Type
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= Event_Type(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Type = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Time = &v
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HolePunch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HolePunch == nil {
+				m.HolePunch = &HolePunchEvent{}
+			}
+			if err := m.HolePunch.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reachability", wireType)
+			}
+			var v Reachability
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= Reachability(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Reachability = &v
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
 		}
 	}
-	if len(m.PeerIDs) > 0 {
-		for _, b := range m.PeerIDs {
-			l = len(b)
-			n += 1 + l + sovP2Pd(uint64(l))
-		}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("time")
 	}
-	return n
-}
 
-func sovP2Pd(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozP2Pd(x uint64) (n int) {
-	return sovP2Pd(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *Request) Unmarshal(dAtA []byte) error {
+func (m *HolePunchEvent) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -2897,17 +14456,17 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Request: wiretype end group for non-group")
+			return fmt.Errorf("proto: HolePunchEvent: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Request: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HolePunchEvent: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			var v Request_Type
+			var v HolePunchEvent_Type
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -2917,7 +14476,7 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= Request_Type(b&0x7F) << shift
+				v |= HolePunchEvent_Type(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2925,10 +14484,31 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 			m.Type = &v
 			hasFields[0] |= uint64(0x00000001)
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Success", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Success = &b
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Connect", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -2938,33 +14518,235 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Connect == nil {
-				m.Connect = &ConnectRequest{}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Error = &s
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ElapsedMillis", wireType)
 			}
-			if err := m.Connect.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ElapsedMillis = &v
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RttMillis", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RttMillis = &v
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attempt", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Attempt = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DirectConnectRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DirectConnectRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DirectConnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Timeout = &v
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StreamOpen", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutMillis", wireType)
 			}
-			var msglen int
+			var v int64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -2974,33 +14756,75 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			m.TimeoutMillis = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StreamOpen == nil {
-				m.StreamOpen = &StreamOpenRequest{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RelayReserveRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
 			}
-			if err := m.StreamOpen.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 4:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RelayReserveRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RelayReserveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StreamHandler", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -3010,33 +14834,32 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.StreamHandler == nil {
-				m.StreamHandler = &StreamHandlerRequest{}
-			}
-			if err := m.StreamHandler.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
 			}
 			iNdEx = postIndex
-		case 5:
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Dht", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -3046,33 +14869,29 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Dht == nil {
-				m.Dht = &DHTRequest{}
-			}
-			if err := m.Dht.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConnManager", wireType)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
 			}
-			var msglen int
+			var v int64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -3082,33 +14901,17 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ConnManager == nil {
-				m.ConnManager = &ConnManagerRequest{}
-			}
-			if err := m.ConnManager.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Disconnect", wireType)
+			m.Timeout = &v
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutMillis", wireType)
 			}
-			var msglen int
+			var v int64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -3118,33 +14921,75 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			m.TimeoutMillis = &v
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Disconnect == nil {
-				m.Disconnect = &DisconnectRequest{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RelayCancelRequest) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
 			}
-			if err := m.Disconnect.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 8:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RelayCancelRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RelayCancelRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pubsub", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -3154,28 +14999,27 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Pubsub == nil {
-				m.Pubsub = &PSRequest{}
-			}
-			if err := m.Pubsub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
 			}
 			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -3196,7 +15040,7 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
 	}
 
 	if iNdEx > l {
@@ -3204,7 +15048,7 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Response) Unmarshal(dAtA []byte) error {
+func (m *RelayReservation) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -3228,38 +15072,17 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Response: wiretype end group for non-group")
+			return fmt.Errorf("proto: RelayReservation: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Response: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			var v Response_Type
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= Response_Type(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Type = &v
-			hasFields[0] |= uint64(0x00000001)
-		case 2:
+			return fmt.Errorf("proto: RelayReservation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Relay", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -3269,33 +15092,32 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Error == nil {
-				m.Error = &ErrorResponse{}
-			}
-			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Relay = append(m.Relay[:0], dAtA[iNdEx:postIndex]...)
+			if m.Relay == nil {
+				m.Relay = []byte{}
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StreamInfo", wireType)
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Expiration", wireType)
 			}
-			var msglen int
+			var v int64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -3305,33 +15127,18 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.StreamInfo == nil {
-				m.StreamInfo = &StreamInfo{}
-			}
-			if err := m.StreamInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
+			m.Expiration = &v
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Identify", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -3341,33 +15148,29 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Identify == nil {
-				m.Identify = &IdentifyResponse{}
-			}
-			if err := m.Identify.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
+			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Dht", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LimitDuration", wireType)
 			}
-			var msglen int
+			var v int64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -3377,33 +15180,17 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Dht == nil {
-				m.Dht = &DHTResponse{}
-			}
-			if err := m.Dht.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
+			m.LimitDuration = &v
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LimitData", wireType)
 			}
-			var msglen int
+			var v uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -3413,31 +15200,17 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Peers = append(m.Peers, &PeerInfo{})
-			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 7:
+			m.LimitData = &v
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Pubsub", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -3447,27 +15220,24 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Pubsub == nil {
-				m.Pubsub = &PSResponse{}
-			}
-			if err := m.Pubsub.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Error = &s
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3489,7 +15259,10 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("relay")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("expiration")
 	}
 
 	if iNdEx > l {
@@ -3497,7 +15270,7 @@ func (m *Response) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *IdentifyResponse) Unmarshal(dAtA []byte) error {
+func (m *ReachabilityResponse) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -3521,17 +15294,17 @@ func (m *IdentifyResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IdentifyResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReachabilityResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IdentifyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReachabilityResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reachability", wireType)
 			}
-			var byteLen int
+			var v Reachability
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -3541,32 +15314,18 @@ func (m *IdentifyResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				v |= Reachability(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
-			if m.Id == nil {
-				m.Id = []byte{}
-			}
-			iNdEx = postIndex
+			m.Reachability = &v
 			hasFields[0] |= uint64(0x00000001)
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Forced", wireType)
 			}
-			var byteLen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -3576,24 +15335,13 @@ func (m *IdentifyResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
-			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			b := bool(v != 0)
+			m.Forced = &b
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -3614,7 +15362,7 @@ func (m *IdentifyResponse) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("reachability")
 	}
 
 	if iNdEx > l {
@@ -3622,7 +15370,7 @@ func (m *IdentifyResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
+func (m *DisconnectRequest) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -3646,84 +15394,17 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ConnectRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DisconnectRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
-			if m.Peer == nil {
-				m.Peer = []byte{}
-			}
-			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000001)
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
-			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			return fmt.Errorf("proto: DisconnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
-			var v int64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -3733,12 +15414,27 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Timeout = &v
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
+			}
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -3767,7 +15463,7 @@ func (m *ConnectRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StreamOpenRequest) Unmarshal(dAtA []byte) error {
+func (m *PSRequest) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -3791,17 +15487,17 @@ func (m *StreamOpenRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StreamOpenRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PSRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StreamOpenRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PSRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
 			}
-			var byteLen int
+			var v PSRequest_Type
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -3811,30 +15507,16 @@ func (m *StreamOpenRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				v |= PSRequest_Type(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
-			if m.Peer == nil {
-				m.Peer = []byte{}
-			}
-			iNdEx = postIndex
+			m.Type = &v
 			hasFields[0] |= uint64(0x00000001)
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3862,13 +15544,14 @@ func (m *StreamOpenRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Proto = append(m.Proto, string(dAtA[iNdEx:postIndex]))
+			s := string(dAtA[iNdEx:postIndex])
+			m.Topic = &s
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var v int64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -3878,12 +15561,26 @@ func (m *StreamOpenRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Timeout = &v
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -3904,7 +15601,7 @@ func (m *StreamOpenRequest) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
 	}
 
 	if iNdEx > l {
@@ -3912,8 +15609,7 @@ func (m *StreamOpenRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *StreamHandlerRequest) Unmarshal(dAtA []byte) error {
-	var hasFields [1]uint64
+func (m *PSMessage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3936,15 +15632,15 @@ func (m *StreamHandlerRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StreamHandlerRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PSMessage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StreamHandlerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PSMessage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -3971,15 +15667,82 @@ func (m *StreamHandlerRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
-			if m.Addr == nil {
-				m.Addr = []byte{}
+			m.From = append(m.From[:0], dAtA[iNdEx:postIndex]...)
+			if m.From == nil {
+				m.From = []byte{}
 			}
 			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000001)
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seqno", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Seqno = append(m.Seqno[:0], dAtA[iNdEx:postIndex]...)
+			if m.Seqno == nil {
+				m.Seqno = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TopicIDs", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4007,71 +15770,47 @@ func (m *StreamHandlerRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Proto = append(m.Proto, string(dAtA[iNdEx:postIndex]))
+			m.TopicIDs = append(m.TopicIDs, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipP2Pd(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthP2Pd
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ErrorResponse) Unmarshal(dAtA []byte) error {
-	var hasFields [1]uint64
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowP2Pd
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signature == nil {
+				m.Signature = []byte{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ErrorResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ErrorResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4081,26 +15820,26 @@ func (m *ErrorResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			s := string(dAtA[iNdEx:postIndex])
-			m.Msg = &s
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
+			}
 			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000001)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -4120,17 +15859,13 @@ func (m *ErrorResponse) Unmarshal(dAtA []byte) error {
 			iNdEx += skippy
 		}
 	}
-	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("msg")
-	}
 
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
 	return nil
 }
-func (m *StreamInfo) Unmarshal(dAtA []byte) error {
-	var hasFields [1]uint64
+func (m *PSResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4153,17 +15888,17 @@ func (m *StreamInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: StreamInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: PSResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: StreamInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PSResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Topics", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4173,30 +15908,27 @@ func (m *StreamInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
-			if m.Peer == nil {
-				m.Peer = []byte{}
-			}
+			m.Topics = append(m.Topics, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000001)
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PeerIDs", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -4223,46 +15955,9 @@ func (m *StreamInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
-			if m.Addr == nil {
-				m.Addr = []byte{}
-			}
-			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000002)
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			s := string(dAtA[iNdEx:postIndex])
-			m.Proto = &s
+			m.PeerIDs = append(m.PeerIDs, make([]byte, postIndex-iNdEx))
+			copy(m.PeerIDs[len(m.PeerIDs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000004)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -4282,23 +15977,13 @@ func (m *StreamInfo) Unmarshal(dAtA []byte) error {
 			iNdEx += skippy
 		}
 	}
-	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
-	}
-	if hasFields[0]&uint64(0x00000002) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
-	}
-	if hasFields[0]&uint64(0x00000004) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
-	}
 
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
 	return nil
 }
-func (m *DHTRequest) Unmarshal(dAtA []byte) error {
-	var hasFields [1]uint64
+func (m *DiagnosticsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4321,17 +16006,17 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DHTRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DiagnosticsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DHTRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DiagnosticsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RoutingTable", wireType)
 			}
-			var v DHTRequest_Type
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4341,18 +16026,31 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= DHTRequest_Type(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Type = &v
-			hasFields[0] |= uint64(0x00000001)
+			if msglen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RoutingTable = append(m.RoutingTable, &DiagnosticsBucket{})
+			if err := m.RoutingTable[len(m.RoutingTable)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Conns", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4362,31 +16060,31 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
-			if m.Peer == nil {
-				m.Peer = []byte{}
+			m.Conns = append(m.Conns, &DiagnosticsConn{})
+			if err := m.Conns[len(m.Conns)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Topics", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4396,31 +16094,31 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Cid = append(m.Cid[:0], dAtA[iNdEx:postIndex]...)
-			if m.Cid == nil {
-				m.Cid = []byte{}
+			m.Topics = append(m.Topics, &DiagnosticsTopic{})
+			if err := m.Topics[len(m.Topics)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Handlers", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4430,31 +16128,31 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
+			m.Handlers = append(m.Handlers, &DiagnosticsHandler{})
+			if err := m.Handlers[len(m.Handlers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Goroutines", wireType)
 			}
-			var byteLen int
+			var v int64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4464,31 +16162,17 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				v |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
-			}
-			iNdEx = postIndex
+			m.Goroutines = &v
 		case 6:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PrivateNetwork", wireType)
 			}
-			var v int32
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4498,17 +16182,18 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int32(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Count = &v
+			b := bool(v != 0)
+			m.PrivateNetwork = &b
 		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HolePunching", wireType)
 			}
-			var v int64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4518,12 +16203,34 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Timeout = &v
+			b := bool(v != 0)
+			m.HolePunching = &b
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HolePunchingActive", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.HolePunchingActive = &b
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -4543,16 +16250,13 @@ func (m *DHTRequest) Unmarshal(dAtA []byte) error {
 			iNdEx += skippy
 		}
 	}
-	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
-	}
 
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
 	return nil
 }
-func (m *DHTResponse) Unmarshal(dAtA []byte) error {
+func (m *DiagnosticsBucket) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -4576,38 +16280,17 @@ func (m *DHTResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DHTResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: DiagnosticsBucket: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DHTResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DiagnosticsBucket: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			var v DHTResponse_Type
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= DHTResponse_Type(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Type = &v
-			hasFields[0] |= uint64(0x00000001)
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Cpl", wireType)
 			}
-			var msglen int
+			var v uint32
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4616,32 +16299,17 @@ func (m *DHTResponse) Unmarshal(dAtA []byte) error {
 					return io.ErrUnexpectedEOF
 				}
 				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Peer == nil {
-				m.Peer = &PeerInfo{}
-			}
-			if err := m.Peer.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+				iNdEx++
+				v |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 3:
+			m.Cpl = &v
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -4668,10 +16336,8 @@ func (m *DHTResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
-			}
+			m.Peers = append(m.Peers, make([]byte, postIndex-iNdEx))
+			copy(m.Peers[len(m.Peers)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -4693,7 +16359,7 @@ func (m *DHTResponse) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("cpl")
 	}
 
 	if iNdEx > l {
@@ -4701,7 +16367,7 @@ func (m *DHTResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PeerInfo) Unmarshal(dAtA []byte) error {
+func (m *DiagnosticsConn) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -4725,15 +16391,15 @@ func (m *PeerInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PeerInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: DiagnosticsConn: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PeerInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DiagnosticsConn: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -4760,15 +16426,15 @@ func (m *PeerInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
-			if m.Id == nil {
-				m.Id = []byte{}
+			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
+			if m.Peer == nil {
+				m.Peer = []byte{}
 			}
 			iNdEx = postIndex
 			hasFields[0] |= uint64(0x00000001)
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addrs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -4795,72 +16461,116 @@ func (m *PeerInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addrs = append(m.Addrs, make([]byte, postIndex-iNdEx))
-			copy(m.Addrs[len(m.Addrs)-1], dAtA[iNdEx:postIndex])
+			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addr == nil {
+				m.Addr = []byte{}
+			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipP2Pd(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Direction", wireType)
 			}
-			if skippy < 0 {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ConnManagerRequest) Unmarshal(dAtA []byte) error {
-	var hasFields [1]uint64
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowP2Pd
+			s := string(dAtA[iNdEx:postIndex])
+			m.Direction = &s
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AgentVersion", wireType)
 			}
-			if iNdEx >= l {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			s := string(dAtA[iNdEx:postIndex])
+			m.AgentVersion = &s
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProtocolVersion", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ConnManagerRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ConnManagerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			var v ConnManagerRequest_Type
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.ProtocolVersion = &s
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Protocols", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4870,18 +16580,29 @@ func (m *ConnManagerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= ConnManagerRequest_Type(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Type = &v
-			hasFields[0] |= uint64(0x00000001)
-		case 2:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Protocols = append(m.Protocols, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Streams", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4891,29 +16612,89 @@ func (m *ConnManagerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
-			if m.Peer == nil {
-				m.Peer = []byte{}
+			m.Streams = append(m.Streams, &DiagnosticsStream{})
+			if err := m.Streams[len(m.Streams)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipP2Pd(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("addr")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DiagnosticsStream) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowP2Pd
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DiagnosticsStream: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DiagnosticsStream: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tag", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -4942,13 +16723,13 @@ func (m *ConnManagerRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			s := string(dAtA[iNdEx:postIndex])
-			m.Tag = &s
+			m.Proto = &s
 			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Weight", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Direction", wireType)
 			}
-			var v int64
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -4958,12 +16739,25 @@ func (m *ConnManagerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Weight = &v
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Direction = &s
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -4983,16 +16777,13 @@ func (m *ConnManagerRequest) Unmarshal(dAtA []byte) error {
 			iNdEx += skippy
 		}
 	}
-	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
-	}
 
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
 	return nil
 }
-func (m *DisconnectRequest) Unmarshal(dAtA []byte) error {
+func (m *DiagnosticsTopic) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -5016,15 +16807,49 @@ func (m *DisconnectRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DisconnectRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DiagnosticsTopic: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DisconnectRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DiagnosticsTopic: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Peer", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Topic = &s
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5051,12 +16876,41 @@ func (m *DisconnectRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Peer = append(m.Peer[:0], dAtA[iNdEx:postIndex]...)
-			if m.Peer == nil {
-				m.Peer = []byte{}
+			m.Peers = append(m.Peers, make([]byte, postIndex-iNdEx))
+			copy(m.Peers[len(m.Peers)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MeshPeers", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthP2Pd
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.MeshPeers = append(m.MeshPeers, make([]byte, postIndex-iNdEx))
+			copy(m.MeshPeers[len(m.MeshPeers)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-			hasFields[0] |= uint64(0x00000001)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -5077,7 +16931,7 @@ func (m *DisconnectRequest) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("peer")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("topic")
 	}
 
 	if iNdEx > l {
@@ -5085,7 +16939,7 @@ func (m *DisconnectRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PSRequest) Unmarshal(dAtA []byte) error {
+func (m *DiagnosticsHandler) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -5109,36 +16963,15 @@ func (m *PSRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PSRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: DiagnosticsHandler: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PSRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DiagnosticsHandler: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			var v PSRequest_Type
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= PSRequest_Type(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.Type = &v
-			hasFields[0] |= uint64(0x00000001)
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Proto", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5167,11 +17000,12 @@ func (m *PSRequest) Unmarshal(dAtA []byte) error {
 				return io.ErrUnexpectedEOF
 			}
 			s := string(dAtA[iNdEx:postIndex])
-			m.Topic = &s
+			m.Proto = &s
 			iNdEx = postIndex
-		case 3:
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Addr", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -5198,11 +17032,53 @@ func (m *PSRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
+			m.Addr = append(m.Addr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Addr == nil {
+				m.Addr = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PassFd", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.PassFd = &b
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Shm", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Shm = &b
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -5223,7 +17099,7 @@ func (m *PSRequest) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("type")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("proto")
 	}
 
 	if iNdEx > l {
@@ -5231,7 +17107,8 @@ func (m *PSRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PSMessage) Unmarshal(dAtA []byte) error {
+func (m *ResourceUsageResponse) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5254,17 +17131,17 @@ func (m *PSMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PSMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResourceUsageResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PSMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResourceUsageResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field System", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -5274,31 +17151,34 @@ func (m *PSMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.From = append(m.From[:0], dAtA[iNdEx:postIndex]...)
-			if m.From == nil {
-				m.From = []byte{}
+			if m.System == nil {
+				m.System = &ResourceScopeUsage{}
+			}
+			if err := m.System.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Transient", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -5308,65 +17188,34 @@ func (m *PSMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Seqno", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowP2Pd
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if m.Transient == nil {
+				m.Transient = &ResourceScopeUsage{}
 			}
-			m.Seqno = append(m.Seqno[:0], dAtA[iNdEx:postIndex]...)
-			if m.Seqno == nil {
-				m.Seqno = []byte{}
+			if err := m.Transient.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 4:
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TopicIDs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Services", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -5376,29 +17225,31 @@ func (m *PSMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			}
+			if msglen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TopicIDs = append(m.TopicIDs, string(dAtA[iNdEx:postIndex]))
+			m.Services = append(m.Services, &ResourceScopeUsage{})
+			if err := m.Services[len(m.Services)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 5:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Protocols", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -5408,31 +17259,31 @@ func (m *PSMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Signature = append(m.Signature[:0], dAtA[iNdEx:postIndex]...)
-			if m.Signature == nil {
-				m.Signature = []byte{}
+			m.Protocols = append(m.Protocols, &ResourceScopeUsage{})
+			if err := m.Protocols[len(m.Protocols)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Peers", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -5442,24 +17293,24 @@ func (m *PSMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthP2Pd
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthP2Pd
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
+			m.Peers = append(m.Peers, &ResourceScopeUsage{})
+			if err := m.Peers[len(m.Peers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -5481,13 +17332,19 @@ func (m *PSMessage) Unmarshal(dAtA []byte) error {
 			iNdEx += skippy
 		}
 	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("system")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("transient")
+	}
 
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
 	return nil
 }
-func (m *PSResponse) Unmarshal(dAtA []byte) error {
+func (m *ResourceScopeUsage) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5510,15 +17367,15 @@ func (m *PSResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PSResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResourceScopeUsage: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PSResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResourceScopeUsage: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Topics", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -5546,13 +17403,14 @@ func (m *PSResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Topics = append(m.Topics, string(dAtA[iNdEx:postIndex]))
+			s := string(dAtA[iNdEx:postIndex])
+			m.Name = &s
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PeerIDs", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Memory", wireType)
 			}
-			var byteLen int
+			var v int64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowP2Pd
@@ -5562,24 +17420,232 @@ func (m *PSResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				v |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthP2Pd
+			m.Memory = &v
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemoryLimit", wireType)
 			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthP2Pd
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.MemoryLimit = &v
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamsInbound", wireType)
 			}
-			m.PeerIDs = append(m.PeerIDs, make([]byte, postIndex-iNdEx))
-			copy(m.PeerIDs[len(m.PeerIDs)-1], dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StreamsInbound = &v
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamsInboundLimit", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StreamsInboundLimit = &v
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamsOutbound", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StreamsOutbound = &v
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamsOutboundLimit", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StreamsOutboundLimit = &v
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnsInbound", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ConnsInbound = &v
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnsInboundLimit", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ConnsInboundLimit = &v
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnsOutbound", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ConnsOutbound = &v
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnsOutboundLimit", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ConnsOutboundLimit = &v
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fd", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Fd = &v
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FdLimit", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowP2Pd
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FdLimit = &v
 		default:
 			iNdEx = preIndex
 			skippy, err := skipP2Pd(dAtA[iNdEx:])
@@ -0,0 +1,36 @@
+//go:build linux
+
+package p2pd
+
+import (
+	"net"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// peerCredentials returns the pid and uid of the process at the other end of
+// a unix socket control connection.
+func peerCredentials(c net.Conn) (pid, uid int, ok bool) {
+	if _, ok := c.RemoteAddr().(*net.UnixAddr); !ok {
+		return 0, 0, false
+	}
+	sc, ok := c.(syscall.Conn)
+	if !ok {
+		return 0, 0, false
+	}
+	raw, err := sc.SyscallConn()
+	if err != nil {
+		return 0, 0, false
+	}
+
+	var cred *unix.Ucred
+	var cerr error
+	err = raw.Control(func(fd uintptr) {
+		cred, cerr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	})
+	if err != nil || cerr != nil {
+		return 0, 0, false
+	}
+	return int(cred.Pid), int(cred.Uid), true
+}
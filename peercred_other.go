@@ -0,0 +1,11 @@
+//go:build !linux
+
+package p2pd
+
+import "net"
+
+// peerCredentials returns the pid and uid of the process at the other end of
+// a unix socket control connection; they aren't available on this platform.
+func peerCredentials(c net.Conn) (pid, uid int, ok bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,96 @@
+package p2pd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/core/record"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// peerRecordTTL is how long the addresses of a peer record added by a client
+// are kept when it doesn't set a TTL.
+var peerRecordTTL = peerstore.AddressTTL
+
+// localPeerRecord returns the marshaled envelope of the signed peer record
+// the host keeps of itself, or nil if it has none.
+func (d *Daemon) localPeerRecord() ([]byte, error) {
+	cab, ok := peerstore.GetCertifiedAddrBook(d.host.Peerstore())
+	if !ok {
+		return nil, nil
+	}
+	env := cab.GetPeerRecord(d.ID())
+	if env == nil {
+		return nil, nil
+	}
+	return env.Marshal()
+}
+
+func (d *Daemon) doPeerRecord(req *pb.Request) *pb.Response {
+	b, err := d.localPeerRecord()
+	if err != nil {
+		return errorResponse(err)
+	}
+	if b == nil {
+		return errorResponseString("daemon has no signed peer record")
+	}
+
+	res := okResponse()
+	res.PeerRecord = &pb.PeerRecordResponse{Envelope: b}
+	return res
+}
+
+func (d *Daemon) doAddPeerRecord(req *pb.Request) *pb.Response {
+	if req.AddPeerRecord == nil {
+		return errorResponseString("Malformed request; missing parameters")
+	}
+
+	ttl := peerRecordTTL
+	if req.AddPeerRecord.Ttl != nil {
+		if req.AddPeerRecord.GetTtl() <= 0 {
+			return errorResponseString("Malformed request; ttl must be positive")
+		}
+		ttl = time.Duration(req.AddPeerRecord.GetTtl()) * time.Second
+	}
+
+	cab, ok := peerstore.GetCertifiedAddrBook(d.host.Peerstore())
+	if !ok {
+		return errorResponseString("peerstore doesn't support signed peer records")
+	}
+
+	env, rec, err := record.ConsumeEnvelope(req.AddPeerRecord.Envelope, peer.PeerRecordEnvelopeDomain)
+	if err != nil {
+		return errorResponse(err)
+	}
+	pr, ok := rec.(*peer.PeerRecord)
+	if !ok {
+		return errorResponseString("envelope doesn't hold a peer record")
+	}
+	if !pr.PeerID.MatchesPublicKey(env.PublicKey) {
+		return errorResponse(fmt.Errorf("peer record of %s isn't signed by its key", pr.PeerID))
+	}
+	if pr.PeerID == d.ID() {
+		return errorResponseString("can't add a peer record of the daemon itself")
+	}
+
+	accepted, err := cab.ConsumePeerRecord(env, ttl)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	addrs := make([][]byte, len(pr.Addrs))
+	for x, addr := range pr.Addrs {
+		addrs[x] = addr.Bytes()
+	}
+
+	res := okResponse()
+	res.AddPeerRecord = &pb.AddPeerRecordResponse{
+		Peer:     []byte(pr.PeerID),
+		Addrs:    addrs,
+		Accepted: &accepted,
+	}
+	return res
+}
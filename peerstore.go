@@ -0,0 +1,80 @@
+package p2pd
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoreds"
+
+	"github.com/libp2p/go-libp2p-daemon/config"
+
+	multierror "github.com/hashicorp/go-multierror"
+	ds "github.com/ipfs/go-datastore"
+	leveldb "github.com/ipfs/go-ds-leveldb"
+)
+
+// certifiedPeerstore is a peerstore keeping signed peer records, as the
+// host requires.
+type certifiedPeerstore interface {
+	peerstore.Peerstore
+	peerstore.CertifiedAddrBook
+}
+
+// persistentPeerstore closes its datastore along with itself, which the host
+// does when it is closed.
+type persistentPeerstore struct {
+	certifiedPeerstore
+	ds ds.Datastore
+}
+
+// RemovePeer keeps the keys, protocols and metadata of peers with addresses
+// left, which the host would otherwise drop some time after they disconnect
+// and when it is closed, so that they survive restarts along with the
+// addresses.
+func (ps *persistentPeerstore) RemovePeer(p peer.ID) {
+	if len(ps.Addrs(p)) > 0 {
+		return
+	}
+	ps.certifiedPeerstore.RemovePeer(p)
+}
+
+// prune removes the peers whose addresses expired while the daemon was down.
+func (ps *persistentPeerstore) prune() {
+	for _, p := range ps.PeersWithKeys() {
+		ps.RemovePeer(p)
+	}
+}
+
+func (ps *persistentPeerstore) Close() error {
+	var merr *multierror.Error
+	if err := ps.certifiedPeerstore.Close(); err != nil {
+		merr = multierror.Append(merr, err)
+	}
+	if err := ps.ds.Close(); err != nil {
+		merr = multierror.Append(merr, err)
+	}
+	return merr.ErrorOrNil()
+}
+
+// NewPeerstore creates a peerstore persisting the addresses, keys, protocols
+// and metadata of peers in a leveldb datastore in the directory of c, and
+// purging expired addresses from it every GC interval of c.
+func NewPeerstore(ctx context.Context, c config.Peerstore) (peerstore.Peerstore, error) {
+	store, err := leveldb.NewDatastore(c.Path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := pstoreds.DefaultOpts()
+	opts.GCPurgeInterval = c.GCInterval
+	ps, err := pstoreds.NewPeerstore(ctx, store, opts)
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+
+	pps := &persistentPeerstore{certifiedPeerstore: ps, ds: store}
+	pps.prune()
+	return pps, nil
+}
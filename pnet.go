@@ -0,0 +1,18 @@
+package p2pd
+
+import (
+	"os"
+
+	"github.com/libp2p/go-libp2p/core/pnet"
+)
+
+// ReadSwarmKey reads the pre-shared key of a private network from a
+// swarm.key file, in the /key/swarm/psk/1.0.0/ format.
+func ReadSwarmKey(path string) (pnet.PSK, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return pnet.DecodeV1PSK(f)
+}
@@ -80,6 +80,9 @@ func (d *Daemon) doPubsubSubscribe(req *pb.PSRequest) (*pb.Response, *ps.Subscri
 }
 
 func (d *Daemon) doPubsubPipe(sub *ps.Subscription, r ggio.ReadCloser, w ggio.WriteCloser) {
+	subscriptions.Inc()
+	defer subscriptions.Dec()
+
 	go func() {
 		// read something until the client closes the connection
 		// at which point we cancel the subscription
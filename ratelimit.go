@@ -0,0 +1,247 @@
+package p2pd
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-daemon/config"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	limitRate       = "rate"
+	limitDHTQueries = "dht_queries"
+)
+
+// pruneInterval is how often clients whose limits have been idle long enough
+// to be back to full are forgotten.
+const pruneInterval = time.Minute
+
+// rateLimiter enforces the rate limits of control requests, with a set of
+// token buckets per client.
+type rateLimiter struct {
+	c config.RateLimit
+
+	mx      sync.Mutex
+	clients map[string]*clientLimits
+	pruned  time.Time
+}
+
+type clientLimits struct {
+	// buckets are keyed like the rules in config.RateLimit.Requests, with
+	// the default rule under ""
+	buckets map[string]*rate.Limiter
+	// queries is the number of DHT requests in flight
+	queries int
+}
+
+func newRateLimiter(c config.RateLimit) *rateLimiter {
+	return &rateLimiter{
+		c:       c,
+		clients: make(map[string]*clientLimits),
+		pruned:  time.Now(),
+	}
+}
+
+// EnableRateLimits limits the control requests of each client as configured
+// by c. Clients connecting over a unix socket are told apart by process where
+// the platform allows it, and by remote IP otherwise.
+func (d *Daemon) EnableRateLimits(c config.RateLimit) error {
+	for k := range c.Requests {
+		if err := checkRuleKey(k); err != nil {
+			return err
+		}
+	}
+
+	d.mx.Lock()
+	defer d.mx.Unlock()
+	d.limits = newRateLimiter(c)
+	return nil
+}
+
+func checkRuleKey(k string) error {
+	typ, subtype, hasSubtype := strings.Cut(k, ".")
+	if _, ok := pb.Request_Type_value[typ]; !ok {
+		return fmt.Errorf("rate limit for unknown request type %s", typ)
+	}
+	if !hasSubtype {
+		return nil
+	}
+
+	var ok bool
+	switch typ {
+	case pb.Request_DHT.String():
+		_, ok = pb.DHTRequest_Type_value[subtype]
+	case pb.Request_PUBSUB.String():
+		_, ok = pb.PSRequest_Type_value[subtype]
+	case pb.Request_CONNMANAGER.String():
+		_, ok = pb.ConnManagerRequest_Type_value[subtype]
+	case pb.Request_CONNGATER.String():
+		_, ok = pb.ConnGaterRequest_Type_value[subtype]
+	}
+	if !ok {
+		return fmt.Errorf("rate limit for unknown request sub-type %s", k)
+	}
+	return nil
+}
+
+// rules returns the keys of the rules that apply to req.
+func (l *rateLimiter) rules(req *pb.Request) []string {
+	keys := make([]string, 0, 3)
+	if l.c.Default.Rate > 0 {
+		keys = append(keys, "")
+	}
+
+	typ := req.GetType().String()
+	if r, ok := l.c.Requests[typ]; ok && r.Rate > 0 {
+		keys = append(keys, typ)
+	}
+	if subtype := requestSubtype(req); subtype != "" {
+		k := typ + "." + subtype
+		if r, ok := l.c.Requests[k]; ok && r.Rate > 0 {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func (l *rateLimiter) rule(key string) config.RateLimitRule {
+	if key == "" {
+		return l.c.Default
+	}
+	return l.c.Requests[key]
+}
+
+// client returns the limits of a client; l.mx must be held.
+func (l *rateLimiter) client(id string) *clientLimits {
+	cl, ok := l.clients[id]
+	if !ok {
+		cl = &clientLimits{buckets: make(map[string]*rate.Limiter)}
+		l.clients[id] = cl
+	}
+	return cl
+}
+
+// allow takes a token for req from each of the client's buckets it is subject
+// to, or none of them if any is empty.
+func (l *rateLimiter) allow(id string, req *pb.Request) bool {
+	keys := l.rules(req)
+	if len(keys) == 0 {
+		return true
+	}
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	now := time.Now()
+	l.prune(now)
+
+	cl := l.client(id)
+	reservations := make([]*rate.Reservation, 0, len(keys))
+	for _, k := range keys {
+		b, ok := cl.buckets[k]
+		if !ok {
+			r := l.rule(k)
+			b = rate.NewLimiter(rate.Limit(r.Rate), r.Burst)
+			cl.buckets[k] = b
+		}
+
+		res := b.ReserveN(now, 1)
+		if !res.OK() || res.DelayFrom(now) > 0 {
+			res.CancelAt(now)
+			for _, res := range reservations {
+				res.CancelAt(now)
+			}
+			return false
+		}
+		reservations = append(reservations, res)
+	}
+	return true
+}
+
+// acquireQuery counts a DHT request of a client as in flight, unless the
+// client already has as many as allowed.
+func (l *rateLimiter) acquireQuery(id string) bool {
+	if l.c.MaxDHTQueries == 0 {
+		return true
+	}
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	cl := l.client(id)
+	if cl.queries >= l.c.MaxDHTQueries {
+		return false
+	}
+	cl.queries++
+	return true
+}
+
+// releaseQuery ends a DHT request counted by acquireQuery.
+func (l *rateLimiter) releaseQuery(id string) {
+	if l.c.MaxDHTQueries == 0 {
+		return
+	}
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	l.clients[id].queries--
+}
+
+// prune forgets the clients with no requests in flight whose buckets are
+// full, as a new set of buckets would be; l.mx must be held.
+func (l *rateLimiter) prune(now time.Time) {
+	if now.Sub(l.pruned) < pruneInterval {
+		return
+	}
+	l.pruned = now
+
+clients:
+	for id, cl := range l.clients {
+		if cl.queries > 0 {
+			continue
+		}
+		for _, b := range cl.buckets {
+			if b.TokensAt(now) < float64(b.Burst()) {
+				continue clients
+			}
+		}
+		delete(l.clients, id)
+	}
+}
+
+// clientID identifies the client at the other end of a control connection.
+// Unix socket clients are identified by process, so that the connections a
+// client opens for each of its requests share the same limits; without peer
+// credentials, all unix socket clients look the same.
+func clientID(c net.Conn) string {
+	if pid, _, ok := peerCredentials(c); ok {
+		return fmt.Sprintf("pid:%d", pid)
+	}
+	return remoteClientID(c)
+}
+
+// remoteClientID identifies a client by the address it connects from,
+// leaving out the port, which changes with every control connection.
+func remoteClientID(c net.Conn) string {
+	addr := c.RemoteAddr()
+	if addr == nil {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(addr.String()); err == nil {
+		return host
+	}
+	return addr.Network() + ":" + addr.String()
+}
+
+func rateLimitedResponse(msg string) *pb.Response {
+	res := errorResponseString(msg)
+	res.Error.Code = pb.ErrorResponse_RATE_LIMITED.Enum()
+	return res
+}
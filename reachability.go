@@ -0,0 +1,52 @@
+package p2pd
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/network"
+
+	proto "github.com/gogo/protobuf/proto"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// trackReachability keeps the reachability of the host up to date, and
+// publishes its changes, until sub is closed.
+func (d *Daemon) trackReachability(sub event.Subscription) {
+	for e := range sub.Out() {
+		evt := e.(event.EvtLocalReachabilityChanged)
+		atomic.StoreInt32(&d.reachability, int32(evt.Reachability))
+
+		now := time.Now().UnixNano()
+		d.events.publish(&pb.Event{
+			Type:         pb.Event_REACHABILITY.Enum(),
+			Time:         &now,
+			Reachability: reachabilityToProto(evt.Reachability).Enum(),
+		})
+	}
+}
+
+func (d *Daemon) getReachability() network.Reachability {
+	return network.Reachability(atomic.LoadInt32(&d.reachability))
+}
+
+func reachabilityToProto(r network.Reachability) pb.Reachability {
+	switch r {
+	case network.ReachabilityPublic:
+		return pb.Reachability_PUBLIC
+	case network.ReachabilityPrivate:
+		return pb.Reachability_PRIVATE
+	default:
+		return pb.Reachability_UNKNOWN
+	}
+}
+
+func (d *Daemon) doGetReachability(req *pb.Request) *pb.Response {
+	res := okResponse()
+	res.Reachability = &pb.ReachabilityResponse{
+		Reachability: reachabilityToProto(d.getReachability()).Enum(),
+		Forced:       proto.Bool(d.reachabilityForced),
+	}
+	return res
+}
@@ -0,0 +1,89 @@
+package p2pd
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/relay"
+
+	"github.com/libp2p/go-libp2p-daemon/config"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// RelayServiceOptions returns the options of a relay service limited by c.
+func RelayServiceOptions(c config.RelayService) ([]relay.Option, error) {
+	rc := relay.Resources{
+		ReservationTTL:         c.ReservationTTL,
+		MaxReservations:        c.MaxReservations,
+		MaxCircuits:            c.MaxCircuits,
+		BufferSize:             c.BufferSize,
+		MaxReservationsPerPeer: c.MaxReservationsPerPeer,
+		MaxReservationsPerIP:   c.MaxReservationsPerIP,
+		MaxReservationsPerASN:  c.MaxReservationsPerASN,
+	}
+	if c.CircuitDuration > 0 {
+		rc.Limit = &relay.RelayLimit{Duration: c.CircuitDuration, Data: c.CircuitData}
+	}
+	opts := []relay.Option{relay.WithResources(rc)}
+
+	if len(c.AllowedPeers) > 0 || len(c.AllowedSubnets) > 0 {
+		acl, err := newRelayACL(c.AllowedPeers, c.AllowedSubnets)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, relay.WithACL(acl))
+	}
+	return opts, nil
+}
+
+// relayACL only lets the allowed peers, and the peers in the allowed
+// subnets, reserve slots.
+type relayACL struct {
+	peers   map[peer.ID]struct{}
+	subnets []*net.IPNet
+}
+
+var _ relay.ACLFilter = (*relayACL)(nil)
+
+func newRelayACL(peers, subnets []string) (*relayACL, error) {
+	allowed, err := decodePeers(peers)
+	if err != nil {
+		return nil, err
+	}
+	acl := &relayACL{peers: make(map[peer.ID]struct{}, len(allowed))}
+	for _, p := range allowed {
+		acl.peers[p] = struct{}{}
+	}
+	for _, s := range subnets {
+		_, ipnet, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid relay subnet %s: %w", s, err)
+		}
+		acl.subnets = append(acl.subnets, ipnet)
+	}
+	return acl, nil
+}
+
+func (acl *relayACL) AllowReserve(p peer.ID, a ma.Multiaddr) bool {
+	if _, ok := acl.peers[p]; ok {
+		return true
+	}
+	ip, err := manet.ToIP(a)
+	if err != nil {
+		return false
+	}
+	for _, ipnet := range acl.subnets {
+		if ipnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowConnect lets any peer connect to the peers with slots, who chose the
+// relay.
+func (acl *relayACL) AllowConnect(src peer.ID, srcAddr ma.Multiaddr, dest peer.ID) bool {
+	return true
+}
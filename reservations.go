@@ -0,0 +1,267 @@
+package p2pd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/client"
+
+	proto "github.com/gogo/protobuf/proto"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+// reservationRetryInterval is how long the daemon waits before retrying to
+// refresh a reservation that failed to refresh.
+var reservationRetryInterval = 30 * time.Second
+
+// reservationTag protects the connections to the relays the daemon has
+// reservations on, which the relays drop the reservations with.
+const reservationTag = "p2pd-relay-reservation"
+
+// relayReservations are the reservations the daemon keeps on relays, at the
+// request of clients, refreshing them until they are cancelled.
+type relayReservations struct {
+	mx    sync.Mutex
+	rsvps map[peer.ID]*reservation
+}
+
+type reservation struct {
+	relay peer.ID
+	// rsvp is the last reservation granted by the relay
+	rsvp *client.Reservation
+	// err is why the last refresh failed, if it did
+	err error
+
+	// refresh asks for an immediate refresh, when the relay disconnects
+	refresh chan struct{}
+	cancel  context.CancelFunc
+}
+
+func newRelayReservations() *relayReservations {
+	return &relayReservations{rsvps: make(map[peer.ID]*reservation)}
+}
+
+// disconnected refreshes the reservation on p, if any, once the daemon is no
+// longer connected to it: the relay dropped the reservation with the
+// connection.
+func (rs *relayReservations) disconnected(n network.Network, c network.Conn) {
+	p := c.RemotePeer()
+	if n.Connectedness(p) == network.Connected {
+		return
+	}
+	rs.mx.Lock()
+	defer rs.mx.Unlock()
+	if r, ok := rs.rsvps[p]; ok {
+		select {
+		case r.refresh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// closeAll stops refreshing the reservations.
+func (rs *relayReservations) closeAll() {
+	rs.mx.Lock()
+	defer rs.mx.Unlock()
+	for p, r := range rs.rsvps {
+		r.cancel()
+		delete(rs.rsvps, p)
+	}
+}
+
+// refreshReservation refreshes r halfway to its expiration, or right away if
+// the relay disconnects, until ctx is done.
+func (d *Daemon) refreshReservation(ctx context.Context, r *reservation) {
+	for {
+		d.reservations.mx.Lock()
+		delay := time.Until(r.rsvp.Expiration) / 2
+		if r.err != nil {
+			delay = reservationRetryInterval
+		}
+		d.reservations.mx.Unlock()
+
+		t := time.NewTimer(delay)
+		select {
+		case <-t.C:
+		case <-r.refresh:
+			t.Stop()
+		case <-ctx.Done():
+			t.Stop()
+			return
+		}
+
+		rctx, cancel := context.WithTimeout(ctx, client.ReserveTimeout)
+		rsvp, err := client.Reserve(rctx, d.host, peer.AddrInfo{ID: r.relay})
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Debugw("error refreshing reservation", "relay", r.relay, "error", err)
+		}
+
+		d.reservations.mx.Lock()
+		r.err = err
+		if err == nil {
+			r.rsvp = rsvp
+		}
+		d.reservations.mx.Unlock()
+	}
+}
+
+// circuitAddrs returns the /p2p-circuit addresses of the daemon through the
+// relay of r: the public addresses vouched by the relay, or else those the
+// daemon is connected to the relay at.
+func (d *Daemon) circuitAddrs(r *reservation) []ma.Multiaddr {
+	relayAddrs := r.rsvp.Addrs
+	if len(relayAddrs) == 0 {
+		for _, c := range d.host.Network().ConnsToPeer(r.relay) {
+			if !isRelayedAddr(c.RemoteMultiaddr()) {
+				relayAddrs = append(relayAddrs, c.RemoteMultiaddr())
+			}
+		}
+	}
+
+	relayID, err := ma.NewComponent("p2p", r.relay.String())
+	if err != nil {
+		return nil
+	}
+	circuit, err := ma.NewComponent("p2p-circuit", "")
+	if err != nil {
+		return nil
+	}
+
+	addrs := make([]ma.Multiaddr, 0, len(relayAddrs))
+	for _, a := range relayAddrs {
+		if _, id := peer.SplitAddr(a); id == "" {
+			a = a.Encapsulate(relayID)
+		}
+		addrs = append(addrs, a.Encapsulate(circuit))
+	}
+	return addrs
+}
+
+func isRelayedAddr(a ma.Multiaddr) bool {
+	_, err := a.ValueForProtocol(ma.P_CIRCUIT)
+	return err == nil
+}
+
+// reservationInfo describes r; the caller holds the reservations lock.
+func (d *Daemon) reservationInfo(r *reservation) *pb.RelayReservation {
+	addrs := d.circuitAddrs(r)
+	baddrs := make([][]byte, len(addrs))
+	for i, a := range addrs {
+		baddrs[i] = a.Bytes()
+	}
+
+	info := &pb.RelayReservation{
+		Relay:         []byte(r.relay),
+		Expiration:    proto.Int64(r.rsvp.Expiration.Unix()),
+		Addrs:         baddrs,
+		LimitDuration: proto.Int64(int64(r.rsvp.LimitDuration / time.Second)),
+		LimitData:     proto.Uint64(r.rsvp.LimitData),
+	}
+	if r.err != nil {
+		errstr := r.err.Error()
+		info.Error = &errstr
+	}
+	return info
+}
+
+func (d *Daemon) doRelayReserve(ctx context.Context, req *pb.Request) *pb.Response {
+	if req.RelayReserve == nil {
+		return errorResponseString("Malformed request; missing parameters")
+	}
+
+	ctx, cancel := d.requestContext(ctx, req.RelayReserve.GetTimeout(), req.RelayReserve.GetTimeoutMillis())
+	defer cancel()
+
+	p, err := peer.IDFromBytes(req.RelayReserve.Peer)
+	if err != nil {
+		return errorResponse(err)
+	}
+	if p == d.ID() {
+		return errorResponseString("cannot reserve a slot on the daemon itself")
+	}
+
+	addrs := make([]ma.Multiaddr, len(req.RelayReserve.Addrs))
+	for i, bs := range req.RelayReserve.Addrs {
+		addr, err := ma.NewMultiaddrBytes(bs)
+		if err != nil {
+			return errorResponse(err)
+		}
+		addrs[i] = addr
+	}
+
+	rsvp, err := client.Reserve(ctx, d.host, peer.AddrInfo{ID: p, Addrs: addrs})
+	if err != nil {
+		return errorResponse(fmt.Errorf("reservation on %s failed: %w", p, err))
+	}
+	d.host.ConnManager().Protect(p, reservationTag)
+
+	rctx, rcancel := context.WithCancel(context.Background())
+	r := &reservation{
+		relay:   p,
+		rsvp:    rsvp,
+		refresh: make(chan struct{}, 1),
+		cancel:  rcancel,
+	}
+
+	d.reservations.mx.Lock()
+	defer d.reservations.mx.Unlock()
+	if old, ok := d.reservations.rsvps[p]; ok {
+		old.cancel()
+	}
+	d.reservations.rsvps[p] = r
+	go d.refreshReservation(rctx, r)
+
+	res := okResponse()
+	res.RelayReservations = []*pb.RelayReservation{d.reservationInfo(r)}
+	return res
+}
+
+func (d *Daemon) doRelayListReservations(req *pb.Request) *pb.Response {
+	d.reservations.mx.Lock()
+	defer d.reservations.mx.Unlock()
+
+	rsvps := make([]*pb.RelayReservation, 0, len(d.reservations.rsvps))
+	for _, r := range d.reservations.rsvps {
+		rsvps = append(rsvps, d.reservationInfo(r))
+	}
+	sort.Slice(rsvps, func(i, j int) bool {
+		return string(rsvps[i].Relay) < string(rsvps[j].Relay)
+	})
+
+	res := okResponse()
+	res.RelayReservations = rsvps
+	return res
+}
+
+func (d *Daemon) doRelayCancel(req *pb.Request) *pb.Response {
+	if req.RelayCancel == nil {
+		return errorResponseString("Malformed request; missing parameters")
+	}
+
+	p, err := peer.IDFromBytes(req.RelayCancel.Peer)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	d.reservations.mx.Lock()
+	r, ok := d.reservations.rsvps[p]
+	delete(d.reservations.rsvps, p)
+	d.reservations.mx.Unlock()
+	if !ok {
+		return errorResponseString(fmt.Sprintf("no reservation on %s", p))
+	}
+
+	r.cancel()
+	d.host.ConnManager().Unprotect(p, reservationTag)
+	return okResponse()
+}
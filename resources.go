@@ -0,0 +1,134 @@
+package p2pd
+
+import (
+	"os"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/network"
+	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+
+	"github.com/libp2p/go-libp2p-daemon/config"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	proto "github.com/gogo/protobuf/proto"
+)
+
+// ResourceService is the resource manager service streams piped to clients
+// are attributed to; its limits can be set in the limits file.
+const ResourceService = "libp2p.daemon"
+
+// pipeMemory is the memory doStreamPipe buffers a stream with.
+const pipeMemory = 2 * 32 << 10
+
+// NewResourceManager creates a resource manager enforcing the default limits,
+// overridden by those in the limits file of c, and exempting the peers in the
+// allowlist of c from the system and transient limits.
+func NewResourceManager(c config.ResourceManager) (network.ResourceManager, error) {
+	limits := rcmgr.DefaultLimits
+	libp2p.SetDefaultServiceLimits(&limits)
+	defaults := limits.AutoScale()
+
+	limiter := rcmgr.NewFixedLimiter(defaults)
+	if c.Limits != "" {
+		f, err := os.Open(c.Limits)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		limiter, err = rcmgr.NewLimiterFromJSON(f, defaults)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	str, err := rcmgr.NewStatsTraceReporter()
+	if err != nil {
+		return nil, err
+	}
+	opts := []rcmgr.Option{rcmgr.WithTraceReporter(str)}
+	if len(c.Allowlist) > 0 {
+		opts = append(opts, rcmgr.WithAllowlistedMultiaddrs(c.Allowlist))
+	}
+
+	return rcmgr.NewResourceManager(limiter, opts...)
+}
+
+// scopeStream attributes s to the daemon's resource manager service and
+// reserves the memory needed to pipe it, so that the service limits apply to
+// the protocols delegated to clients.
+func scopeStream(s network.Stream) error {
+	err := s.Scope().SetService(ResourceService)
+	if err != nil {
+		return err
+	}
+
+	return s.Scope().ReserveMemory(pipeMemory, network.ReservationPriorityMedium)
+}
+
+func (d *Daemon) doResourceUsage(req *pb.Request) *pb.Response {
+	rm := d.host.Network().ResourceManager()
+	state, ok := rm.(rcmgr.ResourceManagerState)
+	if !ok {
+		return errorResponseString("resource manager doesn't report usage")
+	}
+
+	usage := &pb.ResourceUsageResponse{}
+	rm.ViewSystem(func(s network.ResourceScope) error {
+		usage.System = scopeUsage("", s)
+		return nil
+	})
+	rm.ViewTransient(func(s network.ResourceScope) error {
+		usage.Transient = scopeUsage("", s)
+		return nil
+	})
+	for _, svc := range state.ListServices() {
+		rm.ViewService(svc, func(s network.ServiceScope) error {
+			usage.Services = append(usage.Services, scopeUsage(svc, s))
+			return nil
+		})
+	}
+	for _, p := range state.ListProtocols() {
+		rm.ViewProtocol(p, func(s network.ProtocolScope) error {
+			usage.Protocols = append(usage.Protocols, scopeUsage(string(p), s))
+			return nil
+		})
+	}
+	for _, p := range state.ListPeers() {
+		rm.ViewPeer(p, func(s network.PeerScope) error {
+			usage.Peers = append(usage.Peers, scopeUsage(p.String(), s))
+			return nil
+		})
+	}
+
+	res := okResponse()
+	res.ResourceUsage = usage
+	return res
+}
+
+func scopeUsage(name string, s network.ResourceScope) *pb.ResourceScopeUsage {
+	stat := s.Stat()
+	usage := &pb.ResourceScopeUsage{
+		Memory:          proto.Int64(stat.Memory),
+		StreamsInbound:  proto.Int64(int64(stat.NumStreamsInbound)),
+		StreamsOutbound: proto.Int64(int64(stat.NumStreamsOutbound)),
+		ConnsInbound:    proto.Int64(int64(stat.NumConnsInbound)),
+		ConnsOutbound:   proto.Int64(int64(stat.NumConnsOutbound)),
+		Fd:              proto.Int64(int64(stat.NumFD)),
+	}
+	if name != "" {
+		usage.Name = proto.String(name)
+	}
+
+	if l, ok := s.(rcmgr.ResourceScopeLimiter); ok {
+		limit := l.Limit()
+		usage.MemoryLimit = proto.Int64(limit.GetMemoryLimit())
+		usage.StreamsInboundLimit = proto.Int64(int64(limit.GetStreamLimit(network.DirInbound)))
+		usage.StreamsOutboundLimit = proto.Int64(int64(limit.GetStreamLimit(network.DirOutbound)))
+		usage.ConnsInboundLimit = proto.Int64(int64(limit.GetConnLimit(network.DirInbound)))
+		usage.ConnsOutboundLimit = proto.Int64(int64(limit.GetConnLimit(network.DirOutbound)))
+		usage.FdLimit = proto.Int64(int64(limit.GetFDLimit()))
+	}
+
+	return usage
+}
@@ -0,0 +1,316 @@
+// Package shm implements a shared memory data path for streams piped between
+// the daemon and its clients.
+//
+// Stream payloads move through a pair of single-producer/single-consumer ring
+// buffers living in a memfd-backed segment. The segment is handed to the
+// client over the unix socket the stream would otherwise be piped over, and
+// from then on that socket only carries one-byte wakeup and close signals.
+package shm
+
+import (
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// DefaultRingSize is the size of each of the two ring buffers of a segment.
+const DefaultRingSize = 1 << 20 // 1 MB
+
+// each ring has a header holding its head and tail counters, kept on separate
+// cache lines so that producer and consumer don't contend.
+const (
+	headOffset = 0
+	tailOffset = 64
+	headerSize = 128
+)
+
+// signals exchanged over the socket once the segment is established.
+const (
+	sigData  byte = 'd' // the sender's tx ring has new data
+	sigSpace byte = 's' // the sender consumed data from its rx ring
+	sigClose byte = 'c' // the sender will not write anymore
+)
+
+// ErrUnsupported is returned when shared memory is not available on this platform.
+var ErrUnsupported = errors.New("shared memory transport is not supported on this platform")
+
+// UnixConn is the subset of *net.UnixConn needed to pass a segment between processes.
+type UnixConn interface {
+	net.Conn
+	ReadMsgUnix(b, oob []byte) (n, oobn, flags int, addr *net.UnixAddr, err error)
+	WriteMsgUnix(b, oob []byte, addr *net.UnixAddr) (n, oobn int, err error)
+}
+
+// SegmentSize returns the size of a segment holding two rings of ringSize bytes.
+func SegmentSize(ringSize int) int {
+	return 2 * (headerSize + ringSize)
+}
+
+func validRingSize(ringSize int) bool {
+	return ringSize > 0 && ringSize&(ringSize-1) == 0
+}
+
+// Segment is a shared memory mapping holding the two rings of a stream.
+type Segment struct {
+	// fd is the memfd backing the mapping, until it has been passed on
+	fd       int
+	mem      []byte
+	ringSize int
+}
+
+// RingSize returns the size of each of the segment's rings.
+func (s *Segment) RingSize() int {
+	return s.ringSize
+}
+
+type ring struct {
+	hdr  []byte
+	buf  []byte
+	mask uint64
+}
+
+func newRing(mem []byte, ringSize int) *ring {
+	return &ring{
+		hdr:  mem[:headerSize],
+		buf:  mem[headerSize : headerSize+ringSize],
+		mask: uint64(ringSize - 1),
+	}
+}
+
+func (r *ring) head() *uint64 {
+	return (*uint64)(unsafe.Pointer(&r.hdr[headOffset]))
+}
+
+func (r *ring) tail() *uint64 {
+	return (*uint64)(unsafe.Pointer(&r.hdr[tailOffset]))
+}
+
+// write copies as much of p as fits into the ring; it must only be called by the producer.
+func (r *ring) write(p []byte) int {
+	head := atomic.LoadUint64(r.head())
+	tail := atomic.LoadUint64(r.tail())
+	free := uint64(len(r.buf)) - (head - tail)
+	n := uint64(len(p))
+	if n > free {
+		n = free
+	}
+	if n == 0 {
+		return 0
+	}
+
+	start := head & r.mask
+	c := copy(r.buf[start:], p[:n])
+	copy(r.buf, p[c:n])
+
+	atomic.StoreUint64(r.head(), head+n)
+	return int(n)
+}
+
+// read copies as much buffered data as fits into p; it must only be called by the consumer.
+func (r *ring) read(p []byte) int {
+	head := atomic.LoadUint64(r.head())
+	tail := atomic.LoadUint64(r.tail())
+	n := head - tail
+	if n > uint64(len(p)) {
+		n = uint64(len(p))
+	}
+	if n == 0 {
+		return 0
+	}
+
+	start := tail & r.mask
+	end := start + n
+	if end > uint64(len(r.buf)) {
+		end = uint64(len(r.buf))
+	}
+	c := copy(p, r.buf[start:end])
+	copy(p[c:n], r.buf)
+
+	atomic.StoreUint64(r.tail(), tail+n)
+	return int(n)
+}
+
+// Conn is a stream endpoint whose payload goes through a shared memory
+// segment, while the underlying socket carries signals only.
+type Conn struct {
+	sock net.Conn
+	seg  *Segment
+
+	tx, rx *ring
+
+	dataCh  chan struct{}
+	spaceCh chan struct{}
+	done    chan struct{}
+
+	peerClosed atomic.Bool
+	closed     atomic.Bool
+
+	// mx guards the segment mapping against being unmapped while in use
+	mx sync.RWMutex
+	// wmx serializes signal writes
+	wmx       sync.Mutex
+	closeOnce sync.Once
+}
+
+var _ io.ReadWriteCloser = (*Conn)(nil)
+
+// newConn wraps sock and seg; the offering side transmits on the first ring
+// and the accepting side on the second.
+func newConn(sock net.Conn, seg *Segment, offerer bool) *Conn {
+	size := seg.ringSize
+	first := newRing(seg.mem[:headerSize+size], size)
+	second := newRing(seg.mem[headerSize+size:], size)
+
+	c := &Conn{
+		sock:    sock,
+		seg:     seg,
+		dataCh:  make(chan struct{}, 1),
+		spaceCh: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	if offerer {
+		c.tx, c.rx = first, second
+	} else {
+		c.tx, c.rx = second, first
+	}
+
+	go c.readSignals()
+	return c
+}
+
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Conn) readSignals() {
+	defer close(c.done)
+	// whether the peer closed cleanly or went away, it won't write anymore
+	defer c.peerClosed.Store(true)
+
+	buf := make([]byte, 64)
+	for {
+		n, err := c.sock.Read(buf)
+		for _, sig := range buf[:n] {
+			switch sig {
+			case sigData:
+				notify(c.dataCh)
+			case sigSpace:
+				notify(c.spaceCh)
+			case sigClose:
+				c.peerClosed.Store(true)
+				notify(c.dataCh)
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (c *Conn) signal(sig byte) error {
+	c.wmx.Lock()
+	defer c.wmx.Unlock()
+	_, err := c.sock.Write([]byte{sig})
+	return err
+}
+
+func (c *Conn) tryRead(p []byte) (int, error) {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+	if c.closed.Load() {
+		return 0, net.ErrClosed
+	}
+	return c.rx.read(p), nil
+}
+
+func (c *Conn) tryWrite(p []byte) (int, error) {
+	c.mx.RLock()
+	defer c.mx.RUnlock()
+	if c.closed.Load() {
+		return 0, net.ErrClosed
+	}
+	return c.tx.write(p), nil
+}
+
+// Read reads stream data from the segment, blocking until some is available.
+func (c *Conn) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	for {
+		// anything written before the peer closed is visible once we observe the close
+		eof := c.peerClosed.Load()
+
+		n, err := c.tryRead(p)
+		if err != nil {
+			return 0, err
+		}
+		if n > 0 {
+			// the peer may be waiting for space; a failure here surfaces on the next call
+			c.signal(sigSpace)
+			return n, nil
+		}
+		if eof {
+			return 0, io.EOF
+		}
+
+		select {
+		case <-c.dataCh:
+		case <-c.done:
+		}
+	}
+}
+
+// Write writes stream data to the segment, blocking while the ring is full.
+func (c *Conn) Write(p []byte) (int, error) {
+	written := 0
+	for written < len(p) {
+		if c.peerClosed.Load() {
+			return written, io.ErrClosedPipe
+		}
+
+		n, err := c.tryWrite(p[written:])
+		if err != nil {
+			return written, err
+		}
+		if n > 0 {
+			written += n
+			if err := c.signal(sigData); err != nil {
+				return written, err
+			}
+			continue
+		}
+
+		select {
+		case <-c.spaceCh:
+		case <-c.done:
+		}
+	}
+
+	return written, nil
+}
+
+// Close signals the peer that we are done, closes the socket and unmaps the segment.
+func (c *Conn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		c.signal(sigClose)
+		err = c.sock.Close()
+		<-c.done
+
+		c.mx.Lock()
+		c.closed.Store(true)
+		if serr := c.seg.Close(); err == nil {
+			err = serr
+		}
+		c.mx.Unlock()
+	})
+	return err
+}
@@ -0,0 +1,127 @@
+package shm
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// Supported reports whether the shared memory transport is available on this platform.
+const Supported = true
+
+// NewSegment creates and maps a memfd-backed segment with two rings of ringSize
+// bytes each; ringSize must be a power of two.
+func NewSegment(ringSize int) (*Segment, error) {
+	if !validRingSize(ringSize) {
+		return nil, fmt.Errorf("invalid ring size %d; must be a power of two", ringSize)
+	}
+
+	fd, err := unix.MemfdCreate("p2pd-shm", unix.MFD_CLOEXEC)
+	if err != nil {
+		return nil, fmt.Errorf("creating memfd: %w", err)
+	}
+
+	size := SegmentSize(ringSize)
+	if err := unix.Ftruncate(fd, int64(size)); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("sizing memfd: %w", err)
+	}
+
+	return mapSegment(fd, size, ringSize)
+}
+
+func mapSegment(fd, size, ringSize int) (*Segment, error) {
+	mem, err := unix.Mmap(fd, 0, size, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED)
+	if err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("mapping segment: %w", err)
+	}
+
+	return &Segment{fd: fd, mem: mem, ringSize: ringSize}, nil
+}
+
+// Close unmaps the segment.
+func (s *Segment) Close() error {
+	if s.fd >= 0 {
+		unix.Close(s.fd)
+		s.fd = -1
+	}
+	if s.mem == nil {
+		return nil
+	}
+	err := unix.Munmap(s.mem)
+	s.mem = nil
+	return err
+}
+
+// Offer passes seg to the peer at the other end of c and returns a Conn
+// moving stream data through it. The caller must have told the peer to expect
+// the segment, so that it calls Accept.
+func Offer(c UnixConn, seg *Segment) (*Conn, error) {
+	_, _, err := c.WriteMsgUnix([]byte{0}, unix.UnixRights(seg.fd), nil)
+	if err != nil {
+		seg.Close()
+		return nil, fmt.Errorf("passing segment: %w", err)
+	}
+
+	// the peer holds its own reference now
+	unix.Close(seg.fd)
+	seg.fd = -1
+
+	return newConn(c, seg, true), nil
+}
+
+// Accept receives a segment with rings of ringSize bytes offered by the peer
+// at the other end of c, and returns a Conn moving stream data through it.
+func Accept(c UnixConn, ringSize int) (*Conn, error) {
+	if !validRingSize(ringSize) {
+		return nil, fmt.Errorf("invalid ring size %d; must be a power of two", ringSize)
+	}
+
+	buf := make([]byte, 1)
+	oob := make([]byte, unix.CmsgSpace(4))
+	_, oobn, _, _, err := c.ReadMsgUnix(buf, oob)
+	if err != nil {
+		return nil, fmt.Errorf("receiving segment: %w", err)
+	}
+
+	msgs, err := unix.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		return nil, fmt.Errorf("parsing control message: %w", err)
+	}
+	if len(msgs) != 1 {
+		return nil, fmt.Errorf("expected a single control message, got %d", len(msgs))
+	}
+	fds, err := unix.ParseUnixRights(&msgs[0])
+	if err != nil {
+		return nil, fmt.Errorf("parsing control message: %w", err)
+	}
+	if len(fds) != 1 {
+		for _, fd := range fds {
+			unix.Close(fd)
+		}
+		return nil, fmt.Errorf("expected a single file descriptor, got %d", len(fds))
+	}
+	fd := fds[0]
+
+	size := SegmentSize(ringSize)
+	var st unix.Stat_t
+	if err := unix.Fstat(fd, &st); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("inspecting segment: %w", err)
+	}
+	if st.Size != int64(size) {
+		unix.Close(fd)
+		return nil, fmt.Errorf("segment size mismatch; expected %d, got %d", size, st.Size)
+	}
+
+	seg, err := mapSegment(fd, size, ringSize)
+	if err != nil {
+		return nil, err
+	}
+	// the mapping keeps the memory alive
+	unix.Close(seg.fd)
+	seg.fd = -1
+
+	return newConn(c, seg, false), nil
+}
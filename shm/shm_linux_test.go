@@ -0,0 +1,168 @@
+package shm
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"net"
+	"os"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func socketPair(t testing.TB) (*net.UnixConn, *net.UnixConn) {
+	fds, err := unix.Socketpair(unix.AF_UNIX, unix.SOCK_STREAM, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conns := make([]*net.UnixConn, 2)
+	for i, fd := range fds {
+		f := os.NewFile(uintptr(fd), "socketpair")
+		c, err := net.FileConn(f)
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		conns[i] = c.(*net.UnixConn)
+	}
+	return conns[0], conns[1]
+}
+
+func connPair(t testing.TB, ringSize int) (*Conn, *Conn) {
+	a, b := socketPair(t)
+
+	seg, err := NewSegment(ringSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	offered := make(chan *Conn, 1)
+	go func() {
+		c, err := Offer(a, seg)
+		if err != nil {
+			t.Error(err)
+		}
+		offered <- c
+	}()
+
+	accepted, err := Accept(b, ringSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return <-offered, accepted
+}
+
+func TestInvalidRingSize(t *testing.T) {
+	if _, err := NewSegment(1000); err == nil {
+		t.Fatal("expected segment with non power of two ring size to fail")
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	// a small ring forces wrap-around and writers blocking on space
+	offerer, accepter := connPair(t, 4096)
+	defer offerer.Close()
+	defer accepter.Close()
+
+	data := make([]byte, 1<<20)
+	rand.Read(data)
+
+	for _, dir := range []struct {
+		name string
+		w, r *Conn
+	}{
+		{"offerer to accepter", offerer, accepter},
+		{"accepter to offerer", accepter, offerer},
+	} {
+		go func() {
+			if _, err := dir.w.Write(data); err != nil {
+				t.Errorf("%s: %s", dir.name, err)
+			}
+		}()
+
+		buf := make([]byte, len(data))
+		if _, err := io.ReadFull(dir.r, buf); err != nil {
+			t.Fatalf("%s: %s", dir.name, err)
+		}
+		if !bytes.Equal(buf, data) {
+			t.Fatalf("%s: data mismatch", dir.name)
+		}
+	}
+}
+
+func TestCloseDeliversEOF(t *testing.T) {
+	offerer, accepter := connPair(t, 4096)
+	defer accepter.Close()
+
+	if _, err := offerer.Write([]byte("test")); err != nil {
+		t.Fatal(err)
+	}
+	if err := offerer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := io.ReadAll(accepter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != "test" {
+		t.Fatalf(`expected "test", got "%s"`, string(buf))
+	}
+
+	if _, err := accepter.Write([]byte("test")); err == nil {
+		t.Fatal("expected write to closed peer to fail")
+	}
+}
+
+const benchChunk = 64 << 10
+
+func benchmarkPipe(b *testing.B, w io.WriteCloser, r io.Reader) {
+	chunk := make([]byte, benchChunk)
+	rand.Read(chunk)
+
+	b.SetBytes(benchChunk)
+	b.ResetTimer()
+
+	go func() {
+		for i := 0; i < b.N; i++ {
+			if _, err := w.Write(chunk); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+		w.Close()
+	}()
+
+	buf := make([]byte, benchChunk)
+	var total int64
+	for {
+		n, err := r.Read(buf)
+		total += int64(n)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	if total != int64(b.N)*benchChunk {
+		b.Fatalf("expected %d bytes, got %d", int64(b.N)*benchChunk, total)
+	}
+}
+
+// BenchmarkSocketPipe measures moving stream data over a unix socket, as
+// doStreamPipe does without shared memory.
+func BenchmarkSocketPipe(b *testing.B) {
+	w, r := socketPair(b)
+	defer r.Close()
+	benchmarkPipe(b, w, r)
+}
+
+// BenchmarkSharedMemoryPipe measures moving stream data through a shared memory segment.
+func BenchmarkSharedMemoryPipe(b *testing.B) {
+	w, r := connPair(b, DefaultRingSize)
+	defer r.Close()
+	benchmarkPipe(b, w, r)
+}
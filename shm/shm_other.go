@@ -0,0 +1,26 @@
+//go:build !linux
+
+package shm
+
+// Supported reports whether the shared memory transport is available on this platform.
+const Supported = false
+
+// NewSegment is not supported on this platform.
+func NewSegment(ringSize int) (*Segment, error) {
+	return nil, ErrUnsupported
+}
+
+// Close is a no-op on this platform.
+func (s *Segment) Close() error {
+	return nil
+}
+
+// Offer is not supported on this platform.
+func Offer(c UnixConn, seg *Segment) (*Conn, error) {
+	return nil, ErrUnsupported
+}
+
+// Accept is not supported on this platform.
+func Accept(c UnixConn, ringSize int) (*Conn, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,196 @@
+package p2pd
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/record"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// signContext prefixes all the data the daemon signs on behalf of clients, so
+// that its signatures can't pass for those of other libp2p protocols signed
+// with the same key.
+const signContext = "libp2p-daemon-signed:"
+
+// signedData returns the bytes signed for data in domain: the sign context,
+// then the domain prefixed with its length as an unsigned varint, then the
+// data.
+func signedData(domain string, data []byte) []byte {
+	b := make([]byte, 0, len(signContext)+binary.MaxVarintLen64+len(domain)+len(data))
+	b = append(b, signContext...)
+	b = binary.AppendUvarint(b, uint64(len(domain)))
+	b = append(b, domain...)
+	return append(b, data...)
+}
+
+func (d *Daemon) doSign(req *pb.Request) *pb.Response {
+	if req.Sign == nil {
+		return errorResponseString("Malformed request; missing parameters")
+	}
+
+	priv := d.host.Peerstore().PrivKey(d.ID())
+	if priv == nil {
+		return errorResponseString("daemon private key not found")
+	}
+	sig, err := priv.Sign(signedData(req.Sign.GetDomain(), req.Sign.Data))
+	if err != nil {
+		return errorResponse(err)
+	}
+	pub, err := crypto.MarshalPublicKey(priv.GetPublic())
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	res := okResponse()
+	res.Sign = &pb.SignResponse{Signature: sig, PublicKey: pub}
+	return res
+}
+
+func (d *Daemon) doVerify(req *pb.Request) *pb.Response {
+	if req.Verify == nil {
+		return errorResponseString("Malformed request; missing parameters")
+	}
+
+	pub, err := d.signerKey(req.Verify.Peer, req.Verify.PublicKey)
+	if err != nil {
+		return errorResponse(err)
+	}
+	valid, err := pub.Verify(signedData(req.Verify.GetDomain(), req.Verify.Data), req.Verify.Signature)
+	if err != nil {
+		log.Debugw("error verifying signature", "error", err)
+	}
+
+	res := okResponse()
+	res.Verify = &pb.VerifyResponse{Valid: &valid}
+	return res
+}
+
+// signerKey returns the public key of a signer given by its marshaled public
+// key, its peer ID, or both, in which case they must match. The key of a peer
+// ID is looked up in the peerstore when it doesn't embed it.
+func (d *Daemon) signerKey(peerBytes, pubBytes []byte) (crypto.PubKey, error) {
+	var pub crypto.PubKey
+	if pubBytes != nil {
+		var err error
+		pub, err = crypto.UnmarshalPublicKey(pubBytes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if peerBytes == nil {
+		if pub == nil {
+			return nil, fmt.Errorf("missing peer or public key parameter")
+		}
+		return pub, nil
+	}
+
+	p, err := peer.IDFromBytes(peerBytes)
+	if err != nil {
+		return nil, err
+	}
+	if pub != nil {
+		if !p.MatchesPublicKey(pub) {
+			return nil, fmt.Errorf("public key doesn't match peer %s", p)
+		}
+		return pub, nil
+	}
+
+	pub, err = p.ExtractPublicKey()
+	if err == nil {
+		return pub, nil
+	}
+	if pub = d.host.Peerstore().PubKey(p); pub == nil {
+		return nil, fmt.Errorf("public key of peer %s not found", p)
+	}
+	return pub, nil
+}
+
+// rawRecord is an envelope payload of a type set by the client, sealed and
+// opened as is.
+type rawRecord struct {
+	domain      string
+	payloadType []byte
+	payload     []byte
+}
+
+var _ record.Record = (*rawRecord)(nil)
+
+func (r *rawRecord) Domain() string {
+	return r.domain
+}
+
+func (r *rawRecord) Codec() []byte {
+	return r.payloadType
+}
+
+func (r *rawRecord) MarshalRecord() ([]byte, error) {
+	return r.payload, nil
+}
+
+func (r *rawRecord) UnmarshalRecord(b []byte) error {
+	r.payload = b
+	return nil
+}
+
+func (d *Daemon) doSealEnvelope(req *pb.Request) *pb.Response {
+	if req.SealEnvelope == nil {
+		return errorResponseString("Malformed request; missing parameters")
+	}
+	if req.SealEnvelope.GetDomain() == "" || len(req.SealEnvelope.PayloadType) == 0 {
+		return errorResponseString("Malformed request; envelopes need a domain and a payload type")
+	}
+
+	priv := d.host.Peerstore().PrivKey(d.ID())
+	if priv == nil {
+		return errorResponseString("daemon private key not found")
+	}
+	env, err := record.Seal(&rawRecord{
+		domain:      req.SealEnvelope.GetDomain(),
+		payloadType: req.SealEnvelope.PayloadType,
+		payload:     req.SealEnvelope.Payload,
+	}, priv)
+	if err != nil {
+		return errorResponse(err)
+	}
+	b, err := env.Marshal()
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	res := okResponse()
+	res.SealEnvelope = &pb.SealEnvelopeResponse{Envelope: b}
+	return res
+}
+
+func (d *Daemon) doOpenEnvelope(req *pb.Request) *pb.Response {
+	if req.OpenEnvelope == nil {
+		return errorResponseString("Malformed request; missing parameters")
+	}
+
+	rec := &rawRecord{domain: req.OpenEnvelope.GetDomain()}
+	env, err := record.ConsumeTypedEnvelope(req.OpenEnvelope.Envelope, rec)
+	if err != nil {
+		return errorResponse(err)
+	}
+	p, err := peer.IDFromPublicKey(env.PublicKey)
+	if err != nil {
+		return errorResponse(err)
+	}
+	pub, err := crypto.MarshalPublicKey(env.PublicKey)
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	res := okResponse()
+	res.OpenEnvelope = &pb.OpenEnvelopeResponse{
+		Peer:        []byte(p),
+		PublicKey:   pub,
+		PayloadType: env.PayloadType,
+		Payload:     rec.payload,
+	}
+	return res
+}
@@ -1,65 +1,265 @@
 package p2pd
 
 import (
+	"context"
+	"fmt"
 	"io"
 	"net"
+	"os"
 	"sync"
 
 	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	"github.com/libp2p/go-libp2p-daemon/shm"
 
 	ggio "github.com/gogo/protobuf/io"
+	proto "github.com/gogo/protobuf/proto"
+	ma "github.com/multiformats/go-multiaddr"
 	manet "github.com/multiformats/go-multiaddr/net"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-func (d *Daemon) doStreamPipe(c net.Conn, s network.Stream) {
+// streamHandler is a client endpoint registered to handle inbound streams.
+type streamHandler struct {
+	// addr is the address the daemon dials to deliver a stream, unless conn is set
+	addr ma.Multiaddr
+	// conn is the control connection streams are passed over as sockets
+	conn *handlerConn
+	// shm is set when the client accepts stream data through shared memory
+	shm bool
+}
+
+// handlerConn is a control connection a client registered stream handlers on,
+// which the daemon then delivers inbound streams to by passing sockets.
+type handlerConn struct {
+	c  shm.UnixConn
+	w  ggio.WriteCloser
+	mx sync.Mutex
+}
+
+func newHandlerConn(c shm.UnixConn) *handlerConn {
+	return &handlerConn{c: c, w: ggio.NewDelimitedWriter(c)}
+}
+
+// deliver writes the stream info followed by the client's end of the stream socket.
+func (hc *handlerConn) deliver(info *pb.StreamInfo, f *os.File) error {
+	hc.mx.Lock()
+	defer hc.mx.Unlock()
+
+	err := hc.w.WriteMsg(info)
+	if err != nil {
+		return err
+	}
+
+	return sendFile(hc.c, f)
+}
+
+func (d *Daemon) doStreamPipe(ctx context.Context, c io.ReadWriteCloser, s network.Stream) {
+	p := string(s.Protocol())
+	streamsPiped.WithLabelValues(p).Inc()
+	defer streamsPiped.WithLabelValues(p).Dec()
+
+	_, span := d.tracer().Start(ctx, "p2pd.stream.pipe", trace.WithAttributes(
+		attribute.String("protocol", p),
+		attribute.Stringer("peer", s.Conn().RemotePeer()),
+	))
+	defer span.End()
+
 	var wg sync.WaitGroup
 	wg.Add(2)
 
-	pipe := func(dst io.WriteCloser, src io.Reader) {
-		_, err := io.Copy(dst, src)
+	pipe := func(dst io.WriteCloser, src io.Reader, direction string) {
+		cw := &countingWriter{WriteCloser: dst, counter: streamBytes.WithLabelValues(p, direction)}
+		_, err := io.Copy(cw, src)
 		if err != nil && err != io.EOF {
 			log.Debugw("stream error", "error", err)
 			s.Reset()
 		}
 		dst.Close()
+		streamSize.WithLabelValues(p, direction).Observe(float64(cw.n))
+		span.SetAttributes(attribute.Int64("bytes."+direction, cw.n))
 		wg.Done()
 	}
 
-	go pipe(c, s)
-	go pipe(s, c)
+	go pipe(c, s, directionIn)
+	go pipe(s, c, directionOut)
 
 	wg.Wait()
 }
 
+// shmOffer creates a shared memory segment for piping a stream over c, if the
+// client asked for one and c can carry it. It returns nil when the stream
+// should be piped over the socket instead.
+func (d *Daemon) shmOffer(c net.Conn, want bool) *shm.Segment {
+	if !want || !shm.Supported {
+		return nil
+	}
+
+	if _, ok := c.(shm.UnixConn); !ok {
+		return nil
+	}
+
+	seg, err := shm.NewSegment(shm.DefaultRingSize)
+	if err != nil {
+		log.Debugw("error creating shared memory segment", "error", err)
+		return nil
+	}
+
+	return seg
+}
+
+// pipeStream pipes s to the client at the other end of c, through seg if the
+// client was offered a shared memory segment.
+func (d *Daemon) pipeStream(ctx context.Context, c net.Conn, s network.Stream, seg *shm.Segment) {
+	if seg == nil {
+		d.doStreamPipe(ctx, c, s)
+		return
+	}
+
+	sc, err := shm.Offer(c.(shm.UnixConn), seg)
+	if err != nil {
+		log.Debugw("error passing shared memory segment", "error", err)
+		s.Reset()
+		return
+	}
+
+	d.doStreamPipe(ctx, sc, s)
+}
+
 func (d *Daemon) handleStream(s network.Stream) {
 	p := s.Protocol()
 
+	ctx, span := d.tracer().Start(d.ctx, "p2pd.stream.accept", trace.WithAttributes(
+		attribute.String("protocol", string(p)),
+		attribute.Stringer("peer", s.Conn().RemotePeer()),
+	))
+
 	d.mx.Lock()
-	maddr, ok := d.handlers[p]
+	h, ok := d.handlers[p]
 	d.mx.Unlock()
 
 	if !ok {
 		log.Debugw("unexpected stream", "protocol", p)
+		streamAcceptFailures.WithLabelValues(string(p), reasonNoHandler).Inc()
+		endSpan(span, fmt.Errorf("no handler for protocol %s", p))
 		s.Reset()
 		return
 	}
 
-	c, err := manet.Dial(maddr)
+	if err := scopeStream(s); err != nil {
+		log.Debugw("error attaching stream to resource scope", "protocol", p, "error", err)
+		streamAcceptFailures.WithLabelValues(string(p), reasonResourceLimit).Inc()
+		endSpan(span, err)
+		s.Reset()
+		return
+	}
+
+	c, seg, reason, err := d.deliverStream(s, h)
+	endSpan(span, err)
 	if err != nil {
-		log.Debugw("error dialing handler", "handler", maddr.String(), "error", err)
+		streamAcceptFailures.WithLabelValues(string(p), reason).Inc()
 		s.Reset()
 		return
 	}
 	defer c.Close()
 
-	w := ggio.NewDelimitedWriter(c)
+	streamsAccepted.WithLabelValues(string(p)).Inc()
+	d.pipeStream(ctx, c, s, seg)
+}
+
+// deliverStream hands s over to the client endpoint h, returning the conn to
+// pipe it over, or the reason it could not be delivered.
+func (d *Daemon) deliverStream(s network.Stream, h streamHandler) (net.Conn, *shm.Segment, string, error) {
+	var c net.Conn
+	var remote *os.File
+	var err error
+	if h.conn != nil {
+		c, remote, err = socketPair()
+		if err != nil {
+			log.Debugw("error creating stream socket", "error", err)
+			return nil, nil, reasonError, err
+		}
+		// the client holds its own reference once delivered
+		defer remote.Close()
+	} else {
+		c, err = manet.Dial(h.addr)
+		if err != nil {
+			log.Debugw("error dialing handler", "handler", h.addr.String(), "error", err)
+			return nil, nil, reasonHandlerDial, err
+		}
+	}
+
+	seg := d.shmOffer(c, h.shm)
+
 	msg := makeStreamInfo(s)
-	err = w.WriteMsg(msg)
+	if seg != nil {
+		msg.ShmSize = proto.Uint64(uint64(seg.RingSize()))
+	}
+	if h.conn != nil {
+		err = h.conn.deliver(msg, remote)
+	} else {
+		err = ggio.NewDelimitedWriter(c).WriteMsg(msg)
+	}
 	if err != nil {
 		log.Debugw("error accepting stream", "error", err)
-		s.Reset()
-		return
+		c.Close()
+		if seg != nil {
+			seg.Close()
+		}
+		return nil, nil, reasonHandlerWrite, err
+	}
+
+	return c, seg, "", nil
+}
+
+// setStreamHandlers registers h as the handler for protos.
+func (d *Daemon) setStreamHandlers(protos []string, h streamHandler) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	for _, sp := range protos {
+		p := protocol.ID(sp)
+		_, ok := d.handlers[p]
+		if !ok {
+			d.host.SetStreamHandler(p, d.handleStream)
+		}
+		if h.conn != nil {
+			log.Debugw("set stream handler", "protocol", sp, "to", "control connection")
+		} else {
+			log.Debugw("set stream handler", "protocol", sp, "to", h.addr)
+		}
+		d.handlers[p] = h
 	}
+}
+
+// doStreamHandlerConn registers protos to be delivered over hc, until the
+// client closes the connection.
+func (d *Daemon) doStreamHandlerConn(hc *handlerConn, protos []string, wantShm bool, r ggio.ReadCloser) {
+	d.setStreamHandlers(protos, streamHandler{conn: hc, shm: wantShm})
 
-	d.doStreamPipe(c, s)
+	// read something until the client closes the connection
+	// at which point we drop its handlers
+	for {
+		var req pb.Request
+		err := r.ReadMsg(&req)
+		if err != nil {
+			break
+		}
+
+		log.Warnw("unexpected message", "type", req.GetType())
+	}
+
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	for p, h := range d.handlers {
+		if h.conn == hc {
+			log.Debugw("removing stream handler", "protocol", p)
+			d.host.RemoveStreamHandler(p)
+			delete(d.handlers, p)
+		}
+	}
 }
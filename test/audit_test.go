@@ -0,0 +1,190 @@
+package test
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/libp2p/go-libp2p-daemon/config"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	ggio "github.com/gogo/protobuf/io"
+	proto "github.com/gogo/protobuf/proto"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+type auditEntry struct {
+	Client struct {
+		PID *int
+		UID *int
+	}
+	Type    string
+	Subtype string
+	Params  map[string]any
+	Outcome string
+	Error   string
+}
+
+func readAudit(t *testing.T, path string) []auditEntry {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var entries []auditEntry
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		var e auditEntry
+		require.NoError(t, json.Unmarshal(s.Bytes(), &e))
+		entries = append(entries, e)
+	}
+	require.NoError(t, s.Err())
+	return entries
+}
+
+func TestAudit(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	err := d1.EnableAudit(config.Audit{Enabled: true, File: path})
+	require.NoError(t, err)
+
+	// not audited
+	_, _, err = c1.Identify()
+	require.NoError(t, err)
+
+	require.NoError(t, connect(c1, d2))
+	require.NoError(t, c1.Publish("audit", []byte("hello")))
+	require.Error(t, c1.Connect(randPeerID(t), nil))
+
+	entries := readAudit(t, path)
+	require.Len(t, entries, 3)
+
+	require.Equal(t, "CONNECT", entries[0].Type)
+	require.Equal(t, d2.ID().String(), entries[0].Params["peer"])
+	require.Equal(t, "ok", entries[0].Outcome)
+
+	require.Equal(t, "PUBSUB", entries[1].Type)
+	require.Equal(t, "PUBLISH", entries[1].Subtype)
+	require.Equal(t, "audit", entries[1].Params["topic"])
+	require.Equal(t, float64(5), entries[1].Params["dataSize"])
+
+	require.Equal(t, "CONNECT", entries[2].Type)
+	require.Equal(t, "error", entries[2].Outcome)
+	require.NotEmpty(t, entries[2].Error)
+
+	if runtime.GOOS == "linux" && d1.Listener().Addr().Network() == "unix" {
+		require.NotNil(t, entries[0].Client.PID)
+		require.Equal(t, os.Getpid(), *entries[0].Client.PID)
+		require.Equal(t, os.Getuid(), *entries[0].Client.UID)
+	}
+}
+
+func TestAuditRotation(t *testing.T) {
+	d, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	err := d.EnableAudit(config.Audit{Enabled: true, File: path, MaxSize: 512, MaxBackups: 2})
+	require.NoError(t, err)
+
+	for i := 0; i < 20; i++ {
+		require.NoError(t, c.Publish("audit", []byte("hello")))
+	}
+
+	var total int
+	for _, p := range []string{path, path + ".1", path + ".2"} {
+		fi, err := os.Stat(p)
+		require.NoError(t, err)
+		require.LessOrEqual(t, fi.Size(), int64(512))
+		total += len(readAudit(t, p))
+	}
+	require.Less(t, total, 20)
+	_, err = os.Stat(path + ".3")
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestAuditRotationFailure(t *testing.T) {
+	d, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	err := d.EnableAudit(config.Audit{Enabled: true, File: path, MaxSize: 256, MaxBackups: 1})
+	require.NoError(t, err)
+	// the log can't be moved to a backup that is a directory
+	require.NoError(t, os.MkdirAll(filepath.Join(path+".1", "busy"), 0755))
+
+	rotate := map[string]string{"op": "rotate"}
+	before := metricValue(t, "p2pd_audit_failures_total", rotate)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, c.Publish("audit", []byte("hello")))
+	}
+	// entries keep being recorded to the log, which is reopened
+	require.Len(t, readAudit(t, path), 10)
+	require.Greater(t, metricValue(t, "p2pd_audit_failures_total", rotate), before)
+
+	// and it is rotated once the backup can be written again
+	require.NoError(t, os.RemoveAll(path+".1"))
+	require.NoError(t, c.Publish("audit", []byte("hello")))
+	require.Len(t, readAudit(t, path), 1)
+	require.Len(t, readAudit(t, path+".1"), 10)
+}
+
+func TestEnableAuditTwice(t *testing.T) {
+	d, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	dir := t.TempDir()
+	first, second := filepath.Join(dir, "first.log"), filepath.Join(dir, "second.log")
+	require.NoError(t, d.EnableAudit(config.Audit{Enabled: true, File: first}))
+	require.NoError(t, d.EnableAudit(config.Audit{Enabled: true, File: second}))
+
+	require.NoError(t, c.Publish("audit", []byte("hello")))
+	require.Empty(t, readAudit(t, first))
+	require.Len(t, readAudit(t, second), 1)
+}
+
+func TestEnableAuditWhileConnected(t *testing.T) {
+	d, _, closer := createDaemonClientPair(t)
+	defer closer()
+
+	control, err := manet.Dial(d.Listener().Multiaddr())
+	require.NoError(t, err)
+	defer control.Close()
+	w := ggio.NewDelimitedWriter(control)
+	r := ggio.NewDelimitedReader(control, p2pclient.MessageSizeMax)
+	publish := func() {
+		require.NoError(t, w.WriteMsg(&pb.Request{
+			Type: pb.Request_PUBSUB.Enum(),
+			Pubsub: &pb.PSRequest{
+				Type:  pb.PSRequest_PUBLISH.Enum(),
+				Topic: proto.String("audit"),
+				Data:  []byte("hello"),
+			},
+		}))
+		var res pb.Response
+		require.NoError(t, r.ReadMsg(&res))
+		require.Equal(t, pb.Response_OK, res.GetType())
+	}
+
+	// requests on a connection opened before the audit log is enabled, or
+	// replaced, go to the current log
+	publish()
+	dir := t.TempDir()
+	first, second := filepath.Join(dir, "first.log"), filepath.Join(dir, "second.log")
+	require.NoError(t, d.EnableAudit(config.Audit{Enabled: true, File: first}))
+	publish()
+	require.NoError(t, d.EnableAudit(config.Audit{Enabled: true, File: second}))
+	publish()
+
+	require.Len(t, readAudit(t, first), 1)
+	require.Len(t, readAudit(t, second), 1)
+}
@@ -0,0 +1,221 @@
+package test
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	ggio "github.com/gogo/protobuf/io"
+	proto "github.com/gogo/protobuf/proto"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+)
+
+// stallingListener accepts connections and never says a word, so that
+// connecting to it hangs in the security handshake.
+func stallingListener(t *testing.T) ma.Multiaddr {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		var conns []net.Conn
+		defer func() {
+			for _, c := range conns {
+				c.Close()
+			}
+		}()
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conns = append(conns, c)
+		}
+	}()
+
+	addr, err := manet.FromNetAddr(l.Addr())
+	require.NoError(t, err)
+	return addr
+}
+
+func stallingConnect(t *testing.T, addr ma.Multiaddr) *pb.Request {
+	return &pb.Request{
+		Type: pb.Request_CONNECT.Enum(),
+		Connect: &pb.ConnectRequest{
+			Peer:  []byte(randPeerID(t)),
+			Addrs: [][]byte{addr.Bytes()},
+		},
+	}
+}
+
+func TestCancelRequest(t *testing.T) {
+	d, _, closer := createDaemonClientPair(t)
+	defer closer()
+	addr := stallingListener(t)
+
+	control, err := manet.Dial(d.Listener().Multiaddr())
+	require.NoError(t, err)
+	defer control.Close()
+	w := ggio.NewDelimitedWriter(control)
+	r := ggio.NewDelimitedReader(control, p2pclient.MessageSizeMax)
+
+	req := stallingConnect(t, addr)
+	req.Id = proto.Uint64(7)
+	require.NoError(t, w.WriteMsg(req))
+
+	start := time.Now()
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, w.WriteMsg(&pb.Request{
+		Type:   pb.Request_CANCEL.Enum(),
+		Cancel: &pb.CancelRequest{Id: proto.Uint64(7)},
+	}))
+
+	var res pb.Response
+	require.NoError(t, r.ReadMsg(&res))
+	require.Less(t, time.Since(start), 10*time.Second)
+	require.Equal(t, pb.Response_ERROR, res.GetType())
+	require.Equal(t, pb.ErrorResponse_CANCELLED, res.GetError().GetCode())
+	require.Equal(t, uint64(7), res.GetId())
+
+	// the connection goes on serving requests
+	require.NoError(t, w.WriteMsg(&pb.Request{Type: pb.Request_IDENTIFY.Enum(), Id: proto.Uint64(8)}))
+	res.Reset()
+	require.NoError(t, r.ReadMsg(&res))
+	require.Equal(t, pb.Response_OK, res.GetType())
+	require.Equal(t, uint64(8), res.GetId())
+}
+
+func TestPipelinedRequest(t *testing.T) {
+	d, _, closer := createDaemonClientPair(t)
+	defer closer()
+	addr := stallingListener(t)
+
+	control, err := manet.Dial(d.Listener().Multiaddr())
+	require.NoError(t, err)
+	defer control.Close()
+	w := ggio.NewDelimitedWriter(control)
+	r := ggio.NewDelimitedReader(control, p2pclient.MessageSizeMax)
+
+	req := stallingConnect(t, addr)
+	req.Connect.TimeoutMillis = proto.Int64(300)
+	require.NoError(t, w.WriteMsg(req))
+	// sent while the connect is in flight, and answered after it
+	require.NoError(t, w.WriteMsg(&pb.Request{Type: pb.Request_IDENTIFY.Enum()}))
+
+	var res pb.Response
+	start := time.Now()
+	require.NoError(t, r.ReadMsg(&res))
+	require.Equal(t, pb.Response_ERROR, res.GetType())
+	require.Less(t, time.Since(start), 10*time.Second)
+
+	res.Reset()
+	require.NoError(t, r.ReadMsg(&res))
+	require.Equal(t, pb.Response_OK, res.GetType())
+	require.NotNil(t, res.Identify)
+}
+
+func TestCancelOnDisconnect(t *testing.T) {
+	d, _, closer := createDaemonClientPair(t)
+	defer closer()
+	addr := stallingListener(t)
+
+	labels := map[string]string{"type": "CONNECT"}
+	before := metricValue(t, "p2pd_control_request_errors_total", labels)
+
+	control, err := manet.Dial(d.Listener().Multiaddr())
+	require.NoError(t, err)
+	w := ggio.NewDelimitedWriter(control)
+	require.NoError(t, w.WriteMsg(stallingConnect(t, addr)))
+	time.Sleep(100 * time.Millisecond)
+	control.Close()
+
+	// the connect fails well ahead of its default timeout
+	require.Eventually(t, func() bool {
+		return metricValue(t, "p2pd_control_request_errors_total", labels) == before+1
+	}, 10*time.Second, 50*time.Millisecond)
+}
+
+func TestCancelRequestRepeatedly(t *testing.T) {
+	d, _, closer := createDaemonClientPair(t)
+	defer closer()
+	addr := stallingListener(t)
+
+	control, err := manet.Dial(d.Listener().Multiaddr())
+	require.NoError(t, err)
+	defer control.Close()
+	w := ggio.NewDelimitedWriter(control)
+	r := ggio.NewDelimitedReader(control, p2pclient.MessageSizeMax)
+
+	// the request returns as soon as the CANCEL is read, while the daemon
+	// goes back to waiting for data
+	for i := uint64(0); i < 50; i++ {
+		req := stallingConnect(t, addr)
+		req.Id = proto.Uint64(i)
+		require.NoError(t, w.WriteMsg(req))
+		require.NoError(t, w.WriteMsg(&pb.Request{
+			Type:   pb.Request_CANCEL.Enum(),
+			Cancel: &pb.CancelRequest{Id: proto.Uint64(i)},
+		}))
+
+		control.SetReadDeadline(time.Now().Add(10 * time.Second))
+		var res pb.Response
+		require.NoError(t, r.ReadMsg(&res))
+		require.Equal(t, pb.ErrorResponse_CANCELLED, res.GetError().GetCode())
+		require.Equal(t, i, res.GetId())
+
+		require.NoError(t, w.WriteMsg(&pb.Request{Type: pb.Request_IDENTIFY.Enum()}))
+		res.Reset()
+		require.NoError(t, r.ReadMsg(&res))
+		require.Equal(t, pb.Response_OK, res.GetType())
+	}
+}
+
+func TestCancelOtherRequestWhileFinishing(t *testing.T) {
+	d, _, closer := createDaemonClientPair(t)
+	defer closer()
+	addr := stallingListener(t)
+
+	control, err := manet.Dial(d.Listener().Multiaddr())
+	require.NoError(t, err)
+	defer control.Close()
+	w := ggio.NewDelimitedWriter(control)
+	r := ggio.NewDelimitedReader(control, p2pclient.MessageSizeMax)
+
+	req := stallingConnect(t, addr)
+	req.Id = proto.Uint64(1)
+	req.Connect.TimeoutMillis = proto.Int64(100)
+	require.NoError(t, w.WriteMsg(req))
+
+	// the daemon is still reading the CANCEL of another request when the
+	// connect times out
+	var buf bytes.Buffer
+	require.NoError(t, ggio.NewDelimitedWriter(&buf).WriteMsg(&pb.Request{
+		Type:   pb.Request_CANCEL.Enum(),
+		Cancel: &pb.CancelRequest{Id: proto.Uint64(2)},
+	}))
+	cancel := buf.Bytes()
+	_, err = control.Write(cancel[:1])
+	require.NoError(t, err)
+	time.Sleep(300 * time.Millisecond)
+	_, err = control.Write(cancel[1:])
+	require.NoError(t, err)
+
+	control.SetReadDeadline(time.Now().Add(10 * time.Second))
+	var res pb.Response
+	require.NoError(t, r.ReadMsg(&res))
+	require.Equal(t, pb.Response_ERROR, res.GetType())
+	require.NotEqual(t, pb.ErrorResponse_CANCELLED, res.GetError().GetCode())
+	require.Equal(t, uint64(1), res.GetId())
+
+	require.NoError(t, w.WriteMsg(&pb.Request{Type: pb.Request_IDENTIFY.Enum()}))
+	res.Reset()
+	require.NoError(t, r.ReadMsg(&res))
+	require.Equal(t, pb.Response_OK, res.GetType())
+}
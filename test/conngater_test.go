@@ -0,0 +1,208 @@
+package test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+	"github.com/stretchr/testify/require"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/config"
+)
+
+func TestConnGaterBlockPeer(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	require.NoError(t, connect(c1, d2))
+	require.NoError(t, c1.BlockPeer(d2.ID()))
+
+	// blocking a peer closes its connections
+	require.Eventually(t, func() bool {
+		diag, err := c2.Diagnostics()
+		require.NoError(t, err)
+		return len(diag.Conns) == 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	require.Error(t, connect(c1, d2))
+	// the remote end of an inbound connection may see it established before
+	// the daemon drops it
+	connect(c2, d1)
+	require.Eventually(t, func() bool {
+		diag, err := c1.Diagnostics()
+		require.NoError(t, err)
+		return len(diag.Conns) == 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	blocked, err := c1.ListBlocked()
+	require.NoError(t, err)
+	require.Len(t, blocked.Peers, 1)
+	require.Equal(t, d2.ID(), blocked.Peers[0])
+
+	require.NoError(t, c1.UnblockPeer(d2.ID()))
+	require.NoError(t, connect(c1, d2))
+}
+
+func TestConnGaterBlockSubnet(t *testing.T) {
+	_, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	var subnets []*net.IPNet
+	for _, s := range []string{"0.0.0.0/0", "::/0"} {
+		_, ipnet, err := net.ParseCIDR(s)
+		require.NoError(t, err)
+		subnets = append(subnets, ipnet)
+		require.NoError(t, c1.BlockSubnet(ipnet))
+	}
+	require.Error(t, connect(c1, d2))
+
+	blocked, err := c1.ListBlocked()
+	require.NoError(t, err)
+	require.Len(t, blocked.Subnets, 2)
+
+	for _, ipnet := range subnets {
+		require.NoError(t, c1.UnblockSubnet(ipnet))
+	}
+	require.NoError(t, connect(c1, d2))
+}
+
+func TestConnGaterConfig(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "gater.json")
+
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+	d3, _, closer3 := createDaemonClientPair(t)
+	defer closer3()
+
+	err := d1.LoadConnectionGater(config.ConnectionGater{
+		AllowedPeers: []string{d2.ID().String()},
+		File:         file,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, connect(c1, d2))
+	require.Error(t, connect(c1, d3))
+
+	require.NoError(t, c1.BlockPeer(d3.ID()))
+
+	// blocks persist in the file
+	d4, c4, closer4 := createDaemonClientPair(t)
+	defer closer4()
+	require.NoError(t, d4.LoadConnectionGater(config.ConnectionGater{File: file}))
+	blocked, err := c4.ListBlocked()
+	require.NoError(t, err)
+	require.Len(t, blocked.Peers, 1)
+	require.Equal(t, d3.ID(), blocked.Peers[0])
+	require.Empty(t, blocked.AllowedPeers)
+	require.Error(t, connect(c4, d3))
+	require.NoError(t, connect(c4, d2))
+}
+
+func TestConnGaterConfigNotPersisted(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "gater.json")
+
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	err := d1.LoadConnectionGater(config.ConnectionGater{
+		BlockedPeers:   []string{d2.ID().String()},
+		BlockedSubnets: []string{"10.0.0.0/8"},
+		File:           file,
+	})
+	require.NoError(t, err)
+	require.Error(t, connect(c1, d2))
+
+	// config blocks can't be lifted through the API
+	require.Error(t, c1.UnblockPeer(d2.ID()))
+	blocked, err := c1.ListBlocked()
+	require.NoError(t, err)
+	require.Equal(t, []peer.ID{d2.ID()}, blocked.Peers)
+	require.Len(t, blocked.Subnets, 1)
+
+	// removing them from the config lifts them, as they aren't in the file
+	require.NoError(t, d1.LoadConnectionGater(config.ConnectionGater{File: file}))
+	blocked, err = c1.ListBlocked()
+	require.NoError(t, err)
+	require.Empty(t, blocked.Peers)
+	require.Empty(t, blocked.Subnets)
+	require.NoError(t, connect(c1, d2))
+
+	d3, c3, closer3 := createDaemonClientPair(t)
+	defer closer3()
+	require.NoError(t, d3.LoadConnectionGater(config.ConnectionGater{File: file}))
+	require.NoError(t, connect(c3, d2))
+}
+
+func TestConnGaterConcurrentBlocksPersisted(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "gater.json")
+
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	require.NoError(t, d1.LoadConnectionGater(config.ConnectionGater{File: file}))
+
+	// the file ends up with every block, whichever save finishes last
+	peers := randPeerIDs(t, 20)
+	errs := make(chan error, len(peers))
+	for _, p := range peers {
+		go func(p peer.ID) {
+			errs <- c1.BlockPeer(p)
+		}(p)
+	}
+	for range peers {
+		require.NoError(t, <-errs)
+	}
+
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+	require.NoError(t, d2.LoadConnectionGater(config.ConnectionGater{File: file}))
+	blocked, err := c2.ListBlocked()
+	require.NoError(t, err)
+	require.ElementsMatch(t, peers, blocked.Peers)
+}
+
+func TestConnGaterChained(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+	d3, _, closer3 := createDaemonClientPair(t)
+	defer closer3()
+
+	g, err := conngater.NewBasicConnectionGater(nil)
+	require.NoError(t, err)
+	require.NoError(t, g.BlockPeer(d2.ID()))
+
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+	d1, err := p2pd.NewDaemonWithOptions(ctx, dmaddr, "", p2pd.WithConnectionGater(g))
+	require.NoError(t, err)
+	defer d1.Close()
+	c1, closeClient := createClient(t, d1.Listener().Multiaddr(), cmaddr)
+	defer closeClient()
+
+	// both the chained gater and the daemon's own rules apply
+	require.Error(t, connect(c1, d2))
+	require.NoError(t, connect(c1, d3))
+	require.NoError(t, c1.BlockPeer(d3.ID()))
+	require.Error(t, connect(c1, d3))
+
+	// a gater can't be set through the libp2p options
+	dmaddr, _, dirCloser = getEndpointsMaker(t)(t)
+	defer dirCloser()
+	_, err = p2pd.NewDaemonWithOptions(ctx, dmaddr, "", p2pd.WithHostOptions(libp2p.ConnectionGater(g)))
+	require.ErrorContains(t, err, "WithConnectionGater")
+}
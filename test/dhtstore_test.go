@@ -0,0 +1,91 @@
+package test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/config"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+)
+
+func createDHTDaemon(t *testing.T, ctx context.Context, store *p2pd.DHTStore) (*p2pd.Daemon, *p2pclient.Client, func()) {
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	d, err := p2pd.NewDaemonWithOptions(ctx, dmaddr, config.DHTServerMode, p2pd.WithDHTStore(store))
+	require.NoError(t, err)
+	c, closeClient := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	return d, c, func() {
+		closeClient()
+		d.Close()
+		dirCloser()
+	}
+}
+
+func TestPersistentDHTStore(t *testing.T) {
+	c := config.NewDefaultConfig().DHT.Datastore
+	c.Path = filepath.Join(t.TempDir(), "dht")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store, err := p2pd.NewDHTStore(c)
+	require.NoError(t, err)
+	d1, c1, closer1 := createDHTDaemon(t, ctx, store)
+	d2, c2, closer2 := createDHTDaemon(t, ctx, nil)
+	defer closer2()
+	require.NoError(t, connect(c2, d1))
+
+	// d2 stores its public key and a provider record with d1, once d1 is in
+	// its routing table
+	pub, err := d2.ID().ExtractPublicKey()
+	require.NoError(t, err)
+	pubBytes, err := crypto.MarshalPublicKey(pub)
+	require.NoError(t, err)
+	key := append([]byte("/pk/"), []byte(d2.ID())...)
+	require.Eventually(t, func() bool {
+		return c2.PutValue(key, pubBytes) == nil
+	}, 5*time.Second, 50*time.Millisecond)
+	require.NoError(t, c2.Provide(randCid(t)))
+
+	stats, err := c1.DHTStorageStats()
+	require.NoError(t, err)
+	require.EqualValues(t, 1, stats.Records)
+
+	// provider records are written in batches, flushed when d1 closes
+	time.Sleep(100 * time.Millisecond)
+	closer1()
+
+	// a restarted daemon still serves them
+	store, err = p2pd.NewDHTStore(c)
+	require.NoError(t, err)
+	_, c3, closer3 := createDHTDaemon(t, ctx, store)
+	defer closer3()
+	stats, err = c3.DHTStorageStats()
+	require.NoError(t, err)
+	require.Equal(t, &p2pclient.DHTStorageStats{Records: 1, Providers: 1, ProvidedKeys: 1}, stats)
+}
+
+func TestDHTStoreClosedOnFailedStart(t *testing.T) {
+	c := config.NewDefaultConfig().DHT.Datastore
+	c.Path = filepath.Join(t.TempDir(), "dht")
+	dmaddr, _, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+
+	// the store is closed whichever option fails
+	store, err := p2pd.NewDHTStore(c)
+	require.NoError(t, err)
+	_, err = p2pd.NewDaemonWithOptions(context.Background(), dmaddr, config.DHTServerMode,
+		p2pd.WithPrivateNetwork(nil),
+		p2pd.WithDHTStore(store),
+	)
+	require.Error(t, err)
+
+	// it can be opened again once closed
+	store, err = p2pd.NewDHTStore(c)
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+}
@@ -0,0 +1,43 @@
+//go:build !windows && !plan9 && !nacl && !js
+
+package test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnosticsSignal(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+	require.NoError(t, connect(c1, d2))
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "diagnostics1.json")
+	d1.SetDiagnosticsFile(path)
+	// keep the other daemon from dumping to stdout
+	d2.SetDiagnosticsFile(filepath.Join(dir, "diagnostics2.json"))
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+
+	var snapshot struct {
+		Conns []struct {
+			Peer string
+		}
+	}
+	require.Eventually(t, func() bool {
+		body, err := os.ReadFile(path)
+		if err != nil {
+			return false
+		}
+		return json.Unmarshal(body, &snapshot) == nil && len(snapshot.Conns) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,77 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+func TestDiagnostics(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err := c1.Subscribe(ctx, "diagnostics")
+	require.NoError(t, err)
+	_, err = c2.Subscribe(ctx, "diagnostics")
+	require.NoError(t, err)
+
+	err = c1.NewStreamHandler([]string{"/diagnostics-test"}, func(info *p2pclient.StreamInfo, conn io.ReadWriteCloser) {
+		conn.Close()
+	})
+	require.NoError(t, err)
+	require.NoError(t, connect(c1, d2))
+
+	hasPeer := func(peers [][]byte, p peer.ID) bool {
+		for _, bp := range peers {
+			if peer.ID(bp) == p {
+				return true
+			}
+		}
+		return false
+	}
+
+	var diag *pb.DiagnosticsResponse
+	// the mesh forms on the first gossipsub heartbeat
+	require.Eventually(t, func() bool {
+		diag, err = c1.Diagnostics()
+		require.NoError(t, err)
+		for _, topic := range diag.Topics {
+			if topic.GetTopic() == "diagnostics" {
+				return hasPeer(topic.Peers, d2.ID()) && hasPeer(topic.MeshPeers, d2.ID())
+			}
+		}
+		return false
+	}, 10*time.Second, 100*time.Millisecond)
+
+	require.Positive(t, diag.GetGoroutines())
+	require.Len(t, diag.Conns, 1)
+	require.Equal(t, d2.ID(), peer.ID(diag.Conns[0].Peer))
+	require.Len(t, diag.Handlers, 1)
+	require.Equal(t, "/diagnostics-test", diag.Handlers[0].GetProto())
+
+	// the HTTP endpoint serves the same snapshot as JSON
+	rec := httptest.NewRecorder()
+	d1.DiagnosticsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/diagnostics", nil))
+	var snapshot struct {
+		Handlers []struct {
+			Proto string
+		}
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &snapshot))
+	require.Len(t, snapshot.Handlers, 1)
+	require.Equal(t, "/diagnostics-test", snapshot.Handlers[0].Proto)
+
+}
@@ -0,0 +1,230 @@
+package test
+
+import (
+	"context"
+	"math"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/identify"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/stretchr/testify/require"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// simulateLAN treats the addresses of the local network as public, like the
+// addresses of peers behind NATs on the internet, returning one of them.
+func simulateLAN(t *testing.T) string {
+	if raceEnabled {
+		// libp2p reads the address ranges from goroutines outliving the test
+		t.Skip("changes address ranges read by libp2p")
+	}
+	addrs, err := net.InterfaceAddrs()
+	require.NoError(t, err)
+	var ip string
+	for _, a := range addrs {
+		if n, ok := a.(*net.IPNet); ok && n.IP.To4() != nil && !n.IP.IsLoopback() {
+			ip = n.IP.String()
+			break
+		}
+	}
+	if ip == "" {
+		t.Skip("no local network address")
+	}
+
+	private, unroutable, thresh := manet.Private4, manet.Unroutable4, identify.ActivationThresh
+	manet.Private4, manet.Unroutable4 = nil, nil
+	// a single peer observing our address is enough to learn it
+	identify.ActivationThresh = 1
+	t.Cleanup(func() {
+		manet.Private4, manet.Unroutable4, identify.ActivationThresh = private, unroutable, thresh
+	})
+	return ip
+}
+
+func createLANDaemon(t *testing.T, ctx context.Context, ip string, opts ...p2pd.Option) (*p2pd.Daemon, *p2pclient.Client, func()) {
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	opts = append(opts, p2pd.WithHostOptions(libp2p.ListenAddrStrings("/ip4/"+ip+"/tcp/0")))
+	d, err := p2pd.NewDaemonWithOptions(ctx, dmaddr, "", opts...)
+	require.NoError(t, err)
+	c, closeClient := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	return d, c, func() {
+		closeClient()
+		d.Close()
+		dirCloser()
+	}
+}
+
+func nextHolePunchEvent(t *testing.T, events <-chan *pb.Event) *pb.HolePunchEvent {
+	select {
+	case evt := <-events:
+		require.Equal(t, pb.Event_HOLE_PUNCH, evt.GetType())
+		return evt.GetHolePunch()
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for a hole punch event")
+		return nil
+	}
+}
+
+func TestHolePunching(t *testing.T) {
+	ip := simulateLAN(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the daemon behind a NAT
+	d1, c1, closer1 := createLANDaemon(t, ctx, ip,
+		p2pd.WithHolePunching(),
+		p2pd.WithForcedReachability(network.ReachabilityPrivate),
+	)
+	defer closer1()
+	d2, c2, closer2 := createLANDaemon(t, ctx, ip)
+	defer closer2()
+	_, c3, closer3 := createLANDaemon(t, ctx, ip)
+	defer closer3()
+
+	diag, err := c1.Diagnostics()
+	require.NoError(t, err)
+	require.True(t, diag.GetHolePunching())
+
+	// hole punching starts once another peer observes our public address
+	require.NoError(t, connect(c3, d1))
+	require.Eventually(t, func() bool {
+		diag, err := c1.Diagnostics()
+		require.NoError(t, err)
+		return diag.GetHolePunchingActive()
+	}, 10*time.Second, 50*time.Millisecond)
+
+	events, err := c1.Events(ctx, pb.Event_HOLE_PUNCH)
+	require.NoError(t, err)
+
+	// a peer with a public address is dialed directly
+	record, err := c2.PeerRecord()
+	require.NoError(t, err)
+	_, _, err = c1.AddPeerRecord(record, 0)
+	require.NoError(t, err)
+
+	success := map[string]string{"method": "direct_dial", "outcome": "success"}
+	before := metricValue(t, "p2pd_holepunch_outcomes_total", success)
+	require.NoError(t, c1.DirectConnect(d2.ID(), 0))
+	hp := nextHolePunchEvent(t, events)
+	require.Equal(t, pb.HolePunchEvent_DIRECT_DIAL, hp.GetType())
+	require.True(t, hp.GetSuccess())
+	require.Equal(t, before+1, metricValue(t, "p2pd_holepunch_outcomes_total", success))
+
+	// a peer that can't be dialed, with no relayed connection to punch a hole
+	// through, can't be upgraded
+	d4, c4, closer4 := createLANDaemon(t, ctx, ip)
+	record, err = c4.PeerRecord()
+	require.NoError(t, err)
+	closer4()
+	_, _, err = c1.AddPeerRecord(record, 0)
+	require.NoError(t, err)
+
+	protocolError := map[string]string{"outcome": "protocol_error"}
+	before = metricValue(t, "p2pd_holepunch_outcomes_total", protocolError)
+	require.Error(t, c1.DirectConnect(d4.ID(), 0))
+	hp = nextHolePunchEvent(t, events)
+	require.Equal(t, pb.HolePunchEvent_DIRECT_DIAL, hp.GetType())
+	require.False(t, hp.GetSuccess())
+	require.NotEmpty(t, hp.GetError())
+	hp = nextHolePunchEvent(t, events)
+	require.Equal(t, pb.HolePunchEvent_PROTOCOL_ERROR, hp.GetType())
+	require.Equal(t, before+1, metricValue(t, "p2pd_holepunch_outcomes_total", protocolError))
+}
+
+func TestHolePunchingRelayed(t *testing.T) {
+	ip := simulateLAN(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the daemons behind NATs learn their public addresses only once they
+	// are connected through the relay, so that the hole punching service of
+	// d1 starts after the relayed connection is made and leaves it to us to
+	// upgrade
+	identify.ActivationThresh = math.MaxInt
+
+	relay, _, closer1 := createLANDaemon(t, ctx, ip)
+	defer closer1()
+	require.NoError(t, relay.EnableRelayV2())
+	d1, c1, closer2 := createLANDaemon(t, ctx, ip,
+		p2pd.WithHolePunching(),
+		p2pd.WithForcedReachability(network.ReachabilityPrivate),
+	)
+	defer closer2()
+	// d2 advertises no addresses, so that d1 can't dial it directly
+	d2, c2, closer3 := createLANDaemon(t, ctx, ip,
+		p2pd.WithHolePunching(),
+		p2pd.WithForcedReachability(network.ReachabilityPrivate),
+		p2pd.WithHostOptions(libp2p.AddrsFactory(func([]ma.Multiaddr) []ma.Multiaddr { return nil })),
+	)
+	defer closer3()
+
+	rsvp, err := c1.ReserveRelay(relay.ID(), relay.Addrs())
+	require.NoError(t, err)
+	require.NoError(t, c2.Connect(d1.ID(), rsvp.Addrs))
+
+	identify.ActivationThresh = 1
+	for _, c := range []*p2pclient.Client{c1, c2} {
+		require.Eventually(t, func() bool {
+			diag, err := c.Diagnostics()
+			require.NoError(t, err)
+			return diag.GetHolePunchingActive()
+		}, 10*time.Second, 50*time.Millisecond)
+	}
+
+	events, err := c1.Events(ctx, pb.Event_HOLE_PUNCH)
+	require.NoError(t, err)
+
+	// the peer that accepted the relayed connection punches a hole through
+	success := map[string]string{"method": "hole_punch", "outcome": "success"}
+	before := metricValue(t, "p2pd_holepunch_outcomes_total", success)
+	require.NoError(t, c1.DirectConnect(d2.ID(), 0))
+	hp := nextHolePunchEvent(t, events)
+	require.Equal(t, pb.HolePunchEvent_START, hp.GetType())
+	require.NotEmpty(t, hp.GetAddrs())
+	hp = nextHolePunchEvent(t, events)
+	require.Equal(t, pb.HolePunchEvent_ATTEMPT, hp.GetType())
+	hp = nextHolePunchEvent(t, events)
+	require.Equal(t, pb.HolePunchEvent_END, hp.GetType())
+	require.True(t, hp.GetSuccess())
+	require.Equal(t, before+1, metricValue(t, "p2pd_holepunch_outcomes_total", success))
+
+	// the daemons are now connected directly
+	require.Eventually(t, func() bool {
+		diag, err := c1.Diagnostics()
+		require.NoError(t, err)
+		for _, conn := range diag.GetConns() {
+			if peer.ID(conn.GetPeer()) != d2.ID() {
+				continue
+			}
+			addr, err := ma.NewMultiaddrBytes(conn.GetAddr())
+			require.NoError(t, err)
+			if !strings.Contains(addr.String(), "p2p-circuit") {
+				return true
+			}
+		}
+		return false
+	}, 10*time.Second, 50*time.Millisecond)
+}
+
+func TestHolePunchingDisabled(t *testing.T) {
+	_, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	diag, err := c1.Diagnostics()
+	require.NoError(t, err)
+	require.False(t, diag.GetHolePunching())
+	require.Error(t, c1.DirectConnect(d2.ID(), 0))
+	require.Error(t, c1.DirectConnect(peer.ID("foobar"), 0))
+}
@@ -0,0 +1,99 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+)
+
+func TestEncryptedIdentity(t *testing.T) {
+	dir := t.TempDir()
+	priv, _, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "identity")
+	require.NoError(t, p2pd.WriteEncryptedIdentity(priv, path, []byte("correct horse")))
+
+	// the key isn't stored in the clear
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	raw, err := crypto.MarshalPrivateKey(priv)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), string(raw))
+
+	_, err = p2pd.ReadIdentity(path)
+	require.ErrorIs(t, err, p2pd.ErrIdentityEncrypted)
+
+	passphrase := func(pass string) func() ([]byte, error) {
+		return func() ([]byte, error) { return []byte(pass), nil }
+	}
+	_, err = p2pd.ReadIdentityPassphrase(path, passphrase("battery staple"))
+	require.ErrorIs(t, err, p2pd.ErrWrongPassphrase)
+
+	key, err := p2pd.ReadIdentityPassphrase(path, passphrase("correct horse"))
+	require.NoError(t, err)
+	require.True(t, priv.Equals(key))
+
+	// the passphrase file and environment variable are read in that order
+	passFile := filepath.Join(dir, "passphrase")
+	require.NoError(t, os.WriteFile(passFile, []byte("correct horse\n"), 0600))
+	t.Setenv(p2pd.PassphraseEnv, "battery staple")
+	pass, err := p2pd.ReadPassphrase(passFile, "")
+	require.NoError(t, err)
+	require.Equal(t, "correct horse", string(pass))
+	pass, err = p2pd.ReadPassphrase("", "")
+	require.NoError(t, err)
+	require.Equal(t, "battery staple", string(pass))
+
+	// plaintext identities are still read, without a passphrase
+	plainPath := filepath.Join(dir, "plain")
+	require.NoError(t, p2pd.WriteIdentity(priv, plainPath))
+	key, err = p2pd.ReadIdentityPassphrase(plainPath, func() ([]byte, error) {
+		t.Fatal("asked for the passphrase of a plaintext identity")
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.True(t, priv.Equals(key))
+}
+
+func TestPEMIdentity(t *testing.T) {
+	dir := t.TempDir()
+	for _, typ := range []int{crypto.RSA, crypto.Ed25519, crypto.ECDSA} {
+		priv, _, err := crypto.GenerateKeyPair(typ, 2048)
+		require.NoError(t, err)
+
+		path := filepath.Join(dir, priv.Type().String())
+		require.NoError(t, p2pd.WritePEMIdentity(priv, path))
+		key, err := p2pd.ReadIdentity(path)
+		require.NoError(t, err)
+		require.True(t, priv.Equals(key), priv.Type())
+	}
+
+	priv, _, err := crypto.GenerateSecp256k1Key(nil)
+	require.NoError(t, err)
+	require.Error(t, p2pd.WritePEMIdentity(priv, filepath.Join(dir, "secp256k1")))
+}
+
+func TestGenerateIdentity(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "identity")
+	priv, err := p2pd.ReadOrGenerateIdentity(path, crypto.Ed25519, 0, nil)
+	require.NoError(t, err)
+
+	// the generated key is reused afterwards
+	key, err := p2pd.ReadOrGenerateIdentity(path, crypto.Ed25519, 0, nil)
+	require.NoError(t, err)
+	require.True(t, priv.Equals(key))
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	require.NoError(t, os.Chmod(path, 0644))
+	_, err = p2pd.ReadOrGenerateIdentity(path, crypto.Ed25519, 0, nil)
+	require.ErrorContains(t, err, "too open")
+}
@@ -2,7 +2,9 @@ package test
 
 import (
 	"context"
+	"crypto/rand"
 	"io"
+	"runtime"
 	"testing"
 	"time"
 
@@ -126,6 +128,91 @@ func TestStreams(t *testing.T) {
 	conn.Close()
 }
 
+func TestStreamsSharedMemory(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+	if err := connect(c1, d2); err != nil {
+		t.Fatal(err)
+	}
+	c1.EnableSharedMemory(true)
+	c2.EnableSharedMemory(true)
+	testprotos := []string{"/test"}
+
+	err := c1.NewStreamHandler(testprotos, func(info *p2pclient.StreamInfo, conn io.ReadWriteCloser) {
+		defer conn.Close()
+		io.Copy(conn, conn)
+	})
+	require.NoError(t, err)
+
+	_, conn, err := c2.NewStream(d1.ID(), testprotos)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	data := make([]byte, 1<<20)
+	rand.Read(data)
+	go func() {
+		if _, err := conn.Write(data); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	buf := make([]byte, len(data))
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	require.Equal(t, data, buf)
+}
+
+func TestStreamsPassFd(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file descriptor passing requires unix sockets")
+	}
+
+	dmaddr, _, dirCloser := makeUnixEndpoints(t)
+	defer dirCloser()
+	d1, closeDaemon := createDaemon(t, dmaddr)
+	defer closeDaemon()
+	// no listen address; streams are passed over the control connection
+	c1, err := p2pclient.NewClient(d1.Listener().Multiaddr(), nil)
+	require.NoError(t, err)
+
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+	if err := connect(c1, d2); err != nil {
+		t.Fatal(err)
+	}
+	testprotos := []string{"/test"}
+
+	err = c1.NewStreamHandler(testprotos, func(info *p2pclient.StreamInfo, conn io.ReadWriteCloser) {
+		defer conn.Close()
+		io.Copy(conn, conn)
+	})
+	require.NoError(t, err)
+
+	_, conn, err := c2.NewStream(d1.ID(), testprotos)
+	require.NoError(t, err)
+
+	_, err = conn.Write([]byte("test"))
+	require.NoError(t, err)
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	require.Equal(t, "test", string(buf))
+	conn.Close()
+
+	// handlers go away with the connection they were registered on
+	require.NoError(t, c1.Close())
+	require.Eventually(t, func() bool {
+		_, conn, err := c2.NewStream(d1.ID(), testprotos)
+		if err != nil {
+			return true
+		}
+		conn.Close()
+		return false
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
 func TestRelayV2(t *testing.T) {
 	relayHost, _, closer1 := createDaemonClientPair(t)
 	defer closer1()
@@ -0,0 +1,54 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libp2p/go-libp2p-daemon/config"
+)
+
+func TestMDNS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	// a service name of its own keeps the test from discovering other peers
+	c := config.NewDefaultConfig().MDNS
+	c.ServiceName = fmt.Sprintf("_p2pd-test-%d._udp", rand.Int63())
+	peers, err := c1.DiscoveredPeers(ctx)
+	require.NoError(t, err)
+	c.AutoConnect = true
+	require.NoError(t, d1.EnableMDNS(c))
+	c.AutoConnect = false
+	require.NoError(t, d2.EnableMDNS(c))
+
+	select {
+	case pi := <-peers:
+		require.Equal(t, d2.ID(), pi.ID)
+		require.NotEmpty(t, pi.Addrs)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for a discovered peer")
+	}
+
+	// the daemon auto-connects to the peers it discovers
+	require.Eventually(t, func() bool {
+		diag, err := c1.Diagnostics()
+		require.NoError(t, err)
+		for _, conn := range diag.GetConns() {
+			if peer.ID(conn.GetPeer()) == d2.ID() {
+				return true
+			}
+		}
+		return false
+	}, 10*time.Second, 50*time.Millisecond)
+}
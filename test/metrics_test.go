@@ -0,0 +1,113 @@
+package test
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	"github.com/libp2p/go-libp2p/core/test"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// metricValue sums the counters and gauges of the named metric family whose
+// labels include labels.
+func metricValue(t *testing.T, name string, labels map[string]string) float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	var sum float64
+	for _, mf := range families {
+		if mf.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range mf.GetMetric() {
+			have := make(map[string]string)
+			for _, lp := range m.GetLabel() {
+				have[lp.GetName()] = lp.GetValue()
+			}
+			for k, v := range labels {
+				if have[k] != v {
+					continue metrics
+				}
+			}
+			sum += m.GetCounter().GetValue() + m.GetGauge().GetValue()
+		}
+	}
+	return sum
+}
+
+func TestStreamMetrics(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+	if err := connect(c1, d2); err != nil {
+		t.Fatal(err)
+	}
+	testprotos := []string{"/metrics-test"}
+
+	unsupported := map[string]string{"reason": "protocol_unsupported"}
+	before := metricValue(t, "p2pd_stream_open_failures_total", unsupported)
+	_, _, err := c2.NewStream(d1.ID(), testprotos)
+	require.Error(t, err)
+	require.Equal(t, before+1, metricValue(t, "p2pd_stream_open_failures_total", unsupported))
+
+	// a peer with no known addresses can't be dialed
+	unknown, err := test.RandPeerID()
+	require.NoError(t, err)
+	dial := map[string]string{"reason": "dial"}
+	before = metricValue(t, "p2pd_stream_open_failures_total", dial)
+	_, _, err = c2.NewStream(unknown, testprotos)
+	require.Error(t, err)
+	require.Equal(t, before+1, metricValue(t, "p2pd_stream_open_failures_total", dial))
+
+	err = c1.NewStreamHandler(testprotos, func(info *p2pclient.StreamInfo, conn io.ReadWriteCloser) {
+		defer conn.Close()
+		io.Copy(conn, conn)
+	})
+	require.NoError(t, err)
+
+	_, conn, err := c2.NewStream(d1.ID(), testprotos)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("test"))
+	require.NoError(t, err)
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+
+	proto := map[string]string{"protocol": testprotos[0]}
+	require.Equal(t, 1.0, metricValue(t, "p2pd_stream_opened_total", proto))
+	require.Equal(t, 1.0, metricValue(t, "p2pd_stream_accepted_total", proto))
+	// both the opening and the accepting daemon are piping the stream
+	require.Equal(t, 2.0, metricValue(t, "p2pd_stream_piped", proto))
+	require.Eventually(t, func() bool {
+		in := map[string]string{"protocol": testprotos[0], "direction": "in"}
+		return metricValue(t, "p2pd_stream_bytes_total", in) >= 8
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestRequestMetrics(t *testing.T) {
+	d, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	identify := map[string]string{"type": "IDENTIFY", "subtype": ""}
+	findPeer := map[string]string{"type": "DHT", "subtype": "FIND_PEER"}
+	identifies := metricValue(t, "p2pd_control_requests_total", identify)
+	findPeers := metricValue(t, "p2pd_control_requests_total", findPeer)
+	findPeerErrors := metricValue(t, "p2pd_control_request_errors_total", findPeer)
+
+	_, _, err := c.Identify()
+	require.NoError(t, err)
+	// the test daemons run without a DHT
+	_, err = c.FindPeer(d.ID())
+	require.Error(t, err)
+
+	require.Equal(t, identifies+1, metricValue(t, "p2pd_control_requests_total", identify))
+	require.Equal(t, findPeers+1, metricValue(t, "p2pd_control_requests_total", findPeer))
+	require.Equal(t, findPeerErrors+1, metricValue(t, "p2pd_control_request_errors_total", findPeer))
+}
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/record"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerRecords(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	_, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	b, err := c1.PeerRecord()
+	require.NoError(t, err)
+
+	_, rec, err := record.ConsumeEnvelope(b, peer.PeerRecordEnvelopeDomain)
+	require.NoError(t, err)
+	pr, ok := rec.(*peer.PeerRecord)
+	require.True(t, ok)
+	require.Equal(t, d1.ID(), pr.PeerID)
+	require.NotEmpty(t, pr.Addrs)
+
+	// the record is exchanged out-of-band and added to the other daemon
+	info, accepted, err := c2.AddPeerRecord(b, time.Hour)
+	require.NoError(t, err)
+	require.True(t, accepted)
+	require.Equal(t, d1.ID(), info.ID)
+	require.ElementsMatch(t, pr.Addrs, info.Addrs)
+
+	// and its addresses are dialed without being given
+	require.NoError(t, c2.Connect(d1.ID(), nil))
+
+	// envelopes of other types are rejected
+	other, err := c1.SealEnvelope("my-app", []byte("/my-app/record"), []byte("payload"))
+	require.NoError(t, err)
+	_, _, err = c2.AddPeerRecord(other, 0)
+	require.Error(t, err)
+
+	// the daemon's own record is rejected
+	_, _, err = c1.AddPeerRecord(b, 0)
+	require.Error(t, err)
+}
+
+func TestIdentifyPeerRecord(t *testing.T) {
+	d, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	b, err := c.PeerRecord()
+	require.NoError(t, err)
+
+	control, err := manet.Dial(d.Listener().Multiaddr())
+	require.NoError(t, err)
+	defer control.Close()
+	w := ggio.NewDelimitedWriter(control)
+	r := ggio.NewDelimitedReader(control, p2pclient.MessageSizeMax)
+	require.NoError(t, w.WriteMsg(&pb.Request{Type: pb.Request_IDENTIFY.Enum()}))
+	res := &pb.Response{}
+	require.NoError(t, r.ReadMsg(res))
+	require.Equal(t, b, res.GetIdentify().GetPeerRecord())
+}
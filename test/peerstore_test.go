@@ -0,0 +1,62 @@
+package test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/stretchr/testify/require"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/config"
+)
+
+func TestPersistentPeerstore(t *testing.T) {
+	c := config.Peerstore{Path: filepath.Join(t.TempDir(), "peerstore"), GCInterval: time.Hour}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d2, _, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	ps, err := p2pd.NewPeerstore(ctx, c)
+	require.NoError(t, err)
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+	d1, err := p2pd.NewDaemon(ctx, dmaddr, "", libp2p.Peerstore(ps))
+	require.NoError(t, err)
+	c1, closeClient := createClient(t, d1.Listener().Multiaddr(), cmaddr)
+	require.NoError(t, connect(c1, d2))
+	// identify fills in the addresses, protocols and metadata of d2
+	require.Eventually(t, func() bool {
+		protos, err := ps.GetProtocols(d2.ID())
+		require.NoError(t, err)
+		return len(protos) > 0 && len(ps.Addrs(d2.ID())) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+	closeClient()
+	// closing the daemon closes the peerstore and its datastore
+	d1.Close()
+
+	ps, err = p2pd.NewPeerstore(ctx, c)
+	require.NoError(t, err)
+	require.NotEmpty(t, ps.Addrs(d2.ID()))
+	require.NotNil(t, ps.PubKey(d2.ID()))
+	protos, err := ps.GetProtocols(d2.ID())
+	require.NoError(t, err)
+	require.NotEmpty(t, protos)
+	agent, err := ps.Get(d2.ID(), "AgentVersion")
+	require.NoError(t, err)
+	require.NotEmpty(t, agent)
+
+	// a restarted daemon reconnects from the addresses it remembers
+	dmaddr, cmaddr, dirCloser = getEndpointsMaker(t)(t)
+	defer dirCloser()
+	d3, err := p2pd.NewDaemon(ctx, dmaddr, "", libp2p.Peerstore(ps))
+	require.NoError(t, err)
+	defer d3.Close()
+	c3, closeClient := createClient(t, d3.Listener().Multiaddr(), cmaddr)
+	defer closeClient()
+	require.NoError(t, c3.Connect(d2.ID(), nil))
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/stretchr/testify/require"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/config"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+)
+
+func writeSwarmKey(t *testing.T) string {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "swarm.key")
+	err = os.WriteFile(path, []byte("/key/swarm/psk/1.0.0/\n/base16/\n"+hex.EncodeToString(key)+"\n"), 0600)
+	require.NoError(t, err)
+	return path
+}
+
+func createPrivateDaemon(t *testing.T, ctx context.Context, keyFile string) (*p2pd.Daemon, *p2pclient.Client, func()) {
+	psk, err := p2pd.ReadSwarmKey(keyFile)
+	require.NoError(t, err)
+	tc := config.NewDefaultConfig().Transports
+	tc.QUIC.Enabled, tc.WebTransport.Enabled, tc.WebRTC.Enabled = false, false, false
+	transports, err := p2pd.TransportOptions(tc)
+	require.NoError(t, err)
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	d, err := p2pd.NewDaemonWithOptions(ctx, dmaddr, "",
+		p2pd.WithPrivateNetwork(psk),
+		p2pd.WithHostOptions(transports, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0")),
+	)
+	require.NoError(t, err)
+	c, closeClient := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	return d, c, func() {
+		closeClient()
+		d.Close()
+		dirCloser()
+	}
+}
+
+func TestPrivateNetwork(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	keyFile := writeSwarmKey(t)
+	d1, c1, closer1 := createPrivateDaemon(t, ctx, keyFile)
+	defer closer1()
+	d2, c2, closer2 := createPrivateDaemon(t, ctx, keyFile)
+	defer closer2()
+	_, c3, closer3 := createPrivateDaemon(t, ctx, writeSwarmKey(t))
+	defer closer3()
+	_, c4, closer4 := createDaemonClientPair(t)
+	defer closer4()
+
+	// peers sharing the key connect
+	require.NoError(t, connect(c1, d2))
+
+	// peers with another key or none don't
+	require.Error(t, connect(c3, d1))
+	require.Error(t, connect(c4, d1))
+
+	diag, err := c2.Diagnostics()
+	require.NoError(t, err)
+	require.True(t, diag.GetPrivateNetwork())
+	diag, err = c4.Diagnostics()
+	require.NoError(t, err)
+	require.False(t, diag.GetPrivateNetwork())
+}
+
+func TestPrivateNetworkTransports(t *testing.T) {
+	psk, err := p2pd.ReadSwarmKey(writeSwarmKey(t))
+	require.NoError(t, err)
+	dmaddr, _, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+
+	// the default transports include QUIC, which can't have a pre-shared key
+	transports, err := p2pd.TransportOptions(config.NewDefaultConfig().Transports)
+	require.NoError(t, err)
+	_, err = p2pd.NewDaemonWithOptions(context.Background(), dmaddr, "",
+		p2pd.WithPrivateNetwork(psk),
+		p2pd.WithHostOptions(transports),
+	)
+	require.Error(t, err)
+}
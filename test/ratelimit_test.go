@@ -0,0 +1,46 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/libp2p/go-libp2p-daemon/config"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+)
+
+func TestRateLimits(t *testing.T) {
+	d, c, closer := createDaemonClientPair(t)
+	defer closer()
+
+	err := d.EnableRateLimits(config.RateLimit{
+		Enabled: true,
+		Requests: map[string]config.RateLimitRule{
+			"IDENTIFY": {Rate: 0.001, Burst: 2},
+		},
+	})
+	require.NoError(t, err)
+
+	labels := map[string]string{"type": "IDENTIFY", "limit": "rate"}
+	before := metricValue(t, "p2pd_control_requests_limited_total", labels)
+
+	for i := 0; i < 2; i++ {
+		_, _, err := c.Identify()
+		require.NoError(t, err)
+	}
+	_, _, err = c.Identify()
+	require.Error(t, err)
+	require.True(t, errors.Is(err, p2pclient.ErrRateLimited), "unexpected error %s", err)
+	require.Equal(t, before+1, metricValue(t, "p2pd_control_requests_limited_total", labels))
+
+	// other request types aren't limited
+	_, err = c.Diagnostics()
+	require.NoError(t, err)
+
+	err = d.EnableRateLimits(config.RateLimit{
+		Enabled:  true,
+		Requests: map[string]config.RateLimitRule{"DHT.NOT_A_TYPE": {Rate: 1, Burst: 1}},
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,99 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/config"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+func TestReachability(t *testing.T) {
+	ip := simulateLAN(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, c1, closer1 := createLANDaemon(t, ctx, ip, p2pd.WithForcedReachability(network.ReachabilityPrivate))
+	defer closer1()
+	reachability, forced, err := c1.GetReachability()
+	require.NoError(t, err)
+	require.Equal(t, network.ReachabilityPrivate, reachability)
+	require.True(t, forced)
+
+	// AutoNAT asks the first peer offering the service to dial it back, if
+	// the peer is at another IP address
+	_, c2, closer2 := createLANDaemon(t, ctx, "127.0.0.2")
+	defer closer2()
+	d3, _, closer3 := createLANDaemon(t, ctx, "127.0.0.1", p2pd.WithHostOptions(libp2p.EnableNATService()))
+	defer closer3()
+
+	reachability, forced, err = c2.GetReachability()
+	require.NoError(t, err)
+	require.Equal(t, network.ReachabilityUnknown, reachability)
+	require.False(t, forced)
+
+	events, err := c2.Events(ctx, pb.Event_REACHABILITY)
+	require.NoError(t, err)
+	require.NoError(t, connect(c2, d3))
+	select {
+	case evt := <-events:
+		require.Equal(t, pb.Event_REACHABILITY, evt.GetType())
+		require.Equal(t, pb.Reachability_PUBLIC, evt.GetReachability())
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for a reachability event")
+	}
+
+	reachability, _, err = c2.GetReachability()
+	require.NoError(t, err)
+	require.Equal(t, network.ReachabilityPublic, reachability)
+}
+
+func TestAutoRelay(t *testing.T) {
+	ip := simulateLAN(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	relay, _, closer1 := createLANDaemon(t, ctx, ip)
+	defer closer1()
+	require.NoError(t, relay.EnableRelayV2())
+
+	// the daemon behind a NAT advertises its addresses through the relay
+	_, c2, closer2 := createLANDaemon(t, ctx, ip,
+		p2pd.WithForcedReachability(network.ReachabilityPrivate),
+		p2pd.WithHostOptions(libp2p.EnableAutoRelayWithStaticRelays([]peer.AddrInfo{{ID: relay.ID(), Addrs: relay.Addrs()}})),
+	)
+	defer closer2()
+	require.Eventually(t, func() bool {
+		_, addrs, err := c2.Identify()
+		require.NoError(t, err)
+		for _, a := range addrs {
+			if strings.Contains(a.String(), relay.ID().String()+"/p2p-circuit") {
+				return true
+			}
+		}
+		return false
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+func TestAutoRelayWithDHT(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	dmaddr, _, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+
+	// relays are found through the DHT
+	_, err := p2pd.NewDaemonWithOptions(ctx, dmaddr, "", p2pd.WithAutoRelayDHT())
+	require.Error(t, err)
+
+	d, err := p2pd.NewDaemonWithOptions(ctx, dmaddr, config.DHTClientMode, p2pd.WithAutoRelayDHT())
+	require.NoError(t, err)
+	d.Close()
+}
@@ -0,0 +1,59 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/config"
+)
+
+func TestRelayService(t *testing.T) {
+	relay, _, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+	_, c3, closer3 := createDaemonClientPair(t)
+	defer closer3()
+
+	rc := config.NewDefaultConfig().Relay.Service
+	rc.ReservationTTL = 10 * time.Minute
+	rc.CircuitDuration = 30 * time.Second
+	rc.CircuitData = 1 << 20
+	rc.AllowedPeers = []string{d2.ID().String()}
+	// a subnet no peer is in
+	rc.AllowedSubnets = []string{"198.51.100.0/24"}
+	opts, err := p2pd.RelayServiceOptions(rc)
+	require.NoError(t, err)
+	require.NoError(t, relay.EnableRelayV2(opts...))
+
+	opened := map[string]string{"type": "opened"}
+	before := metricValue(t, "libp2p_relaysvc_reservations_total", opened)
+	rsvp, err := c2.ReserveRelay(relay.ID(), relay.Addrs())
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(rc.ReservationTTL), rsvp.Expiration, time.Minute)
+	require.Equal(t, rc.CircuitDuration, rsvp.LimitDuration)
+	require.Equal(t, uint64(rc.CircuitData), rsvp.LimitData)
+	require.Equal(t, before+1, metricValue(t, "libp2p_relaysvc_reservations_total", opened))
+
+	// peers outside the ACL can't reserve slots
+	rejected := map[string]string{"status": "rejected"}
+	before = metricValue(t, "libp2p_relaysvc_reservation_request_response_status_total", rejected)
+	_, err = c3.ReserveRelay(relay.ID(), relay.Addrs())
+	require.Error(t, err)
+	require.Equal(t, before+1, metricValue(t, "libp2p_relaysvc_reservation_request_response_status_total", rejected))
+}
+
+func TestRelayServiceOptions(t *testing.T) {
+	rc := config.NewDefaultConfig().Relay.Service
+	rc.AllowedPeers = []string{"not a peer"}
+	_, err := p2pd.RelayServiceOptions(rc)
+	require.Error(t, err)
+
+	rc = config.NewDefaultConfig().Relay.Service
+	rc.AllowedSubnets = []string{"10.0.0.0"}
+	_, err = p2pd.RelayServiceOptions(rc)
+	require.Error(t, err)
+}
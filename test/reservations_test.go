@@ -0,0 +1,77 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	ggio "github.com/gogo/protobuf/io"
+	"github.com/libp2p/go-libp2p/core/peer"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/stretchr/testify/require"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+// disconnect asks d to disconnect from p, which the client has no method for.
+func disconnect(t *testing.T, d *p2pd.Daemon, p peer.ID) {
+	control, err := manet.Dial(d.Listener().Multiaddr())
+	require.NoError(t, err)
+	defer control.Close()
+	w := ggio.NewDelimitedWriter(control)
+	r := ggio.NewDelimitedReader(control, p2pclient.MessageSizeMax)
+	require.NoError(t, w.WriteMsg(&pb.Request{
+		Type:       pb.Request_DISCONNECT.Enum(),
+		Disconnect: &pb.DisconnectRequest{Peer: []byte(p)},
+	}))
+	res := &pb.Response{}
+	require.NoError(t, r.ReadMsg(res))
+	require.Equal(t, pb.Response_OK, res.GetType())
+}
+
+func TestRelayReservations(t *testing.T) {
+	relay, _, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	require.NoError(t, relay.EnableRelayV2())
+	d2, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+	_, c3, closer3 := createDaemonClientPair(t)
+	defer closer3()
+
+	rsvp, err := c2.ReserveRelay(relay.ID(), relay.Addrs())
+	require.NoError(t, err)
+	require.Equal(t, relay.ID(), rsvp.Relay)
+	require.WithinDuration(t, time.Now().Add(time.Hour), rsvp.Expiration, time.Minute)
+	require.NotEmpty(t, rsvp.Addrs)
+	require.Empty(t, rsvp.Error)
+
+	rsvps, err := c2.RelayReservations()
+	require.NoError(t, err)
+	require.Len(t, rsvps, 1)
+	require.Equal(t, relay.ID(), rsvps[0].Relay)
+
+	// peers reach the daemon at its circuit addresses
+	require.NoError(t, c3.Connect(d2.ID(), rsvp.Addrs))
+
+	// the relay drops the reservation with the connection, and the daemon
+	// makes it again
+	disconnect(t, d2, relay.ID())
+	require.Eventually(t, func() bool {
+		_, c4, closer4 := createDaemonClientPair(t)
+		defer closer4()
+		return c4.Connect(d2.ID(), rsvp.Addrs) == nil
+	}, 10*time.Second, 100*time.Millisecond)
+
+	require.NoError(t, c2.CancelRelayReservation(relay.ID()))
+	rsvps, err = c2.RelayReservations()
+	require.NoError(t, err)
+	require.Empty(t, rsvps)
+	require.Error(t, c2.CancelRelayReservation(relay.ID()))
+
+	// a peer that isn't a relay has no slots
+	_, err = c2.ReserveRelay(d2.ID(), nil)
+	require.Error(t, err)
+	_, err = c3.ReserveRelay(d2.ID(), d2.Addrs())
+	require.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/stretchr/testify/require"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/config"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+)
+
+func TestResourceUsage(t *testing.T) {
+	_, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	_, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	done := make(chan struct{})
+	defer close(done)
+	err := c1.NewStreamHandler([]string{"/resources-test"}, func(info *p2pclient.StreamInfo, conn io.ReadWriteCloser) {
+		<-done
+		conn.Close()
+	})
+	require.NoError(t, err)
+
+	id, addrs, err := c1.Identify()
+	require.NoError(t, err)
+	require.NoError(t, c2.Connect(id, addrs))
+	_, conn, err := c2.NewStream(id, []string{"/resources-test"})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	service := func(usage *pb.ResourceUsageResponse) *pb.ResourceScopeUsage {
+		for _, s := range usage.Services {
+			if s.GetName() == p2pd.ResourceService {
+				return s
+			}
+		}
+		return nil
+	}
+
+	require.Eventually(t, func() bool {
+		usage, err := c1.ResourceUsage()
+		require.NoError(t, err)
+		s := service(usage)
+		return s != nil && s.GetStreamsInbound() == 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	usage, err := c1.ResourceUsage()
+	require.NoError(t, err)
+	require.NotNil(t, usage.System)
+	require.Positive(t, usage.System.GetMemoryLimit())
+	require.Positive(t, service(usage).GetMemory())
+}
+
+func TestResourceLimits(t *testing.T) {
+	dir := t.TempDir()
+	limits := filepath.Join(dir, "limits.json")
+	err := os.WriteFile(limits, []byte(`{"Service": {"libp2p.daemon": {"StreamsInbound": "blockAll"}}}`), 0644)
+	require.NoError(t, err)
+
+	rm, err := p2pd.NewResourceManager(config.ResourceManager{Limits: limits})
+	require.NoError(t, err)
+
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	defer dirCloser()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d1, err := p2pd.NewDaemon(ctx, dmaddr, "", libp2p.ResourceManager(rm))
+	require.NoError(t, err)
+	defer d1.Close()
+	c1, closeClient := createClient(t, d1.Listener().Multiaddr(), cmaddr)
+	defer closeClient()
+	_, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	err = c1.NewStreamHandler([]string{"/resources-limited"}, func(info *p2pclient.StreamInfo, conn io.ReadWriteCloser) {
+		conn.Close()
+	})
+	require.NoError(t, err)
+
+	labels := map[string]string{"protocol": "/resources-limited", "reason": "resource_limit"}
+	before := metricValue(t, "p2pd_stream_accept_failures_total", labels)
+
+	require.NoError(t, c2.Connect(d1.ID(), d1.Addrs()))
+	_, conn, err := c2.NewStream(d1.ID(), []string{"/resources-limited"})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// the stream is reset instead of being handed to the handler
+	_, err = io.ReadAll(conn)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return metricValue(t, "p2pd_stream_accept_failures_total", labels) == before+1
+	}, 5*time.Second, 50*time.Millisecond)
+}
@@ -0,0 +1,75 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/record"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	_, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	data := []byte("attestation")
+	sig, pub, err := c1.Sign(data, "my-app")
+	require.NoError(t, err)
+	require.True(t, d1.ID().MatchesPublicKey(pub))
+
+	// any daemon verifies the signature by peer ID or public key
+	valid, err := c2.Verify(data, sig, "my-app", d1.ID())
+	require.NoError(t, err)
+	require.True(t, valid)
+	valid, err = c2.VerifyWithKey(data, sig, "my-app", pub)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	// but not for other data or domains
+	valid, err = c2.Verify([]byte("forged"), sig, "my-app", d1.ID())
+	require.NoError(t, err)
+	require.False(t, valid)
+	valid, err = c2.Verify(data, sig, "other-app", d1.ID())
+	require.NoError(t, err)
+	require.False(t, valid)
+	valid, err = c2.Verify(data, sig, "", d1.ID())
+	require.NoError(t, err)
+	require.False(t, valid)
+
+	// even without a domain, the data isn't signed as is
+	sig, _, err = c1.Sign(data, "")
+	require.NoError(t, err)
+	valid, err = c2.Verify(data, sig, "", d1.ID())
+	require.NoError(t, err)
+	require.True(t, valid)
+	valid, err = pub.Verify(data, sig)
+	require.NoError(t, err)
+	require.False(t, valid)
+}
+
+func TestEnvelopes(t *testing.T) {
+	d1, c1, closer1 := createDaemonClientPair(t)
+	defer closer1()
+	_, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+
+	payloadType := []byte("/my-app/record")
+	b, err := c1.SealEnvelope("my-app", payloadType, []byte("payload"))
+	require.NoError(t, err)
+
+	env, err := c2.OpenEnvelope(b, "my-app")
+	require.NoError(t, err)
+	require.Equal(t, d1.ID(), env.Peer)
+	require.True(t, d1.ID().MatchesPublicKey(env.PublicKey))
+	require.Equal(t, payloadType, env.PayloadType)
+	require.Equal(t, []byte("payload"), env.Payload)
+
+	// envelopes are libp2p signed envelopes
+	e, err := record.UnmarshalEnvelope(b)
+	require.NoError(t, err)
+	require.Equal(t, []byte("payload"), e.RawPayload)
+
+	_, err = c2.OpenEnvelope(b, "other-app")
+	require.Error(t, err)
+}
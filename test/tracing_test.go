@@ -0,0 +1,160 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ggio "github.com/gogo/protobuf/io"
+	proto "github.com/gogo/protobuf/proto"
+	"github.com/libp2p/go-libp2p-daemon/config"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/stretchr/testify/require"
+)
+
+type spanContext struct {
+	TraceID string
+	SpanID  string
+}
+
+type exportedSpan struct {
+	Name        string
+	SpanContext spanContext
+	Parent      spanContext
+}
+
+func readSpans(t *testing.T, path string) []exportedSpan {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var spans []exportedSpan
+	dec := json.NewDecoder(f)
+	for {
+		var span exportedSpan
+		err := dec.Decode(&span)
+		if err == io.EOF {
+			return spans
+		}
+		require.NoError(t, err)
+		spans = append(spans, span)
+	}
+}
+
+func findSpan(spans []exportedSpan, name string, parent spanContext) *exportedSpan {
+	for _, span := range spans {
+		if span.Name == name && (parent.SpanID == "" || span.Parent == parent) {
+			return &span
+		}
+	}
+	return nil
+}
+
+// findChild returns a span named name whose parent is a span named
+// parentName.
+func findChild(spans []exportedSpan, name, parentName string) *exportedSpan {
+	for _, parent := range spans {
+		if parent.Name != parentName {
+			continue
+		}
+		if span := findSpan(spans, name, parent.SpanContext); span != nil {
+			return span
+		}
+	}
+	return nil
+}
+
+func TestTracing(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	d1, c1, closer1 := createDHTDaemon(t, ctx, nil)
+	d2, c2, closer2 := createDHTDaemon(t, ctx, nil)
+	defer closer2()
+	d3, c3, closer3 := createDaemonClientPair(t)
+	defer closer3()
+
+	path := filepath.Join(t.TempDir(), "spans.json")
+	err := d1.EnableTracing(config.Tracing{Enabled: true, File: path, SampleRatio: 1})
+	require.NoError(t, err)
+
+	// a request continuing the client's trace
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	const spanID = "00f067aa0ba902b7"
+	control, err := manet.Dial(d1.Listener().Multiaddr())
+	require.NoError(t, err)
+	w := ggio.NewDelimitedWriter(control)
+	r := ggio.NewDelimitedReader(control, p2pclient.MessageSizeMax)
+	err = w.WriteMsg(&pb.Request{
+		Type:        pb.Request_IDENTIFY.Enum(),
+		Traceparent: proto.String("00-" + traceID + "-" + spanID + "-01"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, r.ReadMsg(&pb.Response{}))
+	control.Close()
+
+	require.NoError(t, connect(c1, d2))
+
+	testprotos := []string{"/tracing-test"}
+	err = c2.NewStreamHandler(testprotos, func(info *p2pclient.StreamInfo, conn io.ReadWriteCloser) {
+		conn.Close()
+	})
+	require.NoError(t, err)
+	_, conn, err := c1.NewStream(d2.ID(), testprotos)
+	require.NoError(t, err)
+	// the handler closing the stream reaches us through the daemon's pipe
+	_, err = io.ReadAll(conn)
+	require.NoError(t, err)
+	conn.Close()
+	require.Eventually(t, func() bool {
+		return metricValue(t, "p2pd_stream_piped", map[string]string{"protocol": testprotos[0]}) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// a stream to a peer we aren't connected to, which is dialed first
+	err = c3.NewStreamHandler(testprotos, func(info *p2pclient.StreamInfo, conn io.ReadWriteCloser) {
+		conn.Close()
+	})
+	require.NoError(t, err)
+	record, err := c3.PeerRecord()
+	require.NoError(t, err)
+	_, _, err = c1.AddPeerRecord(record, 0)
+	require.NoError(t, err)
+	_, conn, err = c1.NewStream(d3.ID(), testprotos)
+	require.NoError(t, err)
+	conn.Close()
+
+	// the DHT traces the steps of queries, once d2 is in its routing table
+	require.Eventually(t, func() bool {
+		peers, err := c1.GetClosestPeers(ctx, []byte("tracing-test"))
+		if err != nil {
+			return false
+		}
+		for range peers {
+		}
+		return true
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// closing flushes the spans
+	closer1()
+	spans := readSpans(t, path)
+
+	identify := findSpan(spans, "p2pd.IDENTIFY", spanContext{})
+	require.NotNil(t, identify)
+	require.Equal(t, spanContext{TraceID: traceID, SpanID: spanID}, identify.Parent)
+	require.Equal(t, traceID, identify.SpanContext.TraceID)
+
+	connectSpan := findSpan(spans, "p2pd.CONNECT", spanContext{})
+	require.NotNil(t, connectSpan)
+	require.NotNil(t, findSpan(spans, "p2pd.dial", connectSpan.SpanContext))
+
+	require.NotNil(t, findChild(spans, "p2pd.negotiate", "p2pd.STREAM_OPEN"))
+	require.NotNil(t, findChild(spans, "p2pd.dial", "p2pd.STREAM_OPEN"))
+	require.NotNil(t, findChild(spans, "p2pd.stream.pipe", "p2pd.STREAM_OPEN"))
+
+	require.NotNil(t, findChild(spans, "KademliaDHT.IpfsDHT.GetClosestPeers", "p2pd.DHT"))
+}
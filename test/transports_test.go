@@ -0,0 +1,99 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/libp2p/go-libp2p"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	p2pd "github.com/libp2p/go-libp2p-daemon"
+	"github.com/libp2p/go-libp2p-daemon/config"
+	"github.com/libp2p/go-libp2p-daemon/p2pclient"
+)
+
+func createTransportDaemon(t *testing.T, ctx context.Context, tc config.Transports, listen libp2p.Option) (*p2pd.Daemon, *p2pclient.Client, func()) {
+	transports, err := p2pd.TransportOptions(tc)
+	require.NoError(t, err)
+	dmaddr, cmaddr, dirCloser := getEndpointsMaker(t)(t)
+	d, err := p2pd.NewDaemon(ctx, dmaddr, "", transports, listen)
+	require.NoError(t, err)
+	c, closeClient := createClient(t, d.Listener().Multiaddr(), cmaddr)
+	return d, c, func() {
+		closeClient()
+		d.Close()
+		dirCloser()
+	}
+}
+
+func onlyTransports(muxers ...string) config.Transports {
+	tc := config.NewDefaultConfig().Transports
+	tc.TCP.Enabled = false
+	tc.QUIC.Enabled = false
+	tc.WebSocket.Enabled = false
+	tc.WebTransport.Enabled = false
+	tc.WebRTC.Enabled = false
+	tc.Muxers = muxers
+	return tc
+}
+
+func TestTransports(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tcpMplex := onlyTransports(config.MuxerMplex)
+	tcpMplex.TCP.Enabled = true
+	tcpMplex.TCP.Reuseport = false
+	d1, c1, closer1 := createTransportDaemon(t, ctx, tcpMplex, p2pd.DefaultListenAddrs(tcpMplex))
+	defer closer1()
+
+	quicOnly := onlyTransports(config.MuxerYamux)
+	quicOnly.QUIC.Enabled = true
+	d2, _, closer2 := createTransportDaemon(t, ctx, quicOnly, p2pd.DefaultListenAddrs(quicOnly))
+	defer closer2()
+
+	tcpYamux := onlyTransports(config.MuxerYamux)
+	tcpYamux.TCP.Enabled = true
+	d3, _, closer3 := createTransportDaemon(t, ctx, tcpYamux, p2pd.DefaultListenAddrs(tcpYamux))
+	defer closer3()
+
+	tcpBoth := onlyTransports(config.MuxerYamux, config.MuxerMplex)
+	tcpBoth.TCP.Enabled = true
+	d4, _, closer4 := createTransportDaemon(t, ctx, tcpBoth, p2pd.DefaultListenAddrs(tcpBoth))
+	defer closer4()
+
+	// daemons only listen with their transports
+	for _, addr := range d1.Addrs() {
+		_, err := addr.ValueForProtocol(ma.P_TCP)
+		require.NoError(t, err, addr)
+	}
+	for _, addr := range d2.Addrs() {
+		_, err := addr.ValueForProtocol(ma.P_QUIC_V1)
+		require.NoError(t, err, addr)
+	}
+
+	// and only connect with peers sharing a transport and a muxer
+	require.Error(t, connect(c1, d2))
+	require.Error(t, connect(c1, d3))
+	require.NoError(t, connect(c1, d4))
+}
+
+func TestWebSocketTransport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tc := onlyTransports(config.MuxerYamux)
+	tc.WebSocket.Enabled = true
+	// WebSocket is only listened on when its addresses are given
+	d1, _, closer1 := createTransportDaemon(t, ctx, tc, libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0/ws"))
+	defer closer1()
+	for _, addr := range d1.Addrs() {
+		_, err := addr.ValueForProtocol(ma.P_WS)
+		require.NoError(t, err, addr)
+	}
+
+	_, c2, closer2 := createDaemonClientPair(t)
+	defer closer2()
+	require.NoError(t, connect(c2, d1))
+}
@@ -0,0 +1,158 @@
+package p2pd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/libp2p/go-libp2p-daemon/config"
+	pb "github.com/libp2p/go-libp2p-daemon/pb"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/libp2p/go-libp2p-daemon"
+
+var propagator = propagation.TraceContext{}
+
+// EnableTracing sets up a tracer provider of the daemon exporting the spans
+// of control requests and streams as configured by c. Pending spans are
+// flushed when the daemon is closed.
+//
+// The provider is also installed as the global one, through which libraries
+// such as the DHT start the spans of the steps of the daemon's requests. With
+// several daemons in a process, those go to the last one to enable tracing.
+func (d *Daemon) EnableTracing(c config.Tracing) error {
+	var opts []sdktrace.TracerProviderOption
+	var files []*os.File
+
+	if c.Endpoint != "" {
+		eopts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(c.Endpoint)}
+		if c.Insecure {
+			eopts = append(eopts, otlptracehttp.WithInsecure())
+		}
+		exp, err := otlptracehttp.New(d.ctx, eopts...)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	}
+
+	if c.File != "" {
+		f, err := os.OpenFile(c.File, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			return err
+		}
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+		files = append(files, f)
+	}
+
+	if len(opts) == 0 {
+		return fmt.Errorf("no endpoint or file to export spans to")
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName("p2pd"),
+		semconv.ServiceVersion(UserAgent()),
+	))
+	if err != nil {
+		return err
+	}
+
+	opts = append(opts,
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.SampleRatio))),
+	)
+	tp := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tp)
+
+	d.mx.Lock()
+	d.tracerProvider = tp
+	d.tracingShutdown = func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var merr *multierror.Error
+		if err := tp.Shutdown(ctx); err != nil {
+			merr = multierror.Append(merr, err)
+		}
+		for _, f := range files {
+			if err := f.Close(); err != nil {
+				merr = multierror.Append(merr, err)
+			}
+		}
+		return merr.ErrorOrNil()
+	}
+	d.mx.Unlock()
+
+	return nil
+}
+
+// tracer returns the tracer of the daemon's provider, if tracing is enabled,
+// or else of the global one.
+func (d *Daemon) tracer() trace.Tracer {
+	d.mx.Lock()
+	tp := d.tracerProvider
+	d.mx.Unlock()
+	if tp == nil {
+		return otel.Tracer(tracerName)
+	}
+	return tp.Tracer(tracerName)
+}
+
+// startRequestSpan starts the span of a control request, continuing the
+// client's trace if the request carries its context.
+func (d *Daemon) startRequestSpan(req *pb.Request) (context.Context, trace.Span) {
+	ctx := d.ctx
+	if req.Traceparent != nil {
+		carrier := propagation.MapCarrier{
+			"traceparent": req.GetTraceparent(),
+			"tracestate":  req.GetTracestate(),
+		}
+		ctx = propagator.Extract(ctx, carrier)
+	}
+
+	attrs := []attribute.KeyValue{attribute.String("p2pd.request.type", req.GetType().String())}
+	if subtype := requestSubtype(req); subtype != "" {
+		attrs = append(attrs, attribute.String("p2pd.request.subtype", subtype))
+	}
+
+	return d.tracer().Start(ctx, "p2pd."+req.GetType().String(),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// endRequestSpan ends the span of a control request answered with res.
+func endRequestSpan(span trace.Span, res *pb.Response) {
+	if res.GetType() == pb.Response_ERROR {
+		span.SetStatus(codes.Error, res.GetError().GetMsg())
+	}
+	span.End()
+}
+
+// endSpan ends the span of a step, recording err if the step failed.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,86 @@
+package p2pd
+
+import (
+	"crypto/tls"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/p2p/muxer/yamux"
+	quic "github.com/libp2p/go-libp2p/p2p/transport/quic"
+	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
+	libp2pwebrtc "github.com/libp2p/go-libp2p/p2p/transport/webrtc"
+	"github.com/libp2p/go-libp2p/p2p/transport/websocket"
+	webtransport "github.com/libp2p/go-libp2p/p2p/transport/webtransport"
+
+	"github.com/libp2p/go-libp2p-daemon/config"
+	mplex "github.com/libp2p/go-libp2p-mplex"
+)
+
+// TransportOptions returns the options enabling the transports of c, and
+// offering its muxers in order of preference.
+func TransportOptions(c config.Transports) (libp2p.Option, error) {
+	var opts []libp2p.Option
+
+	if c.TCP.Enabled {
+		var tcpOpts []interface{}
+		if !c.TCP.Reuseport {
+			tcpOpts = append(tcpOpts, tcp.DisableReuseport())
+		}
+		if c.TCP.ConnectTimeout > 0 {
+			tcpOpts = append(tcpOpts, tcp.WithConnectionTimeout(c.TCP.ConnectTimeout))
+		}
+		opts = append(opts, libp2p.Transport(tcp.NewTCPTransport, tcpOpts...))
+	}
+	if c.QUIC.Enabled {
+		opts = append(opts, libp2p.Transport(quic.NewTransport))
+	}
+	if c.WebSocket.Enabled {
+		var wsOpts []interface{}
+		if c.WebSocket.TLSCert != "" {
+			cert, err := tls.LoadX509KeyPair(c.WebSocket.TLSCert, c.WebSocket.TLSKey)
+			if err != nil {
+				return nil, err
+			}
+			wsOpts = append(wsOpts, websocket.WithTLSConfig(&tls.Config{Certificates: []tls.Certificate{cert}}))
+		}
+		opts = append(opts, libp2p.Transport(websocket.New, wsOpts...))
+	}
+	if c.WebTransport.Enabled {
+		opts = append(opts, libp2p.Transport(webtransport.New))
+	}
+	if c.WebRTC.Enabled {
+		opts = append(opts, libp2p.Transport(libp2pwebrtc.New))
+	}
+
+	for _, m := range c.Muxers {
+		switch m {
+		case config.MuxerYamux:
+			opts = append(opts, libp2p.Muxer(yamux.ID, yamux.DefaultTransport))
+		case config.MuxerMplex:
+			opts = append(opts, libp2p.Muxer(mplex.ID, mplex.DefaultTransport))
+		}
+	}
+
+	return libp2p.ChainOptions(opts...), nil
+}
+
+// DefaultListenAddrs returns the option listening on all interfaces, on a
+// random port, with the transports of c. Like libp2p, WebSocket is only
+// listened on when its addresses are given.
+func DefaultListenAddrs(c config.Transports) libp2p.Option {
+	var addrs []string
+	for _, ip := range []string{"/ip4/0.0.0.0", "/ip6/::"} {
+		if c.TCP.Enabled {
+			addrs = append(addrs, ip+"/tcp/0")
+		}
+		if c.QUIC.Enabled {
+			addrs = append(addrs, ip+"/udp/0/quic-v1")
+		}
+		if c.WebTransport.Enabled {
+			addrs = append(addrs, ip+"/udp/0/quic-v1/webtransport")
+		}
+		if c.WebRTC.Enabled {
+			addrs = append(addrs, ip+"/udp/0/webrtc-direct")
+		}
+	}
+	return libp2p.ListenAddrStrings(addrs...)
+}
@@ -3,7 +3,6 @@
 package p2pd
 
 import (
-	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
@@ -32,36 +31,7 @@ func (d *Daemon) trapSignals() {
 
 func (d *Daemon) handleSIGUSR1() {
 	// this is our signal to dump diagnostics info.
-	if d.dht != nil {
-		fmt.Println("DHT Routing Table:")
-		d.dht.RoutingTable().Print()
-		fmt.Println()
-		fmt.Println()
-	}
-
-	conns := d.host.Network().Conns()
-	fmt.Printf("Connections and streams (%d):\n", len(conns))
-
-	for _, c := range conns {
-		protos, _ := d.host.Peerstore().GetProtocols(c.RemotePeer()) // error value here is useless
-
-		protoVersion, err := d.host.Peerstore().Get(c.RemotePeer(), "ProtocolVersion")
-		if err != nil {
-			protoVersion = "(unknown)"
-		}
-
-		agent, err := d.host.Peerstore().Get(c.RemotePeer(), "AgentVersion")
-		if err != nil {
-			agent = "(unknown)"
-		}
-
-		streams := c.GetStreams()
-		fmt.Printf("peer: %s, multiaddr: %s\n", c.RemotePeer().String(), c.RemoteMultiaddr())
-		fmt.Printf("\tprotoVersion: %s, agent: %s\n", protoVersion, agent)
-		fmt.Printf("\tprotocols: %v\n", protos)
-		fmt.Printf("\tstreams (%d):\n", len(streams))
-		for _, s := range streams {
-			fmt.Println("\t\tprotocol: ", s.Protocol())
-		}
+	if err := d.dumpDiagnostics(); err != nil {
+		log.Warnw("error dumping diagnostics", "error", err)
 	}
 }